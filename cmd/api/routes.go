@@ -5,7 +5,7 @@ import (
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
-	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"test-http/internal/config"
@@ -13,43 +13,187 @@ import (
 	"test-http/internal/handlers"
 	"test-http/internal/middleware"
 	"test-http/internal/service"
+	"test-http/pkg/cursor"
+	"test-http/pkg/health"
+	"test-http/pkg/jwt"
 )
 
-func RegisterRoutes(r chi.Router, dbPool *pgxpool.Pool, cfg *config.Config, logger *slog.Logger) {
-	validate := validator.New()
+func RegisterRoutes(r chi.Router, dbPool *pgxpool.Pool, migrator *db.Migrator, cfg *config.Config, logger *slog.Logger) {
+	validate := handlers.NewValidator()
 
 	userRepo := db.New(dbPool)
-	userService := service.NewUserService(userRepo, logger)
-	userHandler := handlers.NewUserHandler(logger, userService)
+	txRunner := db.NewTxRunner(dbPool, pgx.TxOptions{}, cfg.PoolConfig.TxMaxAttempts)
+	// Game and progress writes read a session or progress row and write back what they
+	// derived from it. Under REPEATABLE READ a concurrent change to those rows aborts the
+	// transaction with a serialization failure, which the runner replays on fresh data.
+	progressTxRunner := db.NewTxRunner(dbPool, pgx.TxOptions{IsoLevel: pgx.RepeatableRead}, cfg.PoolConfig.TxMaxAttempts)
+	// The cursor key is derived from the JWT secret, never used as is; see cursor.NewCodec.
+	cursors := cursor.NewCodec([]byte(cfg.Auth.JWTSecret))
+	userService := service.NewUserService(userRepo, cfg.Users.RestorePeriod, cursors, logger)
+	userHandler := handlers.NewUserHandler(userService, validate, logger)
 
-	userStatisticsService := service.NewUserStatisticsService(userRepo, logger)
+	userStatisticsService := service.NewUserStatisticsService(userRepo, cursors, logger)
 	statisticsHandler := handlers.NewStatisticsHandler(userStatisticsService, validate, logger)
 
-	readyHandler := handlers.NewReadyHandler(dbPool, cfg, logger)
+	wordService := service.NewWordService(userRepo, logger)
+	wordHandler := handlers.NewWordHandler(wordService, validate, logger)
+
+	wordSetService := service.NewWordSetService(userRepo, txRunner, logger)
+	wordSetHandler := handlers.NewWordSetHandler(wordSetService, validate, logger)
+
+	userSessionService := service.NewUserSessionService(userRepo, txRunner, cfg.Sessions.ActivityGap, cursors, logger)
+	userSessionHandler := handlers.NewUserSessionHandler(userSessionService, validate, logger)
+
+	userProgressService := service.NewUserProgressService(userRepo, progressTxRunner, cursors, logger)
+	userProgressHandler := handlers.NewUserProgressHandler(userProgressService, validate, logger)
+
+	progressImportService := service.NewProgressImportService(txRunner, cfg.Sessions.IdleTimeout, cfg.Sessions.ActivityGap, logger)
+	progressImportHandler := handlers.NewProgressImportHandler(progressImportService, validate, cfg.Imports.MaxFileSize, logger)
+
+	userExportService := service.NewUserExportService(userRepo, userRepo, userRepo, userRepo, userRepo, logger)
+	userExportHandler := handlers.NewUserExportHandler(userExportService, validate, logger)
+
+	userWordSetService := service.NewUserWordSetService(userRepo, userRepo, logger)
+	userWordSetHandler := handlers.NewUserWordSetHandler(userWordSetService, validate, logger)
+
+	gameService := service.NewGameService(userRepo, userRepo, userRepo, userRepo, progressTxRunner, cfg.Sessions.ActivityGap, logger)
+	gameHandler := handlers.NewGameHandler(gameService, validate, logger)
+
+	importService := service.NewImportService(userRepo, txRunner, validate, cfg.Imports.Lease, cfg.Imports.BatchSize, logger)
+	importHandler := handlers.NewImportHandler(importService, validate, cfg.Imports.MaxFileSize, logger)
+
+	reviewService := service.NewReviewService(userRepo, logger)
+	reviewHandler := handlers.NewReviewHandler(reviewService, validate, logger)
+
+	signer := jwt.NewSigner([]byte(cfg.Auth.JWTSecret), cfg.Auth.Issuer, cfg.Auth.AccessTokenTTL)
+	authService := service.NewAuthService(userRepo, signer, cfg.Auth.RefreshTokenTTL, logger)
+	authHandler := handlers.NewAuthHandler(authService, validate, logger)
+
+	healthChecks := health.NewRegistry()
+	healthChecks.Register("database", cfg.Health.CheckTimeout, health.Ping(dbPool), health.Readiness, health.Startup)
+	healthChecks.Register("pool", cfg.Health.CheckTimeout, health.PoolSaturation(dbPool, cfg.Health.PoolSaturation), health.Readiness)
+	healthChecks.Register("schema", cfg.Health.CheckTimeout, health.Schema(migrator), health.Readiness, health.Startup)
+	healthHandler := handlers.NewHealthHandler(healthChecks, cfg.Health.Verbose, logger)
 
 	r.Use(middleware.TraceID)
 	r.Use(middleware.Recover(logger))
 	r.Use(middleware.RequestLogger(logger))
 
 	r.Route("/api/v1", func(r chi.Router) {
-		// --- Users ---
-		r.Route("/users", func(r chi.Router) {
-			r.Post("/", func(w http.ResponseWriter, r *http.Request) { _ = userHandler.CreateUser(w, r) })
-			r.Get("/{id}", func(w http.ResponseWriter, r *http.Request) { _ = userHandler.GetUser(w, r) })
-			r.Get("/email/{email}", func(w http.ResponseWriter, r *http.Request) { _ = userHandler.UserEmail(w, r) })
-			r.Put("/{id}", func(w http.ResponseWriter, r *http.Request) { _ = userHandler.UpdateUser(w, r) })
-			r.Delete("/{id}", func(w http.ResponseWriter, r *http.Request) { _ = userHandler.DeleteUser(w, r) })
+		// --- Auth ---
+		r.Route("/auth", func(r chi.Router) {
+			r.Post("/register", func(w http.ResponseWriter, r *http.Request) { _ = authHandler.Register(w, r) })
+			r.Post("/login", func(w http.ResponseWriter, r *http.Request) { _ = authHandler.Login(w, r) })
+			r.Post("/refresh", func(w http.ResponseWriter, r *http.Request) { _ = authHandler.Refresh(w, r) })
+			r.Post("/logout", func(w http.ResponseWriter, r *http.Request) { _ = authHandler.Logout(w, r) })
 		})
 
-		// --- Statistics ---
-		r.Route("/statistics", func(r chi.Router) {
-			r.Post("/", func(w http.ResponseWriter, r *http.Request) { _ = statisticsHandler.CreateStatistics(w, r) })
-			r.Get("/{user_id}", func(w http.ResponseWriter, r *http.Request) { _ = statisticsHandler.GetStatistics(w, r) })
-			r.Put("/{user_id}", func(w http.ResponseWriter, r *http.Request) { _ = statisticsHandler.UpdateStatistics(w, r) })
-			r.Delete("/{user_id}", func(w http.ResponseWriter, r *http.Request) { _ = statisticsHandler.DeleteStatistics(w, r) })
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.Authenticate(signer, authService, logger))
+
+			// --- Users ---
+			r.Route("/users", func(r chi.Router) {
+				r.With(middleware.RequireAdmin).Get("/", func(w http.ResponseWriter, r *http.Request) { _ = userHandler.ListUsers(w, r) })
+				r.With(middleware.RequireAdmin).Post("/", func(w http.ResponseWriter, r *http.Request) { _ = userHandler.CreateUser(w, r) })
+				r.With(middleware.RequireAdmin).Get("/email/{email}", func(w http.ResponseWriter, r *http.Request) { _ = userHandler.UserEmail(w, r) })
+
+				r.Route("/{id}", func(r chi.Router) {
+					r.Use(middleware.RequireSelf("id"))
+
+					r.Get("/", func(w http.ResponseWriter, r *http.Request) { _ = userHandler.GetUser(w, r) })
+					r.Put("/", func(w http.ResponseWriter, r *http.Request) { _ = userHandler.UpdateUser(w, r) })
+					r.Delete("/", func(w http.ResponseWriter, r *http.Request) { _ = userHandler.DeleteUser(w, r) })
+					r.Get("/export", func(w http.ResponseWriter, r *http.Request) { _ = userExportHandler.ExportUser(w, r) })
+					r.Post("/deactivate", func(w http.ResponseWriter, r *http.Request) { _ = userHandler.DeactivateUser(w, r) })
+					r.With(middleware.RequireAdmin).Post("/reactivate", func(w http.ResponseWriter, r *http.Request) { _ = userHandler.ReactivateUser(w, r) })
+					r.With(middleware.RequireAdmin).Post("/restore", func(w http.ResponseWriter, r *http.Request) { _ = userHandler.RestoreUser(w, r) })
+					r.Get("/reviews/due", func(w http.ResponseWriter, r *http.Request) { _ = reviewHandler.DueReviews(w, r) })
+
+					r.Route("/sessions", func(r chi.Router) {
+						r.Get("/", func(w http.ResponseWriter, r *http.Request) { _ = userSessionHandler.ListSessions(w, r) })
+						r.Post("/", func(w http.ResponseWriter, r *http.Request) { _ = userSessionHandler.CreateSession(w, r) })
+						r.Get("/active", func(w http.ResponseWriter, r *http.Request) { _ = userSessionHandler.ListActiveSessions(w, r) })
+						r.Get("/{session_id}", func(w http.ResponseWriter, r *http.Request) { _ = userSessionHandler.GetSession(w, r) })
+						r.Put("/{session_id}", func(w http.ResponseWriter, r *http.Request) { _ = userSessionHandler.UpdateSession(w, r) })
+						r.Delete("/{session_id}", func(w http.ResponseWriter, r *http.Request) { _ = userSessionHandler.DeleteSession(w, r) })
+						r.Post("/{session_id}/heartbeat", func(w http.ResponseWriter, r *http.Request) { _ = userSessionHandler.Heartbeat(w, r) })
+						r.Post("/{session_id}/events", func(w http.ResponseWriter, r *http.Request) { _ = userSessionHandler.RecordEvent(w, r) })
+					})
+
+					r.Route("/progress", func(r chi.Router) {
+						r.Get("/", func(w http.ResponseWriter, r *http.Request) { _ = userProgressHandler.ListProgress(w, r) })
+						r.Post("/", func(w http.ResponseWriter, r *http.Request) { _ = userProgressHandler.CreateProgress(w, r) })
+						r.Post("/attempts", func(w http.ResponseWriter, r *http.Request) { _ = userProgressHandler.RecordAttempts(w, r) })
+						r.Post("/import", func(w http.ResponseWriter, r *http.Request) { _ = progressImportHandler.ImportProgress(w, r) })
+						r.Get("/words/{word_id}", func(w http.ResponseWriter, r *http.Request) { _ = userProgressHandler.GetWordProgress(w, r) })
+						r.Get("/{progress_id}", func(w http.ResponseWriter, r *http.Request) { _ = userProgressHandler.GetProgress(w, r) })
+						r.Put("/{progress_id}", func(w http.ResponseWriter, r *http.Request) { _ = userProgressHandler.UpdateProgress(w, r) })
+						r.Delete("/{progress_id}", func(w http.ResponseWriter, r *http.Request) { _ = userProgressHandler.DeleteProgress(w, r) })
+					})
+
+					r.Route("/word-sets", func(r chi.Router) {
+						r.Get("/", func(w http.ResponseWriter, r *http.Request) { _ = userWordSetHandler.ListSubscriptions(w, r) })
+						r.Post("/", func(w http.ResponseWriter, r *http.Request) { _ = userWordSetHandler.Subscribe(w, r) })
+						r.Get("/{subscription_id}", func(w http.ResponseWriter, r *http.Request) { _ = userWordSetHandler.GetSubscription(w, r) })
+						r.Put("/{subscription_id}", func(w http.ResponseWriter, r *http.Request) { _ = userWordSetHandler.UpdateSubscription(w, r) })
+						r.Delete("/{subscription_id}", func(w http.ResponseWriter, r *http.Request) { _ = userWordSetHandler.Unsubscribe(w, r) })
+					})
+				})
+			})
+
+			// --- Statistics ---
+			r.With(middleware.RequireAdmin).Get("/statistics", func(w http.ResponseWriter, r *http.Request) { _ = statisticsHandler.ListStatistics(w, r) })
+			r.Route("/statistics/{user_id}", func(r chi.Router) {
+				r.Use(middleware.RequireSelf("user_id"))
+
+				r.Get("/", func(w http.ResponseWriter, r *http.Request) { _ = statisticsHandler.GetStatistics(w, r) })
+				r.Put("/", func(w http.ResponseWriter, r *http.Request) { _ = statisticsHandler.RecomputeStatistics(w, r) })
+			})
+
+			// --- Words ---
+			r.Route("/words", func(r chi.Router) {
+				r.Get("/", func(w http.ResponseWriter, r *http.Request) { _ = wordHandler.SearchWords(w, r) })
+				r.With(middleware.RequireAdmin).Post("/", func(w http.ResponseWriter, r *http.Request) { _ = wordHandler.CreateWord(w, r) })
+				r.Post("/resolve", func(w http.ResponseWriter, r *http.Request) { _ = wordHandler.ResolveWords(w, r) })
+				r.Get("/{id}", func(w http.ResponseWriter, r *http.Request) { _ = wordHandler.GetWord(w, r) })
+				r.With(middleware.RequireAdmin).Put("/{id}", func(w http.ResponseWriter, r *http.Request) { _ = wordHandler.UpdateWord(w, r) })
+				r.With(middleware.RequireAdmin).Delete("/{id}", func(w http.ResponseWriter, r *http.Request) { _ = wordHandler.DeleteWord(w, r) })
+			})
+
+			// --- Word sets ---
+			r.Route("/word-sets", func(r chi.Router) {
+				r.Get("/", func(w http.ResponseWriter, r *http.Request) { _ = wordSetHandler.ListWordSets(w, r) })
+				r.Post("/", func(w http.ResponseWriter, r *http.Request) { _ = wordSetHandler.CreateWordSet(w, r) })
+				r.Get("/{id}", func(w http.ResponseWriter, r *http.Request) { _ = wordSetHandler.GetWordSet(w, r) })
+				r.Put("/{id}", func(w http.ResponseWriter, r *http.Request) { _ = wordSetHandler.UpdateWordSet(w, r) })
+				r.Delete("/{id}", func(w http.ResponseWriter, r *http.Request) { _ = wordSetHandler.DeleteWordSet(w, r) })
+				r.Get("/{id}/words", func(w http.ResponseWriter, r *http.Request) { _ = wordSetHandler.ListWords(w, r) })
+				r.Post("/{id}/words", func(w http.ResponseWriter, r *http.Request) { _ = wordSetHandler.AddWord(w, r) })
+				r.Put("/{id}/words/order", func(w http.ResponseWriter, r *http.Request) { _ = wordSetHandler.ReorderWords(w, r) })
+				r.Delete("/{id}/words/{word_id}", func(w http.ResponseWriter, r *http.Request) { _ = wordSetHandler.RemoveWord(w, r) })
+			})
+
+			// --- Imports ---
+			r.Route("/imports", func(r chi.Router) {
+				r.Use(middleware.RequireAdmin)
+
+				r.Post("/", func(w http.ResponseWriter, r *http.Request) { _ = importHandler.CreateImport(w, r) })
+				r.Get("/{id}", func(w http.ResponseWriter, r *http.Request) { _ = importHandler.GetImport(w, r) })
+				r.Get("/{id}/errors", func(w http.ResponseWriter, r *http.Request) { _ = importHandler.ListImportErrors(w, r) })
+				r.Post("/{id}/retry", func(w http.ResponseWriter, r *http.Request) { _ = importHandler.RetryImport(w, r) })
+			})
+
+			// --- Games ---
+			r.Route("/games", func(r chi.Router) {
+				r.Post("/", func(w http.ResponseWriter, r *http.Request) { _ = gameHandler.StartGame(w, r) })
+				r.Get("/{session_id}/question", func(w http.ResponseWriter, r *http.Request) { _ = gameHandler.NextQuestion(w, r) })
+				r.Post("/{session_id}/answers", func(w http.ResponseWriter, r *http.Request) { _ = gameHandler.AnswerQuestion(w, r) })
+				r.Post("/{session_id}/finish", func(w http.ResponseWriter, r *http.Request) { _ = gameHandler.FinishGame(w, r) })
+			})
 		})
 	})
 
-	// --- Health Check ---
-	r.Get("/readyz", func(w http.ResponseWriter, r *http.Request) { _ = readyHandler.ReadyzHandler(w, r) })
+	r.Get("/livez", func(w http.ResponseWriter, r *http.Request) { _ = healthHandler.LivezHandler(w, r) })
+	r.Get("/readyz", func(w http.ResponseWriter, r *http.Request) { _ = healthHandler.ReadyzHandler(w, r) })
+	r.Get("/startupz", func(w http.ResponseWriter, r *http.Request) { _ = healthHandler.StartupzHandler(w, r) })
 }
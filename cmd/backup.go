@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"test-http/internal/db"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// runBackup dumps the database to -o. The archive is written next to the target
+// and renamed into place, so an interrupted backup never leaves a partial file behind.
+func runBackup(dbPool *pgxpool.Pool, log *slog.Logger, args []string) error {
+	flags := flag.NewFlagSet("backup", flag.ExitOnError)
+	out := flags.String("o", "", "archive `file` to write")
+	_ = flags.Parse(args)
+	if *out == "" {
+		flags.Usage()
+		return errors.New("backup: -o is required")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	tmp, err := os.CreateTemp(filepath.Dir(*out), filepath.Base(*out)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	header, err := db.Backup(ctx, dbPool, tmp)
+	if err != nil {
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), *out); err != nil {
+		return err
+	}
+
+	log.Info("Backup written",
+		slog.String("file", *out),
+		slog.Int64("schema_version", header.SchemaVersion),
+		slog.Int("tables", len(header.Tables)),
+	)
+	return nil
+}
+
+// runRestore loads the archive at -i ("-" reads stdin) into the database.
+func runRestore(dbPool *pgxpool.Pool, log *slog.Logger, args []string) error {
+	flags := flag.NewFlagSet("restore", flag.ExitOnError)
+	in := flags.String("i", "", "archive `file` to read, - for stdin")
+	replace := flags.Bool("replace", false, "truncate the application tables before loading")
+	_ = flags.Parse(args)
+	if *in == "" {
+		flags.Usage()
+		return errors.New("restore: -i is required")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	var src io.Reader = os.Stdin
+	if *in != "-" {
+		f, err := os.Open(*in)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		src = f
+	}
+
+	header, err := db.Restore(ctx, dbPool, src, *replace)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Backup restored",
+		slog.String("file", *in),
+		slog.Int64("schema_version", header.SchemaVersion),
+		slog.Time("created_at", header.CreatedAt),
+		slog.Bool("replace", *replace),
+	)
+	return nil
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"test-http/internal/config"
+	"test-http/internal/db"
+	"test-http/internal/dto"
+	"test-http/internal/handlers"
+	"test-http/internal/service"
+	errorsPkg "test-http/pkg/errors_pkg"
+	"test-http/pkg/fault"
+	"test-http/pkg/wordimport"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// runImport queues the word list in -f and runs the job in the foreground, or with
+// -resume picks an interrupted or failed job up after its last committed batch. The
+// job is the same one the API creates, so its progress can be polled over HTTP too.
+func runImport(cfg *config.Config, dbPool *pgxpool.Pool, log *slog.Logger, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	file := flags.String("f", "", "word list `file` to import")
+	format := flags.String("format", "", "csv, tsv, anki_text or apkg (default: from the file extension)")
+	source := flags.String("source", "", "source language `code` of the lemmas")
+	target := flags.String("target", "", "target language `code` of the translations")
+	setTitle := flags.String("set", "", "add the words to a new word set with this `title`")
+	setID := flags.String("set-id", "", "add the words to the existing word set with this `id`")
+	resume := flags.String("resume", "", "resume the import job with this `id`")
+	_ = flags.Parse(args)
+	if (*file == "") == (*resume == "") {
+		flags.Usage()
+		return errors.New("import: exactly one of -f and -resume is required")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	validate := handlers.NewValidator()
+	txRunner := db.NewTxRunner(dbPool, pgx.TxOptions{}, cfg.PoolConfig.TxMaxAttempts)
+	imports := service.NewImportService(db.New(dbPool), txRunner, validate, cfg.Imports.Lease, cfg.Imports.BatchSize, log)
+
+	var id pgtype.UUID
+	if *resume != "" {
+		parsed, err := uuid.Parse(*resume)
+		if err != nil {
+			return fmt.Errorf("import: -resume: %w", err)
+		}
+		id = pgtype.UUID{Bytes: parsed, Valid: true}
+
+		// A failed job has to be queued again; an interrupted one is still running
+		// and is claimed below once its lease has run out.
+		if _, err := imports.Retry(ctx, dto.RetryImportRequest{ID: id}); err != nil {
+			if f := fault.HandleErr(err); f.Code != string(errorsPkg.ImportJobNotRetryable) {
+				return importError(err)
+			}
+		}
+	} else {
+		data, err := os.ReadFile(*file)
+		if err != nil {
+			return err
+		}
+		req := dto.CreateImportRequest{
+			Filename:     filepath.Base(*file),
+			Format:       *format,
+			SourceLang:   *source,
+			TargetLang:   *target,
+			WordSetTitle: *setTitle,
+			Data:         data,
+		}
+		if req.Format == "" {
+			req.Format = string(wordimport.DetectFormat(*file))
+		}
+		if *setID != "" {
+			parsed, err := uuid.Parse(*setID)
+			if err != nil {
+				return fmt.Errorf("import: -set-id: %w", err)
+			}
+			req.WordSetID = pgtype.UUID{Bytes: parsed, Valid: true}
+		}
+		if err := validate.Struct(req); err != nil {
+			return fmt.Errorf("import: %w", err)
+		}
+
+		job, err := imports.Create(ctx, req)
+		if err != nil {
+			return importError(err)
+		}
+		id = job.ID
+	}
+
+	job, ok, err := imports.RunNext(ctx, id)
+	if err != nil {
+		return importError(err)
+	}
+	if !ok {
+		return fmt.Errorf("import: job %s is not waiting to run: it has finished or another runner holds its lease", id)
+	}
+
+	log.Info("Import finished",
+		slog.String("import_id", job.ID.String()),
+		slog.String("status", job.Status),
+		slog.Int("total_rows", int(job.TotalRows)),
+		slog.Int("created_words", int(job.CreatedWords)),
+		slog.Int("existing_words", int(job.ExistingWords)),
+		slog.Int("failed_rows", int(job.FailedRows)),
+	)
+	if job.Status != service.ImportStatusCompleted {
+		code := ""
+		if job.ErrorCode != nil {
+			code = *job.ErrorCode
+		}
+		return fmt.Errorf("import: job %s %s: %s", job.ID, job.Status, code)
+	}
+	return nil
+}
+
+// runImportProgress replays the review log in -f into the progress of -user and
+// prints the report as JSON. With -dry-run nothing is written, so unmatched words
+// can be fixed in the catalog first.
+func runImportProgress(cfg *config.Config, dbPool *pgxpool.Pool, log *slog.Logger, args []string) error {
+	flags := flag.NewFlagSet("import-progress", flag.ExitOnError)
+	file := flags.String("f", "", "review log `file` with word, timestamp and result columns")
+	user := flags.String("user", "", "`id` of the user whose progress is imported")
+	source := flags.String("source", "", "source language `code` of the words")
+	target := flags.String("target", "", "target language `code` of the words")
+	dryRun := flags.Bool("dry-run", false, "report what would be imported without writing anything")
+	_ = flags.Parse(args)
+	if *file == "" || *user == "" {
+		flags.Usage()
+		return errors.New("import-progress: -f and -user are required")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	userID, err := uuid.Parse(*user)
+	if err != nil {
+		return fmt.Errorf("import-progress: -user: %w", err)
+	}
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		return err
+	}
+	req := dto.ImportProgressRequest{
+		UserID:     pgtype.UUID{Bytes: userID, Valid: true},
+		SourceLang: *source,
+		TargetLang: *target,
+		DryRun:     *dryRun,
+		Data:       data,
+	}
+	if err := handlers.NewValidator().Struct(req); err != nil {
+		return fmt.Errorf("import-progress: %w", err)
+	}
+
+	txRunner := db.NewTxRunner(dbPool, pgx.TxOptions{}, cfg.PoolConfig.TxMaxAttempts)
+	importer := service.NewProgressImportService(txRunner, cfg.Sessions.IdleTimeout, cfg.Sessions.ActivityGap, log)
+	report, err := importer.Import(ctx, req)
+	if err != nil {
+		return importError(err)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// importError spells out a service fault, whose Error() is only its code.
+func importError(err error) error {
+	var f *fault.Fault
+	if !errors.As(err, &f) {
+		return err
+	}
+	if len(f.Args) == 0 {
+		return fmt.Errorf("import: %s", f.Code)
+	}
+	return fmt.Errorf("import: %s %v", f.Code, f.Args)
+}
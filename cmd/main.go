@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
@@ -9,15 +10,41 @@ import (
 	"syscall"
 	routes "test-http/cmd/api"
 	"test-http/internal/config"
+	"test-http/internal/db"
+	"test-http/internal/handlers"
+	"test-http/internal/service"
 
 	pool "test-http/pkg/db"
 	"test-http/pkg/logger"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
 )
 
+const usage = `usage: test-http [command] [flags]
+
+commands:
+  serve            run the HTTP API (default)
+  backup           dump all application tables to an archive
+  restore          load an archive written by backup
+  import           import words from a CSV, TSV or Anki file
+  import-progress  replay a review log from another app into a user's progress
+  migrate          apply, roll back or list schema migrations (up|down|status|redo)
+`
+
 func main() {
+	command, args := "serve", os.Args[1:]
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
+	}
+	switch command {
+	case "serve", "backup", "restore", "import", "import-progress", "migrate":
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
 
 	if err := godotenv.Load(); err != nil {
 		panic("Error loading .env file")
@@ -41,9 +68,53 @@ func main() {
 	}
 	defer dbPool.Close()
 
+	switch command {
+	case "serve":
+		err = serve(cfg, log, dbPool)
+	case "backup":
+		err = runBackup(dbPool, log, args)
+	case "restore":
+		err = runRestore(dbPool, log, args)
+	case "import":
+		err = runImport(cfg, dbPool, log, args)
+	case "import-progress":
+		err = runImportProgress(cfg, dbPool, log, args)
+	case "migrate":
+		err = runMigrate(dbPool, log, args)
+	}
+	if err != nil {
+		log.Error("Command failed", slog.String("command", command), slog.String("error", err.Error()))
+		dbPool.Close()
+		os.Exit(1)
+	}
+}
+
+func serve(cfg *config.Config, log *slog.Logger, dbPool *pgxpool.Pool) error {
+	migrator, err := db.NewMigrator(dbPool, log)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+	if cfg.Migrations.OnStart {
+		// Blocks until replicas booting alongside have finished their own run.
+		if _, err := migrator.Up(context.Background()); err != nil {
+			return fmt.Errorf("migrate on start: %w", err)
+		}
+	}
+
 	r := chi.NewRouter()
 
-	routes.RegisterRoutes(r, dbPool, cfg, log)
+	routes.RegisterRoutes(r, dbPool, migrator, cfg, log)
+
+	reaperCtx, stopReaper := context.WithCancel(context.Background())
+	defer stopReaper()
+	reaper := service.NewSessionReaper(db.New(dbPool), cfg.Sessions.IdleTimeout, log)
+	go reaper.Run(reaperCtx, cfg.Sessions.ReapInterval)
+	purger := service.NewUserPurger(db.New(dbPool), cfg.Users.RestorePeriod, log)
+	go purger.Run(reaperCtx, cfg.Users.PurgeInterval)
+	importer := service.NewImportService(db.New(dbPool), db.NewTxRunner(dbPool, pgx.TxOptions{}, cfg.PoolConfig.TxMaxAttempts),
+		handlers.NewValidator(), cfg.Imports.Lease, cfg.Imports.BatchSize, log)
+	go importer.Run(reaperCtx, cfg.Imports.PollInterval)
 
 	srv := &http.Server{
 		Addr:         cfg.Address(),
@@ -66,6 +137,7 @@ func main() {
 
 	<-done
 	log.Info("Shutdown signal received")
+	stopReaper()
 
 	ctx, cancel := context.WithTimeout(context.Background(), cfg.TimeOuts.ShutdownTimeout)
 	defer cancel()
@@ -75,5 +147,5 @@ func main() {
 	} else {
 		log.Info("Graceful shutdown completed")
 	}
-
+	return nil
 }
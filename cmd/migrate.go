@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"test-http/internal/db"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pressly/goose/v3"
+)
+
+const migrateUsage = "usage: test-http migrate up|down|status|redo"
+
+// runMigrate applies, rolls back or lists the migrations embedded in the binary.
+func runMigrate(dbPool *pgxpool.Pool, log *slog.Logger, args []string) error {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return errors.New("migrate: exactly one action is required")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	migrator, err := db.NewMigrator(dbPool, log)
+	if err != nil {
+		return fmt.Errorf("migrate: %w", err)
+	}
+	defer migrator.Close()
+
+	var results []*goose.MigrationResult
+	switch args[0] {
+	case "up":
+		results, err = migrator.Up(ctx)
+	case "down":
+		var result *goose.MigrationResult
+		if result, err = migrator.Down(ctx); result != nil {
+			results = append(results, result)
+		}
+	case "redo":
+		results, err = migrator.Redo(ctx)
+	case "status":
+		return printMigrationStatus(ctx, migrator)
+	default:
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return fmt.Errorf("migrate: unknown action %q", args[0])
+	}
+	if errors.Is(err, goose.ErrNoNextVersion) {
+		err = nil
+	}
+	if err != nil {
+		return fmt.Errorf("migrate %s: %w", args[0], err)
+	}
+
+	current, latest, err := migrator.Versions(ctx)
+	if err != nil {
+		return fmt.Errorf("migrate: %w", err)
+	}
+	log.Info("Migrations done",
+		slog.String("action", args[0]),
+		slog.Int("applied", len(results)),
+		slog.Int64("version", current),
+		slog.Int64("latest", latest),
+	)
+	return nil
+}
+
+func printMigrationStatus(ctx context.Context, migrator *db.Migrator) error {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return fmt.Errorf("migrate status: %w", err)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tSTATE\tAPPLIED AT\tFILE")
+	for _, s := range statuses {
+		applied := "-"
+		if s.State == goose.StateApplied {
+			applied = s.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", s.Source.Version, s.State, applied, s.Source.Path)
+	}
+	return w.Flush()
+}
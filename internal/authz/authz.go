@@ -0,0 +1,67 @@
+// Package authz decides whether the authenticated caller may touch a row.
+//
+// The rule is simple: users act on their own rows only, admins act on
+// everything. Checks fail closed, so a context without a principal is denied.
+package authz
+
+import (
+	"context"
+
+	errorsPkg "test-http/pkg/errors_pkg"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+type ctxKey struct{}
+
+// Principal is the authenticated caller.
+type Principal struct {
+	UserID pgtype.UUID
+	Role   string
+}
+
+func (p Principal) IsAdmin() bool {
+	return p.Role == RoleAdmin
+}
+
+// CanAccess reports whether p may act on rows owned by ownerID.
+func (p Principal) CanAccess(ownerID pgtype.UUID) bool {
+	if p.IsAdmin() {
+		return true
+	}
+	return p.UserID.Valid && ownerID.Valid && p.UserID == ownerID
+}
+
+// WithPrincipal returns a copy of ctx carrying p.
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, ctxKey{}, p)
+}
+
+// FromContext returns the principal stored in ctx, if any.
+func FromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(ctxKey{}).(Principal)
+	return p, ok && p.UserID.Valid
+}
+
+// RequireOwner returns a Forbidden fault unless the caller owns ownerID or is an admin.
+func RequireOwner(ctx context.Context, ownerID pgtype.UUID) error {
+	p, ok := FromContext(ctx)
+	if !ok || !p.CanAccess(ownerID) {
+		return errorsPkg.Forbidden.Err()
+	}
+	return nil
+}
+
+// RequireAdmin returns a Forbidden fault unless the caller is an admin.
+func RequireAdmin(ctx context.Context) error {
+	p, ok := FromContext(ctx)
+	if !ok || !p.IsAdmin() {
+		return errorsPkg.Forbidden.Err()
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package authz
+
+import (
+	"context"
+	"testing"
+
+	errorsPkg "test-http/pkg/errors_pkg"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestRequireOwner(t *testing.T) {
+	alice := pgtype.UUID{Bytes: [16]byte{1}, Valid: true}
+	bob := pgtype.UUID{Bytes: [16]byte{2}, Valid: true}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		owner   pgtype.UUID
+		allowed bool
+	}{
+		{"own row", WithPrincipal(context.Background(), Principal{UserID: alice, Role: RoleUser}), alice, true},
+		{"foreign row", WithPrincipal(context.Background(), Principal{UserID: alice, Role: RoleUser}), bob, false},
+		{"ownerless row", WithPrincipal(context.Background(), Principal{UserID: alice, Role: RoleUser}), pgtype.UUID{}, false},
+		{"admin", WithPrincipal(context.Background(), Principal{UserID: alice, Role: RoleAdmin}), bob, true},
+		{"anonymous", context.Background(), alice, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := RequireOwner(tt.ctx, tt.owner)
+			if tt.allowed && err != nil {
+				t.Fatalf("expected access, got %v", err)
+			}
+			if !tt.allowed && (err == nil || err.Error() != string(errorsPkg.Forbidden)) {
+				t.Fatalf("expected %s, got %v", errorsPkg.Forbidden, err)
+			}
+		})
+	}
+}
+
+func TestRequireAdmin(t *testing.T) {
+	id := pgtype.UUID{Bytes: [16]byte{1}, Valid: true}
+
+	if err := RequireAdmin(WithPrincipal(context.Background(), Principal{UserID: id, Role: RoleAdmin})); err != nil {
+		t.Fatalf("admin rejected: %v", err)
+	}
+	if err := RequireAdmin(WithPrincipal(context.Background(), Principal{UserID: id, Role: RoleUser})); err == nil {
+		t.Fatal("regular user accepted as admin")
+	}
+}
@@ -17,6 +17,12 @@ type Config struct {
 	App        AppConfig  `envPrefix:"APP_"`
 	PoolConfig PoolConfig `envPrefix:"POOL_"`
 	TimeOuts   TimeOuts   `envPrefix:"TIMEOUTS_"`
+	Auth       Auth       `envPrefix:"AUTH_"`
+	Sessions   Sessions   `envPrefix:"SESSIONS_"`
+	Users      Users      `envPrefix:"USERS_"`
+	Imports    Imports    `envPrefix:"IMPORTS_"`
+	Migrations Migrations `envPrefix:"MIGRATIONS_"`
+	Health     Health     `envPrefix:"HEALTH_"`
 }
 
 type HTTP struct {
@@ -52,12 +58,60 @@ type AppConfig struct {
 	Version string `env:"VERSION" envDefault:"1.0.0" validate:"required"`
 }
 
+type Auth struct {
+	JWTSecret       string        `env:"JWT_SECRET"        validate:"required,min=32"`
+	Issuer          string        `env:"ISSUER"            envDefault:"test-http" validate:"required"`
+	AccessTokenTTL  time.Duration `env:"ACCESS_TOKEN_TTL"  envDefault:"15m"  validate:"min=1m"`
+	RefreshTokenTTL time.Duration `env:"REFRESH_TOKEN_TTL" envDefault:"720h" validate:"min=1h"`
+}
+
+// Sessions controls how long an active session may stay idle before it is abandoned,
+// and the longest gap between two events that still counts as time on task.
+type Sessions struct {
+	IdleTimeout  time.Duration `env:"IDLE_TIMEOUT"  envDefault:"30m" validate:"min=1m"`
+	ReapInterval time.Duration `env:"REAP_INTERVAL" envDefault:"1m"  validate:"min=1s"`
+	ActivityGap  time.Duration `env:"ACTIVITY_GAP"  envDefault:"2m"  validate:"min=1s"`
+}
+
+// Users controls how long a deleted account can be restored before it is purged.
+type Users struct {
+	RestorePeriod time.Duration `env:"RESTORE_PERIOD" envDefault:"720h" validate:"min=1h"`
+	PurgeInterval time.Duration `env:"PURGE_INTERVAL" envDefault:"1h"   validate:"min=1m"`
+}
+
+// Imports controls the runner of word import jobs: how often it looks for queued jobs,
+// how many rows go into one transaction and how long a silent runner keeps its job.
+type Imports struct {
+	PollInterval time.Duration `env:"POLL_INTERVAL" envDefault:"5s"       validate:"min=1s"`
+	Lease        time.Duration `env:"LEASE"         envDefault:"2m"       validate:"min=10s"`
+	BatchSize    int           `env:"BATCH_SIZE"    envDefault:"500"      validate:"min=1,max=10000"`
+	MaxFileSize  int64         `env:"MAX_FILE_SIZE" envDefault:"20971520" validate:"min=1024"`
+}
+
+// Migrations controls whether serve applies pending migrations before it starts
+// listening. Replicas take turns through an advisory lock, so all of them may enable it.
+type Migrations struct {
+	OnStart bool `env:"ON_START" envDefault:"false"`
+}
+
+// Health controls the probe checks: how long each may take and the share of pool
+// connections in use at which a replica stops reporting ready. Verbose lets probes
+// answer ?verbose with check errors and details; the probes are unauthenticated, so
+// it stays off wherever they are reachable from outside the cluster.
+type Health struct {
+	CheckTimeout   time.Duration `env:"CHECK_TIMEOUT"   envDefault:"2s"  validate:"min=100ms"`
+	PoolSaturation float64       `env:"POOL_SATURATION" envDefault:"0.9" validate:"gt=0,lte=1"`
+	Verbose        bool          `env:"VERBOSE"         envDefault:"false"`
+}
+
 type PoolConfig struct {
 	MaxConns          int32         `env:"MAX_CONNS" envDefault:"16" validate:"min=1,max=100"`
 	MinConns          int32         `env:"MIN_CONNS" envDefault:"4" validate:"min=1,max=100"`
 	MaxConnLifetime   time.Duration `env:"MAX_CONN_LIFETIME" envDefault:"1h" validate:"min=1m"`
 	MaxConnIdleTime   time.Duration `env:"MAX_CONN_IDLE_TIME" envDefault:"15m" validate:"min=1m"`
 	HealthCheckPeriod time.Duration `env:"HEALTH_CHECK_PERIOD" envDefault:"1m" validate:"min=10s"`
+	// TxMaxAttempts bounds how often a transaction is replayed after a serialization failure or deadlock.
+	TxMaxAttempts int `env:"TX_MAX_ATTEMPTS" envDefault:"3" validate:"min=1,max=10"`
 }
 
 func (c *Config) Address() string {
@@ -0,0 +1,155 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"test-http/pkg/backup"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// BackupTables are the application tables, in an order that satisfies foreign keys on restore.
+var BackupTables = []string{
+	"users",
+	"user_credentials",
+	"refresh_tokens",
+	"words",
+	"word_sets",
+	"word_set_items",
+	"user_word_sets",
+	"user_sessions",
+	"user_session_events",
+	"game_questions",
+	"user_progress",
+	"user_statistics",
+	"import_jobs",
+	"import_job_payloads",
+	"import_job_errors",
+}
+
+var (
+	ErrSchemaMismatch = errors.New("db: backup was taken at another migration level")
+	ErrUnknownTable   = errors.New("db: backup contains an unknown table")
+	ErrRestoreTarget  = errors.New("db: restore target tables are not empty")
+)
+
+// schemaVersion is the latest migration goose has applied; goose deletes the row of a
+// migration it rolls back.
+const schemaVersion = `SELECT COALESCE(MAX(version_id), 0) FROM goose_db_version WHERE is_applied`
+
+// Backup writes every table in BackupTables to w with COPY TO, all from one
+// read-only snapshot so the archive is consistent without blocking writers.
+func Backup(ctx context.Context, db TxBeginner, w io.Writer) (backup.Header, error) {
+	tx, err := db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return backup.Header{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	header := backup.Header{CreatedAt: time.Now().UTC(), Tables: BackupTables}
+	if err := tx.QueryRow(ctx, schemaVersion).Scan(&header.SchemaVersion); err != nil {
+		return backup.Header{}, fmt.Errorf("db: read schema version: %w", err)
+	}
+
+	bw, err := backup.NewWriter(w, header)
+	if err != nil {
+		return backup.Header{}, err
+	}
+	for _, table := range BackupTables {
+		sql := "COPY " + pgx.Identifier{table}.Sanitize() + " TO STDOUT (FORMAT binary)"
+		if err := bw.WriteTable(table, func(dst io.Writer) (int64, error) {
+			tag, err := tx.Conn().PgConn().CopyTo(ctx, dst, sql)
+			return tag.RowsAffected(), err
+		}); err != nil {
+			return backup.Header{}, err
+		}
+	}
+	if err := bw.Close(); err != nil {
+		return backup.Header{}, err
+	}
+
+	return header, tx.Commit(ctx)
+}
+
+// Restore loads an archive written by Backup with COPY FROM in a single transaction,
+// so a failed restore leaves the database as it was. The database must be at the
+// archive's migration level. The target tables must be empty unless replace is set,
+// in which case they are truncated first. User triggers are disabled while loading
+// so that derived tables such as user_statistics are restored as dumped; this needs
+// the same table ownership the migrations need.
+func Restore(ctx context.Context, db TxBeginner, r io.Reader, replace bool) (backup.Header, error) {
+	br, err := backup.NewReader(r)
+	if err != nil {
+		return backup.Header{}, err
+	}
+	tables := make([]string, len(br.Header.Tables))
+	for i, table := range br.Header.Tables {
+		if !slices.Contains(BackupTables, table) {
+			return backup.Header{}, fmt.Errorf("%w: %q", ErrUnknownTable, table)
+		}
+		tables[i] = pgx.Identifier{table}.Sanitize()
+	}
+
+	err = pgx.BeginTxFunc(ctx, db, pgx.TxOptions{}, func(tx pgx.Tx) error {
+		var version int64
+		if err := tx.QueryRow(ctx, schemaVersion).Scan(&version); err != nil {
+			return fmt.Errorf("db: read schema version: %w", err)
+		}
+		if version != br.Header.SchemaVersion {
+			return fmt.Errorf("%w: backup %d, database %d", ErrSchemaMismatch, br.Header.SchemaVersion, version)
+		}
+
+		if replace {
+			if _, err := tx.Exec(ctx, "TRUNCATE "+strings.Join(tables, ", ")); err != nil {
+				return err
+			}
+		} else {
+			for i, table := range tables {
+				var used bool
+				if err := tx.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM "+table+")").Scan(&used); err != nil {
+					return err
+				}
+				if used {
+					return fmt.Errorf("%w: %s", ErrRestoreTarget, br.Header.Tables[i])
+				}
+			}
+		}
+
+		if err := setUserTriggers(ctx, tx, tables, "DISABLE"); err != nil {
+			return err
+		}
+		for {
+			err := br.NextTable(func(name string, data io.Reader) (int64, error) {
+				sql := "COPY " + pgx.Identifier{name}.Sanitize() + " FROM STDIN (FORMAT binary)"
+				tag, err := tx.Conn().PgConn().CopyFrom(ctx, data, sql)
+				return tag.RowsAffected(), err
+			})
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return setUserTriggers(ctx, tx, tables, "ENABLE")
+	})
+	if err != nil {
+		return backup.Header{}, err
+	}
+	return br.Header, nil
+}
+
+func setUserTriggers(ctx context.Context, tx pgx.Tx, tables []string, action string) error {
+	for _, table := range tables {
+		if _, err := tx.Exec(ctx, "ALTER TABLE "+table+" "+action+" TRIGGER USER"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package db
+
+import (
+	"io"
+	"io/fs"
+	"log/slog"
+	"testing"
+
+	"test-http/migration"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// The pool connects lazily, so the embedded sources can be checked without a database.
+func TestNewMigrator_EmbedsEveryMigration(t *testing.T) {
+	pool, err := pgxpool.New(t.Context(), "postgres://localhost:1/none?pool_min_conns=0")
+	if err != nil {
+		t.Fatalf("pgxpool.New: %v", err)
+	}
+	defer pool.Close()
+
+	m, err := NewMigrator(pool, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("NewMigrator: %v", err)
+	}
+	defer m.Close()
+
+	files, err := fs.Glob(migration.FS, "*.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sources := m.provider.ListSources()
+	if len(files) == 0 || len(sources) != len(files) {
+		t.Fatalf("%d migrations known to goose, %d embedded files", len(sources), len(files))
+	}
+	for i := 1; i < len(sources); i++ {
+		if sources[i].Version <= sources[i-1].Version {
+			t.Fatalf("versions out of order at %s", sources[i].Path)
+		}
+	}
+}
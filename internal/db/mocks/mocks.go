@@ -37,18 +37,18 @@ func (m *MockUserRepo) EXPECT() *MockUserRepoMockRecorder {
 }
 
 // CountUsers mocks base method.
-func (m *MockUserRepo) CountUsers(ctx context.Context) (int64, error) {
+func (m *MockUserRepo) CountUsers(ctx context.Context, arg db.CountUsersParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountUsers", ctx)
+	ret := m.ctrl.Call(m, "CountUsers", ctx, arg)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CountUsers indicates an expected call of CountUsers.
-func (mr *MockUserRepoMockRecorder) CountUsers(ctx interface{}) *gomock.Call {
+func (mr *MockUserRepoMockRecorder) CountUsers(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUsers", reflect.TypeOf((*MockUserRepo)(nil).CountUsers), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUsers", reflect.TypeOf((*MockUserRepo)(nil).CountUsers), ctx, arg)
 }
 
 // CreateUser mocks base method.
@@ -67,11 +67,12 @@ func (mr *MockUserRepoMockRecorder) CreateUser(ctx, arg interface{}) *gomock.Cal
 }
 
 // DeleteUser mocks base method.
-func (m *MockUserRepo) DeleteUser(ctx context.Context, id pgtype.UUID) error {
+func (m *MockUserRepo) DeleteUser(ctx context.Context, id pgtype.UUID) (int64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DeleteUser", ctx, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // DeleteUser indicates an expected call of DeleteUser.
@@ -110,6 +111,21 @@ func (mr *MockUserRepoMockRecorder) GetUserByEmail(ctx, email interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockUserRepo)(nil).GetUserByEmail), ctx, email)
 }
 
+// GetUserIncludingDeleted mocks base method.
+func (m *MockUserRepo) GetUserIncludingDeleted(ctx context.Context, id pgtype.UUID) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIncludingDeleted", ctx, id)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIncludingDeleted indicates an expected call of GetUserIncludingDeleted.
+func (mr *MockUserRepoMockRecorder) GetUserIncludingDeleted(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIncludingDeleted", reflect.TypeOf((*MockUserRepo)(nil).GetUserIncludingDeleted), ctx, id)
+}
+
 // ListUsers mocks base method.
 func (m *MockUserRepo) ListUsers(ctx context.Context, arg db.ListUsersParams) ([]db.User, error) {
 	m.ctrl.T.Helper()
@@ -125,6 +141,81 @@ func (mr *MockUserRepoMockRecorder) ListUsers(ctx, arg interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserRepo)(nil).ListUsers), ctx, arg)
 }
 
+// ListUsersAfter mocks base method.
+func (m *MockUserRepo) ListUsersAfter(ctx context.Context, arg db.ListUsersAfterParams) ([]db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsersAfter", ctx, arg)
+	ret0, _ := ret[0].([]db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsersAfter indicates an expected call of ListUsersAfter.
+func (mr *MockUserRepoMockRecorder) ListUsersAfter(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsersAfter", reflect.TypeOf((*MockUserRepo)(nil).ListUsersAfter), ctx, arg)
+}
+
+// ListUsersBefore mocks base method.
+func (m *MockUserRepo) ListUsersBefore(ctx context.Context, arg db.ListUsersBeforeParams) ([]db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsersBefore", ctx, arg)
+	ret0, _ := ret[0].([]db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsersBefore indicates an expected call of ListUsersBefore.
+func (mr *MockUserRepoMockRecorder) ListUsersBefore(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsersBefore", reflect.TypeOf((*MockUserRepo)(nil).ListUsersBefore), ctx, arg)
+}
+
+// PurgeDeletedUsers mocks base method.
+func (m *MockUserRepo) PurgeDeletedUsers(ctx context.Context, deletedBefore pgtype.Timestamptz) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedUsers", ctx, deletedBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedUsers indicates an expected call of PurgeDeletedUsers.
+func (mr *MockUserRepoMockRecorder) PurgeDeletedUsers(ctx, deletedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedUsers", reflect.TypeOf((*MockUserRepo)(nil).PurgeDeletedUsers), ctx, deletedBefore)
+}
+
+// RestoreUser mocks base method.
+func (m *MockUserRepo) RestoreUser(ctx context.Context, arg db.RestoreUserParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreUser", ctx, arg)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreUser indicates an expected call of RestoreUser.
+func (mr *MockUserRepoMockRecorder) RestoreUser(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUser", reflect.TypeOf((*MockUserRepo)(nil).RestoreUser), ctx, arg)
+}
+
+// SetUserActive mocks base method.
+func (m *MockUserRepo) SetUserActive(ctx context.Context, arg db.SetUserActiveParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserActive", ctx, arg)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetUserActive indicates an expected call of SetUserActive.
+func (mr *MockUserRepoMockRecorder) SetUserActive(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserActive", reflect.TypeOf((*MockUserRepo)(nil).SetUserActive), ctx, arg)
+}
+
 // UpdateUser mocks base method.
 func (m *MockUserRepo) UpdateUser(ctx context.Context, arg db.UpdateUserParams) (db.User, error) {
 	m.ctrl.T.Helper()
@@ -140,6 +231,133 @@ func (mr *MockUserRepoMockRecorder) UpdateUser(ctx, arg interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserRepo)(nil).UpdateUser), ctx, arg)
 }
 
+// MockAuthRepo is a mock of AuthRepo interface.
+type MockAuthRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthRepoMockRecorder
+}
+
+// MockAuthRepoMockRecorder is the mock recorder for MockAuthRepo.
+type MockAuthRepoMockRecorder struct {
+	mock *MockAuthRepo
+}
+
+// NewMockAuthRepo creates a new mock instance.
+func NewMockAuthRepo(ctrl *gomock.Controller) *MockAuthRepo {
+	mock := &MockAuthRepo{ctrl: ctrl}
+	mock.recorder = &MockAuthRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthRepo) EXPECT() *MockAuthRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateRefreshToken mocks base method.
+func (m *MockAuthRepo) CreateRefreshToken(ctx context.Context, arg db.CreateRefreshTokenParams) (db.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRefreshToken", ctx, arg)
+	ret0, _ := ret[0].(db.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRefreshToken indicates an expected call of CreateRefreshToken.
+func (mr *MockAuthRepoMockRecorder) CreateRefreshToken(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRefreshToken", reflect.TypeOf((*MockAuthRepo)(nil).CreateRefreshToken), ctx, arg)
+}
+
+// GetRefreshTokenByHash mocks base method.
+func (m *MockAuthRepo) GetRefreshTokenByHash(ctx context.Context, tokenHash []byte) (db.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRefreshTokenByHash", ctx, tokenHash)
+	ret0, _ := ret[0].(db.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRefreshTokenByHash indicates an expected call of GetRefreshTokenByHash.
+func (mr *MockAuthRepoMockRecorder) GetRefreshTokenByHash(ctx, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRefreshTokenByHash", reflect.TypeOf((*MockAuthRepo)(nil).GetRefreshTokenByHash), ctx, tokenHash)
+}
+
+// GetUser mocks base method.
+func (m *MockAuthRepo) GetUser(ctx context.Context, id pgtype.UUID) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, id)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockAuthRepoMockRecorder) GetUser(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockAuthRepo)(nil).GetUser), ctx, id)
+}
+
+// GetUserCredentialsByEmail mocks base method.
+func (m *MockAuthRepo) GetUserCredentialsByEmail(ctx context.Context, email string) (db.GetUserCredentialsByEmailRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserCredentialsByEmail", ctx, email)
+	ret0, _ := ret[0].(db.GetUserCredentialsByEmailRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserCredentialsByEmail indicates an expected call of GetUserCredentialsByEmail.
+func (mr *MockAuthRepoMockRecorder) GetUserCredentialsByEmail(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserCredentialsByEmail", reflect.TypeOf((*MockAuthRepo)(nil).GetUserCredentialsByEmail), ctx, email)
+}
+
+// RegisterUser mocks base method.
+func (m *MockAuthRepo) RegisterUser(ctx context.Context, arg db.RegisterUserParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterUser", ctx, arg)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterUser indicates an expected call of RegisterUser.
+func (mr *MockAuthRepoMockRecorder) RegisterUser(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterUser", reflect.TypeOf((*MockAuthRepo)(nil).RegisterUser), ctx, arg)
+}
+
+// RevokeRefreshToken mocks base method.
+func (m *MockAuthRepo) RevokeRefreshToken(ctx context.Context, id pgtype.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRefreshToken", ctx, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeRefreshToken indicates an expected call of RevokeRefreshToken.
+func (mr *MockAuthRepoMockRecorder) RevokeRefreshToken(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRefreshToken", reflect.TypeOf((*MockAuthRepo)(nil).RevokeRefreshToken), ctx, id)
+}
+
+// RevokeRefreshTokenFamily mocks base method.
+func (m *MockAuthRepo) RevokeRefreshTokenFamily(ctx context.Context, familyID pgtype.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRefreshTokenFamily", ctx, familyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRefreshTokenFamily indicates an expected call of RevokeRefreshTokenFamily.
+func (mr *MockAuthRepoMockRecorder) RevokeRefreshTokenFamily(ctx, familyID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRefreshTokenFamily", reflect.TypeOf((*MockAuthRepo)(nil).RevokeRefreshTokenFamily), ctx, familyID)
+}
+
 // MockUserSessionRepo is a mock of UserSessionRepo interface.
 type MockUserSessionRepo struct {
 	ctrl     *gomock.Controller
@@ -163,6 +381,66 @@ func (m *MockUserSessionRepo) EXPECT() *MockUserSessionRepoMockRecorder {
 	return m.recorder
 }
 
+// AbandonActiveUserSessions mocks base method.
+func (m *MockUserSessionRepo) AbandonActiveUserSessions(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AbandonActiveUserSessions", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AbandonActiveUserSessions indicates an expected call of AbandonActiveUserSessions.
+func (mr *MockUserSessionRepoMockRecorder) AbandonActiveUserSessions(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AbandonActiveUserSessions", reflect.TypeOf((*MockUserSessionRepo)(nil).AbandonActiveUserSessions), ctx, userID)
+}
+
+// AbandonIdleSessions mocks base method.
+func (m *MockUserSessionRepo) AbandonIdleSessions(ctx context.Context, idleBefore pgtype.Timestamptz) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AbandonIdleSessions", ctx, idleBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AbandonIdleSessions indicates an expected call of AbandonIdleSessions.
+func (mr *MockUserSessionRepoMockRecorder) AbandonIdleSessions(ctx, idleBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AbandonIdleSessions", reflect.TypeOf((*MockUserSessionRepo)(nil).AbandonIdleSessions), ctx, idleBefore)
+}
+
+// CreateAnswerSessionEvents mocks base method.
+func (m *MockUserSessionRepo) CreateAnswerSessionEvents(ctx context.Context, arg db.CreateAnswerSessionEventsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAnswerSessionEvents", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAnswerSessionEvents indicates an expected call of CreateAnswerSessionEvents.
+func (mr *MockUserSessionRepoMockRecorder) CreateAnswerSessionEvents(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAnswerSessionEvents", reflect.TypeOf((*MockUserSessionRepo)(nil).CreateAnswerSessionEvents), ctx, arg)
+}
+
+// CreateCompletedUserSession mocks base method.
+func (m *MockUserSessionRepo) CreateCompletedUserSession(ctx context.Context, arg db.CreateCompletedUserSessionParams) (db.UserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCompletedUserSession", ctx, arg)
+	ret0, _ := ret[0].(db.UserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCompletedUserSession indicates an expected call of CreateCompletedUserSession.
+func (mr *MockUserSessionRepoMockRecorder) CreateCompletedUserSession(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCompletedUserSession", reflect.TypeOf((*MockUserSessionRepo)(nil).CreateCompletedUserSession), ctx, arg)
+}
+
 // CreateUserSession mocks base method.
 func (m *MockUserSessionRepo) CreateUserSession(ctx context.Context, arg db.CreateUserSessionParams) (db.UserSession, error) {
 	m.ctrl.T.Helper()
@@ -178,18 +456,34 @@ func (mr *MockUserSessionRepoMockRecorder) CreateUserSession(ctx, arg interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserSession", reflect.TypeOf((*MockUserSessionRepo)(nil).CreateUserSession), ctx, arg)
 }
 
+// CreateUserSessionEvent mocks base method.
+func (m *MockUserSessionRepo) CreateUserSessionEvent(ctx context.Context, arg db.CreateUserSessionEventParams) (db.UserSessionEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserSessionEvent", ctx, arg)
+	ret0, _ := ret[0].(db.UserSessionEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUserSessionEvent indicates an expected call of CreateUserSessionEvent.
+func (mr *MockUserSessionRepoMockRecorder) CreateUserSessionEvent(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserSessionEvent", reflect.TypeOf((*MockUserSessionRepo)(nil).CreateUserSessionEvent), ctx, arg)
+}
+
 // DeleteUserSession mocks base method.
-func (m *MockUserSessionRepo) DeleteUserSession(ctx context.Context, id pgtype.UUID) error {
+func (m *MockUserSessionRepo) DeleteUserSession(ctx context.Context, arg db.DeleteUserSessionParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUserSession", ctx, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "DeleteUserSession", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // DeleteUserSession indicates an expected call of DeleteUserSession.
-func (mr *MockUserSessionRepoMockRecorder) DeleteUserSession(ctx, id interface{}) *gomock.Call {
+func (mr *MockUserSessionRepoMockRecorder) DeleteUserSession(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserSession", reflect.TypeOf((*MockUserSessionRepo)(nil).DeleteUserSession), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserSession", reflect.TypeOf((*MockUserSessionRepo)(nil).DeleteUserSession), ctx, arg)
 }
 
 // GetUserSession mocks base method.
@@ -237,6 +531,51 @@ func (mr *MockUserSessionRepoMockRecorder) ListUserSessions(ctx, arg interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserSessions", reflect.TypeOf((*MockUserSessionRepo)(nil).ListUserSessions), ctx, arg)
 }
 
+// ListUserSessionsAfter mocks base method.
+func (m *MockUserSessionRepo) ListUserSessionsAfter(ctx context.Context, arg db.ListUserSessionsAfterParams) ([]db.UserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserSessionsAfter", ctx, arg)
+	ret0, _ := ret[0].([]db.UserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserSessionsAfter indicates an expected call of ListUserSessionsAfter.
+func (mr *MockUserSessionRepoMockRecorder) ListUserSessionsAfter(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserSessionsAfter", reflect.TypeOf((*MockUserSessionRepo)(nil).ListUserSessionsAfter), ctx, arg)
+}
+
+// ListUserSessionsBefore mocks base method.
+func (m *MockUserSessionRepo) ListUserSessionsBefore(ctx context.Context, arg db.ListUserSessionsBeforeParams) ([]db.UserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserSessionsBefore", ctx, arg)
+	ret0, _ := ret[0].([]db.UserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserSessionsBefore indicates an expected call of ListUserSessionsBefore.
+func (mr *MockUserSessionRepoMockRecorder) ListUserSessionsBefore(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserSessionsBefore", reflect.TypeOf((*MockUserSessionRepo)(nil).ListUserSessionsBefore), ctx, arg)
+}
+
+// RecordSessionActivity mocks base method.
+func (m *MockUserSessionRepo) RecordSessionActivity(ctx context.Context, arg db.RecordSessionActivityParams) (db.UserSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordSessionActivity", ctx, arg)
+	ret0, _ := ret[0].(db.UserSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordSessionActivity indicates an expected call of RecordSessionActivity.
+func (mr *MockUserSessionRepoMockRecorder) RecordSessionActivity(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordSessionActivity", reflect.TypeOf((*MockUserSessionRepo)(nil).RecordSessionActivity), ctx, arg)
+}
+
 // UpdateUserSession mocks base method.
 func (m *MockUserSessionRepo) UpdateUserSession(ctx context.Context, arg db.UpdateUserSessionParams) (db.UserSession, error) {
 	m.ctrl.T.Helper()
@@ -291,17 +630,18 @@ func (mr *MockUserProgressRepoMockRecorder) CreateUserProgress(ctx, arg interfac
 }
 
 // DeleteUserProgress mocks base method.
-func (m *MockUserProgressRepo) DeleteUserProgress(ctx context.Context, id pgtype.UUID) error {
+func (m *MockUserProgressRepo) DeleteUserProgress(ctx context.Context, arg db.DeleteUserProgressParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUserProgress", ctx, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "DeleteUserProgress", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // DeleteUserProgress indicates an expected call of DeleteUserProgress.
-func (mr *MockUserProgressRepoMockRecorder) DeleteUserProgress(ctx, id interface{}) *gomock.Call {
+func (mr *MockUserProgressRepoMockRecorder) DeleteUserProgress(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserProgress", reflect.TypeOf((*MockUserProgressRepo)(nil).DeleteUserProgress), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserProgress", reflect.TypeOf((*MockUserProgressRepo)(nil).DeleteUserProgress), ctx, arg)
 }
 
 // GetUserProgress mocks base method.
@@ -334,86 +674,132 @@ func (mr *MockUserProgressRepoMockRecorder) GetUserProgressByUserAndWord(ctx, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserProgressByUserAndWord", reflect.TypeOf((*MockUserProgressRepo)(nil).GetUserProgressByUserAndWord), ctx, arg)
 }
 
-// ListUserProgress mocks base method.
-func (m *MockUserProgressRepo) ListUserProgress(ctx context.Context, userID pgtype.UUID) ([]db.UserProgress, error) {
+// ListDueReviews mocks base method.
+func (m *MockUserProgressRepo) ListDueReviews(ctx context.Context, arg db.ListDueReviewsParams) ([]db.ListDueReviewsRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUserProgress", ctx, userID)
-	ret0, _ := ret[0].([]db.UserProgress)
+	ret := m.ctrl.Call(m, "ListDueReviews", ctx, arg)
+	ret0, _ := ret[0].([]db.ListDueReviewsRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListUserProgress indicates an expected call of ListUserProgress.
-func (mr *MockUserProgressRepoMockRecorder) ListUserProgress(ctx, userID interface{}) *gomock.Call {
+// ListDueReviews indicates an expected call of ListDueReviews.
+func (mr *MockUserProgressRepoMockRecorder) ListDueReviews(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserProgress", reflect.TypeOf((*MockUserProgressRepo)(nil).ListUserProgress), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueReviews", reflect.TypeOf((*MockUserProgressRepo)(nil).ListDueReviews), ctx, arg)
 }
 
-// UpdateUserProgress mocks base method.
-func (m *MockUserProgressRepo) UpdateUserProgress(ctx context.Context, arg db.UpdateUserProgressParams) (db.UserProgress, error) {
+// ListUserProgress mocks base method.
+func (m *MockUserProgressRepo) ListUserProgress(ctx context.Context, arg db.ListUserProgressParams) ([]db.UserProgress, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUserProgress", ctx, arg)
-	ret0, _ := ret[0].(db.UserProgress)
+	ret := m.ctrl.Call(m, "ListUserProgress", ctx, arg)
+	ret0, _ := ret[0].([]db.UserProgress)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateUserProgress indicates an expected call of UpdateUserProgress.
-func (mr *MockUserProgressRepoMockRecorder) UpdateUserProgress(ctx, arg interface{}) *gomock.Call {
+// ListUserProgress indicates an expected call of ListUserProgress.
+func (mr *MockUserProgressRepoMockRecorder) ListUserProgress(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserProgress", reflect.TypeOf((*MockUserProgressRepo)(nil).UpdateUserProgress), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserProgress", reflect.TypeOf((*MockUserProgressRepo)(nil).ListUserProgress), ctx, arg)
 }
 
-// MockUserStatisticsRepo is a mock of UserStatisticsRepo interface.
-type MockUserStatisticsRepo struct {
-	ctrl     *gomock.Controller
-	recorder *MockUserStatisticsRepoMockRecorder
+// ListUserProgressAfter mocks base method.
+func (m *MockUserProgressRepo) ListUserProgressAfter(ctx context.Context, arg db.ListUserProgressAfterParams) ([]db.UserProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserProgressAfter", ctx, arg)
+	ret0, _ := ret[0].([]db.UserProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// MockUserStatisticsRepoMockRecorder is the mock recorder for MockUserStatisticsRepo.
-type MockUserStatisticsRepoMockRecorder struct {
-	mock *MockUserStatisticsRepo
+// ListUserProgressAfter indicates an expected call of ListUserProgressAfter.
+func (mr *MockUserProgressRepoMockRecorder) ListUserProgressAfter(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserProgressAfter", reflect.TypeOf((*MockUserProgressRepo)(nil).ListUserProgressAfter), ctx, arg)
 }
 
-// NewMockUserStatisticsRepo creates a new mock instance.
-func NewMockUserStatisticsRepo(ctrl *gomock.Controller) *MockUserStatisticsRepo {
-	mock := &MockUserStatisticsRepo{ctrl: ctrl}
-	mock.recorder = &MockUserStatisticsRepoMockRecorder{mock}
-	return mock
+// ListUserProgressBefore mocks base method.
+func (m *MockUserProgressRepo) ListUserProgressBefore(ctx context.Context, arg db.ListUserProgressBeforeParams) ([]db.UserProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserProgressBefore", ctx, arg)
+	ret0, _ := ret[0].([]db.UserProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockUserStatisticsRepo) EXPECT() *MockUserStatisticsRepoMockRecorder {
-	return m.recorder
+// ListUserProgressBefore indicates an expected call of ListUserProgressBefore.
+func (mr *MockUserProgressRepoMockRecorder) ListUserProgressBefore(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserProgressBefore", reflect.TypeOf((*MockUserProgressRepo)(nil).ListUserProgressBefore), ctx, arg)
 }
 
-// CreateUserStatistics mocks base method.
-func (m *MockUserStatisticsRepo) CreateUserStatistics(ctx context.Context, arg db.CreateUserStatisticsParams) (db.UserStatistic, error) {
+// ListUserProgressByWords mocks base method.
+func (m *MockUserProgressRepo) ListUserProgressByWords(ctx context.Context, arg db.ListUserProgressByWordsParams) ([]db.UserProgress, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUserStatistics", ctx, arg)
-	ret0, _ := ret[0].(db.UserStatistic)
+	ret := m.ctrl.Call(m, "ListUserProgressByWords", ctx, arg)
+	ret0, _ := ret[0].([]db.UserProgress)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateUserStatistics indicates an expected call of CreateUserStatistics.
-func (mr *MockUserStatisticsRepoMockRecorder) CreateUserStatistics(ctx, arg interface{}) *gomock.Call {
+// ListUserProgressByWords indicates an expected call of ListUserProgressByWords.
+func (mr *MockUserProgressRepoMockRecorder) ListUserProgressByWords(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserStatistics", reflect.TypeOf((*MockUserStatisticsRepo)(nil).CreateUserStatistics), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserProgressByWords", reflect.TypeOf((*MockUserProgressRepo)(nil).ListUserProgressByWords), ctx, arg)
 }
 
-// DeleteUserStatistics mocks base method.
-func (m *MockUserStatisticsRepo) DeleteUserStatistics(ctx context.Context, userID pgtype.UUID) error {
+// RecordUserProgressAttempt mocks base method.
+func (m *MockUserProgressRepo) RecordUserProgressAttempt(ctx context.Context, arg db.RecordUserProgressAttemptParams) (db.UserProgress, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUserStatistics", ctx, userID)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "RecordUserProgressAttempt", ctx, arg)
+	ret0, _ := ret[0].(db.UserProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordUserProgressAttempt indicates an expected call of RecordUserProgressAttempt.
+func (mr *MockUserProgressRepoMockRecorder) RecordUserProgressAttempt(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordUserProgressAttempt", reflect.TypeOf((*MockUserProgressRepo)(nil).RecordUserProgressAttempt), ctx, arg)
+}
+
+// UpdateUserProgress mocks base method.
+func (m *MockUserProgressRepo) UpdateUserProgress(ctx context.Context, arg db.UpdateUserProgressParams) (db.UserProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserProgress", ctx, arg)
+	ret0, _ := ret[0].(db.UserProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteUserStatistics indicates an expected call of DeleteUserStatistics.
-func (mr *MockUserStatisticsRepoMockRecorder) DeleteUserStatistics(ctx, userID interface{}) *gomock.Call {
+// UpdateUserProgress indicates an expected call of UpdateUserProgress.
+func (mr *MockUserProgressRepoMockRecorder) UpdateUserProgress(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserStatistics", reflect.TypeOf((*MockUserStatisticsRepo)(nil).DeleteUserStatistics), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserProgress", reflect.TypeOf((*MockUserProgressRepo)(nil).UpdateUserProgress), ctx, arg)
+}
+
+// MockUserStatisticsRepo is a mock of UserStatisticsRepo interface.
+type MockUserStatisticsRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserStatisticsRepoMockRecorder
+}
+
+// MockUserStatisticsRepoMockRecorder is the mock recorder for MockUserStatisticsRepo.
+type MockUserStatisticsRepoMockRecorder struct {
+	mock *MockUserStatisticsRepo
+}
+
+// NewMockUserStatisticsRepo creates a new mock instance.
+func NewMockUserStatisticsRepo(ctrl *gomock.Controller) *MockUserStatisticsRepo {
+	mock := &MockUserStatisticsRepo{ctrl: ctrl}
+	mock.recorder = &MockUserStatisticsRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserStatisticsRepo) EXPECT() *MockUserStatisticsRepoMockRecorder {
+	return m.recorder
 }
 
 // GetUserStatistics mocks base method.
@@ -446,19 +832,49 @@ func (mr *MockUserStatisticsRepoMockRecorder) ListUserStatistics(ctx, arg interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserStatistics", reflect.TypeOf((*MockUserStatisticsRepo)(nil).ListUserStatistics), ctx, arg)
 }
 
-// UpdateUserStatistics mocks base method.
-func (m *MockUserStatisticsRepo) UpdateUserStatistics(ctx context.Context, arg db.UpdateUserStatisticsParams) (db.UserStatistic, error) {
+// ListUserStatisticsAfter mocks base method.
+func (m *MockUserStatisticsRepo) ListUserStatisticsAfter(ctx context.Context, arg db.ListUserStatisticsAfterParams) ([]db.UserStatistic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserStatisticsAfter", ctx, arg)
+	ret0, _ := ret[0].([]db.UserStatistic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserStatisticsAfter indicates an expected call of ListUserStatisticsAfter.
+func (mr *MockUserStatisticsRepoMockRecorder) ListUserStatisticsAfter(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserStatisticsAfter", reflect.TypeOf((*MockUserStatisticsRepo)(nil).ListUserStatisticsAfter), ctx, arg)
+}
+
+// ListUserStatisticsBefore mocks base method.
+func (m *MockUserStatisticsRepo) ListUserStatisticsBefore(ctx context.Context, arg db.ListUserStatisticsBeforeParams) ([]db.UserStatistic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserStatisticsBefore", ctx, arg)
+	ret0, _ := ret[0].([]db.UserStatistic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserStatisticsBefore indicates an expected call of ListUserStatisticsBefore.
+func (mr *MockUserStatisticsRepoMockRecorder) ListUserStatisticsBefore(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserStatisticsBefore", reflect.TypeOf((*MockUserStatisticsRepo)(nil).ListUserStatisticsBefore), ctx, arg)
+}
+
+// RecomputeUserStatistics mocks base method.
+func (m *MockUserStatisticsRepo) RecomputeUserStatistics(ctx context.Context, userID pgtype.UUID) (db.UserStatistic, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUserStatistics", ctx, arg)
+	ret := m.ctrl.Call(m, "RecomputeUserStatistics", ctx, userID)
 	ret0, _ := ret[0].(db.UserStatistic)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateUserStatistics indicates an expected call of UpdateUserStatistics.
-func (mr *MockUserStatisticsRepoMockRecorder) UpdateUserStatistics(ctx, arg interface{}) *gomock.Call {
+// RecomputeUserStatistics indicates an expected call of RecomputeUserStatistics.
+func (mr *MockUserStatisticsRepoMockRecorder) RecomputeUserStatistics(ctx, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserStatistics", reflect.TypeOf((*MockUserStatisticsRepo)(nil).UpdateUserStatistics), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecomputeUserStatistics", reflect.TypeOf((*MockUserStatisticsRepo)(nil).RecomputeUserStatistics), ctx, userID)
 }
 
 // MockUserWordSetRepo is a mock of UserWordSetRepo interface.
@@ -500,17 +916,18 @@ func (mr *MockUserWordSetRepoMockRecorder) CreateUserWordSet(ctx, arg interface{
 }
 
 // DeleteUserWordSet mocks base method.
-func (m *MockUserWordSetRepo) DeleteUserWordSet(ctx context.Context, id pgtype.UUID) error {
+func (m *MockUserWordSetRepo) DeleteUserWordSet(ctx context.Context, arg db.DeleteUserWordSetParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUserWordSet", ctx, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "DeleteUserWordSet", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // DeleteUserWordSet indicates an expected call of DeleteUserWordSet.
-func (mr *MockUserWordSetRepoMockRecorder) DeleteUserWordSet(ctx, id interface{}) *gomock.Call {
+func (mr *MockUserWordSetRepoMockRecorder) DeleteUserWordSet(ctx, arg interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserWordSet", reflect.TypeOf((*MockUserWordSetRepo)(nil).DeleteUserWordSet), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserWordSet", reflect.TypeOf((*MockUserWordSetRepo)(nil).DeleteUserWordSet), ctx, arg)
 }
 
 // GetUserWordSet mocks base method.
@@ -557,3 +974,861 @@ func (mr *MockUserWordSetRepoMockRecorder) UpdateUserWordSet(ctx, arg interface{
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserWordSet", reflect.TypeOf((*MockUserWordSetRepo)(nil).UpdateUserWordSet), ctx, arg)
 }
+
+// MockWordRepo is a mock of WordRepo interface.
+type MockWordRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockWordRepoMockRecorder
+}
+
+// MockWordRepoMockRecorder is the mock recorder for MockWordRepo.
+type MockWordRepoMockRecorder struct {
+	mock *MockWordRepo
+}
+
+// NewMockWordRepo creates a new mock instance.
+func NewMockWordRepo(ctrl *gomock.Controller) *MockWordRepo {
+	mock := &MockWordRepo{ctrl: ctrl}
+	mock.recorder = &MockWordRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWordRepo) EXPECT() *MockWordRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateWord mocks base method.
+func (m *MockWordRepo) CreateWord(ctx context.Context, arg db.CreateWordParams) (db.Word, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWord", ctx, arg)
+	ret0, _ := ret[0].(db.Word)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWord indicates an expected call of CreateWord.
+func (mr *MockWordRepoMockRecorder) CreateWord(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWord", reflect.TypeOf((*MockWordRepo)(nil).CreateWord), ctx, arg)
+}
+
+// CreateWordIfMissing mocks base method.
+func (m *MockWordRepo) CreateWordIfMissing(ctx context.Context, arg db.CreateWordIfMissingParams) (db.Word, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWordIfMissing", ctx, arg)
+	ret0, _ := ret[0].(db.Word)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWordIfMissing indicates an expected call of CreateWordIfMissing.
+func (mr *MockWordRepoMockRecorder) CreateWordIfMissing(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWordIfMissing", reflect.TypeOf((*MockWordRepo)(nil).CreateWordIfMissing), ctx, arg)
+}
+
+// DeleteWord mocks base method.
+func (m *MockWordRepo) DeleteWord(ctx context.Context, id pgtype.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWord", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWord indicates an expected call of DeleteWord.
+func (mr *MockWordRepoMockRecorder) DeleteWord(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWord", reflect.TypeOf((*MockWordRepo)(nil).DeleteWord), ctx, id)
+}
+
+// GetWord mocks base method.
+func (m *MockWordRepo) GetWord(ctx context.Context, id pgtype.UUID) (db.Word, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWord", ctx, id)
+	ret0, _ := ret[0].(db.Word)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWord indicates an expected call of GetWord.
+func (mr *MockWordRepoMockRecorder) GetWord(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWord", reflect.TypeOf((*MockWordRepo)(nil).GetWord), ctx, id)
+}
+
+// GetWordByLemma mocks base method.
+func (m *MockWordRepo) GetWordByLemma(ctx context.Context, arg db.GetWordByLemmaParams) (db.Word, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWordByLemma", ctx, arg)
+	ret0, _ := ret[0].(db.Word)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWordByLemma indicates an expected call of GetWordByLemma.
+func (mr *MockWordRepoMockRecorder) GetWordByLemma(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWordByLemma", reflect.TypeOf((*MockWordRepo)(nil).GetWordByLemma), ctx, arg)
+}
+
+// ListWordsByIDs mocks base method.
+func (m *MockWordRepo) ListWordsByIDs(ctx context.Context, ids []pgtype.UUID) ([]db.Word, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWordsByIDs", ctx, ids)
+	ret0, _ := ret[0].([]db.Word)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWordsByIDs indicates an expected call of ListWordsByIDs.
+func (mr *MockWordRepoMockRecorder) ListWordsByIDs(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWordsByIDs", reflect.TypeOf((*MockWordRepo)(nil).ListWordsByIDs), ctx, ids)
+}
+
+// ListWordsByLemmas mocks base method.
+func (m *MockWordRepo) ListWordsByLemmas(ctx context.Context, arg db.ListWordsByLemmasParams) ([]db.Word, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWordsByLemmas", ctx, arg)
+	ret0, _ := ret[0].([]db.Word)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWordsByLemmas indicates an expected call of ListWordsByLemmas.
+func (mr *MockWordRepoMockRecorder) ListWordsByLemmas(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWordsByLemmas", reflect.TypeOf((*MockWordRepo)(nil).ListWordsByLemmas), ctx, arg)
+}
+
+// SearchWords mocks base method.
+func (m *MockWordRepo) SearchWords(ctx context.Context, arg db.SearchWordsParams) ([]db.Word, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchWords", ctx, arg)
+	ret0, _ := ret[0].([]db.Word)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchWords indicates an expected call of SearchWords.
+func (mr *MockWordRepoMockRecorder) SearchWords(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchWords", reflect.TypeOf((*MockWordRepo)(nil).SearchWords), ctx, arg)
+}
+
+// UpdateWord mocks base method.
+func (m *MockWordRepo) UpdateWord(ctx context.Context, arg db.UpdateWordParams) (db.Word, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWord", ctx, arg)
+	ret0, _ := ret[0].(db.Word)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWord indicates an expected call of UpdateWord.
+func (mr *MockWordRepoMockRecorder) UpdateWord(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWord", reflect.TypeOf((*MockWordRepo)(nil).UpdateWord), ctx, arg)
+}
+
+// MockWordSetRepo is a mock of WordSetRepo interface.
+type MockWordSetRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockWordSetRepoMockRecorder
+}
+
+// MockWordSetRepoMockRecorder is the mock recorder for MockWordSetRepo.
+type MockWordSetRepoMockRecorder struct {
+	mock *MockWordSetRepo
+}
+
+// NewMockWordSetRepo creates a new mock instance.
+func NewMockWordSetRepo(ctrl *gomock.Controller) *MockWordSetRepo {
+	mock := &MockWordSetRepo{ctrl: ctrl}
+	mock.recorder = &MockWordSetRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWordSetRepo) EXPECT() *MockWordSetRepoMockRecorder {
+	return m.recorder
+}
+
+// AddWordSetItem mocks base method.
+func (m *MockWordSetRepo) AddWordSetItem(ctx context.Context, arg db.AddWordSetItemParams) (db.WordSetItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWordSetItem", ctx, arg)
+	ret0, _ := ret[0].(db.WordSetItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddWordSetItem indicates an expected call of AddWordSetItem.
+func (mr *MockWordSetRepoMockRecorder) AddWordSetItem(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWordSetItem", reflect.TypeOf((*MockWordSetRepo)(nil).AddWordSetItem), ctx, arg)
+}
+
+// AddWordSetItemIfMissing mocks base method.
+func (m *MockWordSetRepo) AddWordSetItemIfMissing(ctx context.Context, arg db.AddWordSetItemIfMissingParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWordSetItemIfMissing", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddWordSetItemIfMissing indicates an expected call of AddWordSetItemIfMissing.
+func (mr *MockWordSetRepoMockRecorder) AddWordSetItemIfMissing(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWordSetItemIfMissing", reflect.TypeOf((*MockWordSetRepo)(nil).AddWordSetItemIfMissing), ctx, arg)
+}
+
+// CountWordSetItems mocks base method.
+func (m *MockWordSetRepo) CountWordSetItems(ctx context.Context, wordSetID pgtype.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountWordSetItems", ctx, wordSetID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountWordSetItems indicates an expected call of CountWordSetItems.
+func (mr *MockWordSetRepoMockRecorder) CountWordSetItems(ctx, wordSetID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountWordSetItems", reflect.TypeOf((*MockWordSetRepo)(nil).CountWordSetItems), ctx, wordSetID)
+}
+
+// CreateWordSet mocks base method.
+func (m *MockWordSetRepo) CreateWordSet(ctx context.Context, arg db.CreateWordSetParams) (db.WordSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWordSet", ctx, arg)
+	ret0, _ := ret[0].(db.WordSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWordSet indicates an expected call of CreateWordSet.
+func (mr *MockWordSetRepoMockRecorder) CreateWordSet(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWordSet", reflect.TypeOf((*MockWordSetRepo)(nil).CreateWordSet), ctx, arg)
+}
+
+// DeleteWordSet mocks base method.
+func (m *MockWordSetRepo) DeleteWordSet(ctx context.Context, id pgtype.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWordSet", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWordSet indicates an expected call of DeleteWordSet.
+func (mr *MockWordSetRepoMockRecorder) DeleteWordSet(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWordSet", reflect.TypeOf((*MockWordSetRepo)(nil).DeleteWordSet), ctx, id)
+}
+
+// GetWordSet mocks base method.
+func (m *MockWordSetRepo) GetWordSet(ctx context.Context, id pgtype.UUID) (db.WordSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWordSet", ctx, id)
+	ret0, _ := ret[0].(db.WordSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWordSet indicates an expected call of GetWordSet.
+func (mr *MockWordSetRepoMockRecorder) GetWordSet(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWordSet", reflect.TypeOf((*MockWordSetRepo)(nil).GetWordSet), ctx, id)
+}
+
+// ListWordSetItems mocks base method.
+func (m *MockWordSetRepo) ListWordSetItems(ctx context.Context, arg db.ListWordSetItemsParams) ([]db.ListWordSetItemsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWordSetItems", ctx, arg)
+	ret0, _ := ret[0].([]db.ListWordSetItemsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWordSetItems indicates an expected call of ListWordSetItems.
+func (mr *MockWordSetRepoMockRecorder) ListWordSetItems(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWordSetItems", reflect.TypeOf((*MockWordSetRepo)(nil).ListWordSetItems), ctx, arg)
+}
+
+// ListWordSets mocks base method.
+func (m *MockWordSetRepo) ListWordSets(ctx context.Context, arg db.ListWordSetsParams) ([]db.WordSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWordSets", ctx, arg)
+	ret0, _ := ret[0].([]db.WordSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWordSets indicates an expected call of ListWordSets.
+func (mr *MockWordSetRepoMockRecorder) ListWordSets(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWordSets", reflect.TypeOf((*MockWordSetRepo)(nil).ListWordSets), ctx, arg)
+}
+
+// LockWordSet mocks base method.
+func (m *MockWordSetRepo) LockWordSet(ctx context.Context, id pgtype.UUID) (pgtype.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockWordSet", ctx, id)
+	ret0, _ := ret[0].(pgtype.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockWordSet indicates an expected call of LockWordSet.
+func (mr *MockWordSetRepoMockRecorder) LockWordSet(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockWordSet", reflect.TypeOf((*MockWordSetRepo)(nil).LockWordSet), ctx, id)
+}
+
+// RemoveWordSetItem mocks base method.
+func (m *MockWordSetRepo) RemoveWordSetItem(ctx context.Context, arg db.RemoveWordSetItemParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveWordSetItem", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveWordSetItem indicates an expected call of RemoveWordSetItem.
+func (mr *MockWordSetRepoMockRecorder) RemoveWordSetItem(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveWordSetItem", reflect.TypeOf((*MockWordSetRepo)(nil).RemoveWordSetItem), ctx, arg)
+}
+
+// ReorderWordSetItems mocks base method.
+func (m *MockWordSetRepo) ReorderWordSetItems(ctx context.Context, arg db.ReorderWordSetItemsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderWordSetItems", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderWordSetItems indicates an expected call of ReorderWordSetItems.
+func (mr *MockWordSetRepoMockRecorder) ReorderWordSetItems(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderWordSetItems", reflect.TypeOf((*MockWordSetRepo)(nil).ReorderWordSetItems), ctx, arg)
+}
+
+// UpdateWordSet mocks base method.
+func (m *MockWordSetRepo) UpdateWordSet(ctx context.Context, arg db.UpdateWordSetParams) (db.WordSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWordSet", ctx, arg)
+	ret0, _ := ret[0].(db.WordSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWordSet indicates an expected call of UpdateWordSet.
+func (mr *MockWordSetRepoMockRecorder) UpdateWordSet(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWordSet", reflect.TypeOf((*MockWordSetRepo)(nil).UpdateWordSet), ctx, arg)
+}
+
+// MockGameRepo is a mock of GameRepo interface.
+type MockGameRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockGameRepoMockRecorder
+}
+
+// MockGameRepoMockRecorder is the mock recorder for MockGameRepo.
+type MockGameRepoMockRecorder struct {
+	mock *MockGameRepo
+}
+
+// NewMockGameRepo creates a new mock instance.
+func NewMockGameRepo(ctrl *gomock.Controller) *MockGameRepo {
+	mock := &MockGameRepo{ctrl: ctrl}
+	mock.recorder = &MockGameRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGameRepo) EXPECT() *MockGameRepoMockRecorder {
+	return m.recorder
+}
+
+// AnswerGameQuestion mocks base method.
+func (m *MockGameRepo) AnswerGameQuestion(ctx context.Context, arg db.AnswerGameQuestionParams) (db.GameQuestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnswerGameQuestion", ctx, arg)
+	ret0, _ := ret[0].(db.GameQuestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnswerGameQuestion indicates an expected call of AnswerGameQuestion.
+func (mr *MockGameRepoMockRecorder) AnswerGameQuestion(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnswerGameQuestion", reflect.TypeOf((*MockGameRepo)(nil).AnswerGameQuestion), ctx, arg)
+}
+
+// CreateGameQuestion mocks base method.
+func (m *MockGameRepo) CreateGameQuestion(ctx context.Context, arg db.CreateGameQuestionParams) (db.GameQuestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGameQuestion", ctx, arg)
+	ret0, _ := ret[0].(db.GameQuestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGameQuestion indicates an expected call of CreateGameQuestion.
+func (mr *MockGameRepoMockRecorder) CreateGameQuestion(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGameQuestion", reflect.TypeOf((*MockGameRepo)(nil).CreateGameQuestion), ctx, arg)
+}
+
+// GetGameQuestion mocks base method.
+func (m *MockGameRepo) GetGameQuestion(ctx context.Context, id pgtype.UUID) (db.GameQuestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGameQuestion", ctx, id)
+	ret0, _ := ret[0].(db.GameQuestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGameQuestion indicates an expected call of GetGameQuestion.
+func (mr *MockGameRepoMockRecorder) GetGameQuestion(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGameQuestion", reflect.TypeOf((*MockGameRepo)(nil).GetGameQuestion), ctx, id)
+}
+
+// GetGameSummary mocks base method.
+func (m *MockGameRepo) GetGameSummary(ctx context.Context, sessionID pgtype.UUID) (db.GetGameSummaryRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGameSummary", ctx, sessionID)
+	ret0, _ := ret[0].(db.GetGameSummaryRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGameSummary indicates an expected call of GetGameSummary.
+func (mr *MockGameRepoMockRecorder) GetGameSummary(ctx, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGameSummary", reflect.TypeOf((*MockGameRepo)(nil).GetGameSummary), ctx, sessionID)
+}
+
+// GetNextGameQuestion mocks base method.
+func (m *MockGameRepo) GetNextGameQuestion(ctx context.Context, sessionID pgtype.UUID) (db.GameQuestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNextGameQuestion", ctx, sessionID)
+	ret0, _ := ret[0].(db.GameQuestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNextGameQuestion indicates an expected call of GetNextGameQuestion.
+func (mr *MockGameRepoMockRecorder) GetNextGameQuestion(ctx, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNextGameQuestion", reflect.TypeOf((*MockGameRepo)(nil).GetNextGameQuestion), ctx, sessionID)
+}
+
+// PickDistractors mocks base method.
+func (m *MockGameRepo) PickDistractors(ctx context.Context, arg db.PickDistractorsParams) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PickDistractors", ctx, arg)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PickDistractors indicates an expected call of PickDistractors.
+func (mr *MockGameRepoMockRecorder) PickDistractors(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PickDistractors", reflect.TypeOf((*MockGameRepo)(nil).PickDistractors), ctx, arg)
+}
+
+// PickRoundWords mocks base method.
+func (m *MockGameRepo) PickRoundWords(ctx context.Context, arg db.PickRoundWordsParams) ([]db.Word, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PickRoundWords", ctx, arg)
+	ret0, _ := ret[0].([]db.Word)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PickRoundWords indicates an expected call of PickRoundWords.
+func (mr *MockGameRepoMockRecorder) PickRoundWords(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PickRoundWords", reflect.TypeOf((*MockGameRepo)(nil).PickRoundWords), ctx, arg)
+}
+
+// MockImportRepo is a mock of ImportRepo interface.
+type MockImportRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportRepoMockRecorder
+}
+
+// MockImportRepoMockRecorder is the mock recorder for MockImportRepo.
+type MockImportRepoMockRecorder struct {
+	mock *MockImportRepo
+}
+
+// NewMockImportRepo creates a new mock instance.
+func NewMockImportRepo(ctrl *gomock.Controller) *MockImportRepo {
+	mock := &MockImportRepo{ctrl: ctrl}
+	mock.recorder = &MockImportRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportRepo) EXPECT() *MockImportRepoMockRecorder {
+	return m.recorder
+}
+
+// CheckpointImportJob mocks base method.
+func (m *MockImportRepo) CheckpointImportJob(ctx context.Context, arg db.CheckpointImportJobParams) (db.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckpointImportJob", ctx, arg)
+	ret0, _ := ret[0].(db.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckpointImportJob indicates an expected call of CheckpointImportJob.
+func (mr *MockImportRepoMockRecorder) CheckpointImportJob(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckpointImportJob", reflect.TypeOf((*MockImportRepo)(nil).CheckpointImportJob), ctx, arg)
+}
+
+// ClaimImportJob mocks base method.
+func (m *MockImportRepo) ClaimImportJob(ctx context.Context, arg db.ClaimImportJobParams) (db.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimImportJob", ctx, arg)
+	ret0, _ := ret[0].(db.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimImportJob indicates an expected call of ClaimImportJob.
+func (mr *MockImportRepoMockRecorder) ClaimImportJob(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimImportJob", reflect.TypeOf((*MockImportRepo)(nil).ClaimImportJob), ctx, arg)
+}
+
+// CreateImportJob mocks base method.
+func (m *MockImportRepo) CreateImportJob(ctx context.Context, arg db.CreateImportJobParams) (db.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateImportJob", ctx, arg)
+	ret0, _ := ret[0].(db.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateImportJob indicates an expected call of CreateImportJob.
+func (mr *MockImportRepoMockRecorder) CreateImportJob(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateImportJob", reflect.TypeOf((*MockImportRepo)(nil).CreateImportJob), ctx, arg)
+}
+
+// CreateImportJobError mocks base method.
+func (m *MockImportRepo) CreateImportJobError(ctx context.Context, arg db.CreateImportJobErrorParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateImportJobError", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateImportJobError indicates an expected call of CreateImportJobError.
+func (mr *MockImportRepoMockRecorder) CreateImportJobError(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateImportJobError", reflect.TypeOf((*MockImportRepo)(nil).CreateImportJobError), ctx, arg)
+}
+
+// CreateImportJobPayload mocks base method.
+func (m *MockImportRepo) CreateImportJobPayload(ctx context.Context, arg db.CreateImportJobPayloadParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateImportJobPayload", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateImportJobPayload indicates an expected call of CreateImportJobPayload.
+func (mr *MockImportRepoMockRecorder) CreateImportJobPayload(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateImportJobPayload", reflect.TypeOf((*MockImportRepo)(nil).CreateImportJobPayload), ctx, arg)
+}
+
+// FinishImportJob mocks base method.
+func (m *MockImportRepo) FinishImportJob(ctx context.Context, arg db.FinishImportJobParams) (db.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishImportJob", ctx, arg)
+	ret0, _ := ret[0].(db.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinishImportJob indicates an expected call of FinishImportJob.
+func (mr *MockImportRepoMockRecorder) FinishImportJob(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishImportJob", reflect.TypeOf((*MockImportRepo)(nil).FinishImportJob), ctx, arg)
+}
+
+// GetImportJob mocks base method.
+func (m *MockImportRepo) GetImportJob(ctx context.Context, id pgtype.UUID) (db.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImportJob", ctx, id)
+	ret0, _ := ret[0].(db.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImportJob indicates an expected call of GetImportJob.
+func (mr *MockImportRepoMockRecorder) GetImportJob(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImportJob", reflect.TypeOf((*MockImportRepo)(nil).GetImportJob), ctx, id)
+}
+
+// GetImportJobPayload mocks base method.
+func (m *MockImportRepo) GetImportJobPayload(ctx context.Context, jobID pgtype.UUID) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImportJobPayload", ctx, jobID)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImportJobPayload indicates an expected call of GetImportJobPayload.
+func (mr *MockImportRepoMockRecorder) GetImportJobPayload(ctx, jobID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImportJobPayload", reflect.TypeOf((*MockImportRepo)(nil).GetImportJobPayload), ctx, jobID)
+}
+
+// ListImportJobErrors mocks base method.
+func (m *MockImportRepo) ListImportJobErrors(ctx context.Context, arg db.ListImportJobErrorsParams) ([]db.ImportJobError, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListImportJobErrors", ctx, arg)
+	ret0, _ := ret[0].([]db.ImportJobError)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListImportJobErrors indicates an expected call of ListImportJobErrors.
+func (mr *MockImportRepoMockRecorder) ListImportJobErrors(ctx, arg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImportJobErrors", reflect.TypeOf((*MockImportRepo)(nil).ListImportJobErrors), ctx, arg)
+}
+
+// RetryImportJob mocks base method.
+func (m *MockImportRepo) RetryImportJob(ctx context.Context, id pgtype.UUID) (db.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryImportJob", ctx, id)
+	ret0, _ := ret[0].(db.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetryImportJob indicates an expected call of RetryImportJob.
+func (mr *MockImportRepoMockRecorder) RetryImportJob(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryImportJob", reflect.TypeOf((*MockImportRepo)(nil).RetryImportJob), ctx, id)
+}
+
+// MockTx is a mock of Tx interface.
+type MockTx struct {
+	ctrl     *gomock.Controller
+	recorder *MockTxMockRecorder
+}
+
+// MockTxMockRecorder is the mock recorder for MockTx.
+type MockTxMockRecorder struct {
+	mock *MockTx
+}
+
+// NewMockTx creates a new mock instance.
+func NewMockTx(ctrl *gomock.Controller) *MockTx {
+	mock := &MockTx{ctrl: ctrl}
+	mock.recorder = &MockTxMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTx) EXPECT() *MockTxMockRecorder {
+	return m.recorder
+}
+
+// Auth mocks base method.
+func (m *MockTx) Auth() db.AuthRepo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Auth")
+	ret0, _ := ret[0].(db.AuthRepo)
+	return ret0
+}
+
+// Auth indicates an expected call of Auth.
+func (mr *MockTxMockRecorder) Auth() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Auth", reflect.TypeOf((*MockTx)(nil).Auth))
+}
+
+// Games mocks base method.
+func (m *MockTx) Games() db.GameRepo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Games")
+	ret0, _ := ret[0].(db.GameRepo)
+	return ret0
+}
+
+// Games indicates an expected call of Games.
+func (mr *MockTxMockRecorder) Games() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Games", reflect.TypeOf((*MockTx)(nil).Games))
+}
+
+// Imports mocks base method.
+func (m *MockTx) Imports() db.ImportRepo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Imports")
+	ret0, _ := ret[0].(db.ImportRepo)
+	return ret0
+}
+
+// Imports indicates an expected call of Imports.
+func (mr *MockTxMockRecorder) Imports() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Imports", reflect.TypeOf((*MockTx)(nil).Imports))
+}
+
+// Progress mocks base method.
+func (m *MockTx) Progress() db.UserProgressRepo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Progress")
+	ret0, _ := ret[0].(db.UserProgressRepo)
+	return ret0
+}
+
+// Progress indicates an expected call of Progress.
+func (mr *MockTxMockRecorder) Progress() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Progress", reflect.TypeOf((*MockTx)(nil).Progress))
+}
+
+// Sessions mocks base method.
+func (m *MockTx) Sessions() db.UserSessionRepo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sessions")
+	ret0, _ := ret[0].(db.UserSessionRepo)
+	return ret0
+}
+
+// Sessions indicates an expected call of Sessions.
+func (mr *MockTxMockRecorder) Sessions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sessions", reflect.TypeOf((*MockTx)(nil).Sessions))
+}
+
+// Statistics mocks base method.
+func (m *MockTx) Statistics() db.UserStatisticsRepo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Statistics")
+	ret0, _ := ret[0].(db.UserStatisticsRepo)
+	return ret0
+}
+
+// Statistics indicates an expected call of Statistics.
+func (mr *MockTxMockRecorder) Statistics() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Statistics", reflect.TypeOf((*MockTx)(nil).Statistics))
+}
+
+// UserWordSets mocks base method.
+func (m *MockTx) UserWordSets() db.UserWordSetRepo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserWordSets")
+	ret0, _ := ret[0].(db.UserWordSetRepo)
+	return ret0
+}
+
+// UserWordSets indicates an expected call of UserWordSets.
+func (mr *MockTxMockRecorder) UserWordSets() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserWordSets", reflect.TypeOf((*MockTx)(nil).UserWordSets))
+}
+
+// Users mocks base method.
+func (m *MockTx) Users() db.UserRepo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Users")
+	ret0, _ := ret[0].(db.UserRepo)
+	return ret0
+}
+
+// Users indicates an expected call of Users.
+func (mr *MockTxMockRecorder) Users() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Users", reflect.TypeOf((*MockTx)(nil).Users))
+}
+
+// WordSets mocks base method.
+func (m *MockTx) WordSets() db.WordSetRepo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WordSets")
+	ret0, _ := ret[0].(db.WordSetRepo)
+	return ret0
+}
+
+// WordSets indicates an expected call of WordSets.
+func (mr *MockTxMockRecorder) WordSets() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WordSets", reflect.TypeOf((*MockTx)(nil).WordSets))
+}
+
+// Words mocks base method.
+func (m *MockTx) Words() db.WordRepo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Words")
+	ret0, _ := ret[0].(db.WordRepo)
+	return ret0
+}
+
+// Words indicates an expected call of Words.
+func (mr *MockTxMockRecorder) Words() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Words", reflect.TypeOf((*MockTx)(nil).Words))
+}
+
+// MockTxRunner is a mock of TxRunner interface.
+type MockTxRunner struct {
+	ctrl     *gomock.Controller
+	recorder *MockTxRunnerMockRecorder
+}
+
+// MockTxRunnerMockRecorder is the mock recorder for MockTxRunner.
+type MockTxRunnerMockRecorder struct {
+	mock *MockTxRunner
+}
+
+// NewMockTxRunner creates a new mock instance.
+func NewMockTxRunner(ctrl *gomock.Controller) *MockTxRunner {
+	mock := &MockTxRunner{ctrl: ctrl}
+	mock.recorder = &MockTxRunnerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTxRunner) EXPECT() *MockTxRunnerMockRecorder {
+	return m.recorder
+}
+
+// InTx mocks base method.
+func (m *MockTxRunner) InTx(ctx context.Context, fn func(db.Tx) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InTx", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InTx indicates an expected call of InTx.
+func (mr *MockTxRunnerMockRecorder) InTx(ctx, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InTx", reflect.TypeOf((*MockTxRunner)(nil).InTx), ctx, fn)
+}
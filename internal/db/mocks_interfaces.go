@@ -10,10 +10,26 @@ type UserRepo interface {
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
 	GetUser(ctx context.Context, id pgtype.UUID) (User, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
+	GetUserIncludingDeleted(ctx context.Context, id pgtype.UUID) (User, error)
 	ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error)
-	CountUsers(ctx context.Context) (int64, error)
+	ListUsersAfter(ctx context.Context, arg ListUsersAfterParams) ([]User, error)
+	ListUsersBefore(ctx context.Context, arg ListUsersBeforeParams) ([]User, error)
+	CountUsers(ctx context.Context, arg CountUsersParams) (int64, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
-	DeleteUser(ctx context.Context, id pgtype.UUID) error
+	SetUserActive(ctx context.Context, arg SetUserActiveParams) (User, error)
+	DeleteUser(ctx context.Context, id pgtype.UUID) (int64, error)
+	RestoreUser(ctx context.Context, arg RestoreUserParams) (User, error)
+	PurgeDeletedUsers(ctx context.Context, deletedBefore pgtype.Timestamptz) (int64, error)
+}
+
+type AuthRepo interface {
+	RegisterUser(ctx context.Context, arg RegisterUserParams) (User, error)
+	GetUser(ctx context.Context, id pgtype.UUID) (User, error)
+	GetUserCredentialsByEmail(ctx context.Context, email string) (GetUserCredentialsByEmailRow, error)
+	CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) (RefreshToken, error)
+	GetRefreshTokenByHash(ctx context.Context, tokenHash []byte) (RefreshToken, error)
+	RevokeRefreshToken(ctx context.Context, id pgtype.UUID) (int64, error)
+	RevokeRefreshTokenFamily(ctx context.Context, familyID pgtype.UUID) error
 }
 
 type UserSessionRepo interface {
@@ -21,25 +37,38 @@ type UserSessionRepo interface {
 	GetUserSession(ctx context.Context, id pgtype.UUID) (UserSession, error)
 	ListActiveSessions(ctx context.Context, arg ListActiveSessionsParams) ([]UserSession, error)
 	ListUserSessions(ctx context.Context, arg ListUserSessionsParams) ([]UserSession, error)
+	ListUserSessionsAfter(ctx context.Context, arg ListUserSessionsAfterParams) ([]UserSession, error)
+	ListUserSessionsBefore(ctx context.Context, arg ListUserSessionsBeforeParams) ([]UserSession, error)
 	UpdateUserSession(ctx context.Context, arg UpdateUserSessionParams) (UserSession, error)
-	DeleteUserSession(ctx context.Context, id pgtype.UUID) error
+	RecordSessionActivity(ctx context.Context, arg RecordSessionActivityParams) (UserSession, error)
+	CreateCompletedUserSession(ctx context.Context, arg CreateCompletedUserSessionParams) (UserSession, error)
+	CreateUserSessionEvent(ctx context.Context, arg CreateUserSessionEventParams) (UserSessionEvent, error)
+	CreateAnswerSessionEvents(ctx context.Context, arg CreateAnswerSessionEventsParams) (int64, error)
+	AbandonActiveUserSessions(ctx context.Context, userID pgtype.UUID) (int64, error)
+	AbandonIdleSessions(ctx context.Context, idleBefore pgtype.Timestamptz) (int64, error)
+	DeleteUserSession(ctx context.Context, arg DeleteUserSessionParams) (int64, error)
 }
 
 type UserProgressRepo interface {
 	CreateUserProgress(ctx context.Context, arg CreateUserProgressParams) (UserProgress, error)
 	GetUserProgress(ctx context.Context, id pgtype.UUID) (UserProgress, error)
 	GetUserProgressByUserAndWord(ctx context.Context, arg GetUserProgressByUserAndWordParams) (UserProgress, error)
-	ListUserProgress(ctx context.Context, userID pgtype.UUID) ([]UserProgress, error)
+	ListUserProgressByWords(ctx context.Context, arg ListUserProgressByWordsParams) ([]UserProgress, error)
+	ListUserProgress(ctx context.Context, arg ListUserProgressParams) ([]UserProgress, error)
+	ListUserProgressAfter(ctx context.Context, arg ListUserProgressAfterParams) ([]UserProgress, error)
+	ListUserProgressBefore(ctx context.Context, arg ListUserProgressBeforeParams) ([]UserProgress, error)
+	ListDueReviews(ctx context.Context, arg ListDueReviewsParams) ([]ListDueReviewsRow, error)
 	UpdateUserProgress(ctx context.Context, arg UpdateUserProgressParams) (UserProgress, error)
-	DeleteUserProgress(ctx context.Context, id pgtype.UUID) error
+	RecordUserProgressAttempt(ctx context.Context, arg RecordUserProgressAttemptParams) (UserProgress, error)
+	DeleteUserProgress(ctx context.Context, arg DeleteUserProgressParams) (int64, error)
 }
 
 type UserStatisticsRepo interface {
-	CreateUserStatistics(ctx context.Context, arg CreateUserStatisticsParams) (UserStatistic, error)
 	GetUserStatistics(ctx context.Context, userID pgtype.UUID) (UserStatistic, error)
 	ListUserStatistics(ctx context.Context, arg ListUserStatisticsParams) ([]UserStatistic, error)
-	UpdateUserStatistics(ctx context.Context, arg UpdateUserStatisticsParams) (UserStatistic, error)
-	DeleteUserStatistics(ctx context.Context, userID pgtype.UUID) error
+	ListUserStatisticsAfter(ctx context.Context, arg ListUserStatisticsAfterParams) ([]UserStatistic, error)
+	ListUserStatisticsBefore(ctx context.Context, arg ListUserStatisticsBeforeParams) ([]UserStatistic, error)
+	RecomputeUserStatistics(ctx context.Context, userID pgtype.UUID) (UserStatistic, error)
 }
 
 type UserWordSetRepo interface {
@@ -47,5 +76,73 @@ type UserWordSetRepo interface {
 	GetUserWordSet(ctx context.Context, id pgtype.UUID) (UserWordSet, error)
 	ListUserWordSets(ctx context.Context, arg ListUserWordSetsParams) ([]UserWordSet, error)
 	UpdateUserWordSet(ctx context.Context, arg UpdateUserWordSetParams) (UserWordSet, error)
-	DeleteUserWordSet(ctx context.Context, id pgtype.UUID) error
+	DeleteUserWordSet(ctx context.Context, arg DeleteUserWordSetParams) (int64, error)
+}
+
+type WordRepo interface {
+	CreateWord(ctx context.Context, arg CreateWordParams) (Word, error)
+	CreateWordIfMissing(ctx context.Context, arg CreateWordIfMissingParams) (Word, error)
+	GetWord(ctx context.Context, id pgtype.UUID) (Word, error)
+	GetWordByLemma(ctx context.Context, arg GetWordByLemmaParams) (Word, error)
+	ListWordsByIDs(ctx context.Context, ids []pgtype.UUID) ([]Word, error)
+	ListWordsByLemmas(ctx context.Context, arg ListWordsByLemmasParams) ([]Word, error)
+	SearchWords(ctx context.Context, arg SearchWordsParams) ([]Word, error)
+	UpdateWord(ctx context.Context, arg UpdateWordParams) (Word, error)
+	DeleteWord(ctx context.Context, id pgtype.UUID) error
+}
+
+type WordSetRepo interface {
+	CreateWordSet(ctx context.Context, arg CreateWordSetParams) (WordSet, error)
+	GetWordSet(ctx context.Context, id pgtype.UUID) (WordSet, error)
+	ListWordSets(ctx context.Context, arg ListWordSetsParams) ([]WordSet, error)
+	LockWordSet(ctx context.Context, id pgtype.UUID) (pgtype.UUID, error)
+	UpdateWordSet(ctx context.Context, arg UpdateWordSetParams) (WordSet, error)
+	DeleteWordSet(ctx context.Context, id pgtype.UUID) error
+	AddWordSetItem(ctx context.Context, arg AddWordSetItemParams) (WordSetItem, error)
+	AddWordSetItemIfMissing(ctx context.Context, arg AddWordSetItemIfMissingParams) (int64, error)
+	RemoveWordSetItem(ctx context.Context, arg RemoveWordSetItemParams) (int64, error)
+	ReorderWordSetItems(ctx context.Context, arg ReorderWordSetItemsParams) (int64, error)
+	CountWordSetItems(ctx context.Context, wordSetID pgtype.UUID) (int64, error)
+	ListWordSetItems(ctx context.Context, arg ListWordSetItemsParams) ([]ListWordSetItemsRow, error)
+}
+
+type GameRepo interface {
+	PickRoundWords(ctx context.Context, arg PickRoundWordsParams) ([]Word, error)
+	PickDistractors(ctx context.Context, arg PickDistractorsParams) ([]string, error)
+	CreateGameQuestion(ctx context.Context, arg CreateGameQuestionParams) (GameQuestion, error)
+	GetGameQuestion(ctx context.Context, id pgtype.UUID) (GameQuestion, error)
+	GetNextGameQuestion(ctx context.Context, sessionID pgtype.UUID) (GameQuestion, error)
+	AnswerGameQuestion(ctx context.Context, arg AnswerGameQuestionParams) (GameQuestion, error)
+	GetGameSummary(ctx context.Context, sessionID pgtype.UUID) (GetGameSummaryRow, error)
+}
+
+type ImportRepo interface {
+	CreateImportJob(ctx context.Context, arg CreateImportJobParams) (ImportJob, error)
+	CreateImportJobPayload(ctx context.Context, arg CreateImportJobPayloadParams) error
+	GetImportJob(ctx context.Context, id pgtype.UUID) (ImportJob, error)
+	GetImportJobPayload(ctx context.Context, jobID pgtype.UUID) ([]byte, error)
+	ClaimImportJob(ctx context.Context, arg ClaimImportJobParams) (ImportJob, error)
+	CheckpointImportJob(ctx context.Context, arg CheckpointImportJobParams) (ImportJob, error)
+	FinishImportJob(ctx context.Context, arg FinishImportJobParams) (ImportJob, error)
+	RetryImportJob(ctx context.Context, id pgtype.UUID) (ImportJob, error)
+	CreateImportJobError(ctx context.Context, arg CreateImportJobErrorParams) error
+	ListImportJobErrors(ctx context.Context, arg ListImportJobErrorsParams) ([]ImportJobError, error)
+}
+
+// Tx hands out repositories bound to a single database transaction.
+type Tx interface {
+	Users() UserRepo
+	Auth() AuthRepo
+	Sessions() UserSessionRepo
+	Progress() UserProgressRepo
+	Statistics() UserStatisticsRepo
+	UserWordSets() UserWordSetRepo
+	Words() WordRepo
+	WordSets() WordSetRepo
+	Games() GameRepo
+	Imports() ImportRepo
+}
+
+type TxRunner interface {
+	InTx(ctx context.Context, fn func(tx Tx) error) error
 }
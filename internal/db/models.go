@@ -8,12 +8,78 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type GameQuestion struct {
+	ID          pgtype.UUID        `json:"id"`
+	SessionID   pgtype.UUID        `json:"session_id"`
+	WordID      pgtype.UUID        `json:"word_id"`
+	Position    int32              `json:"position"`
+	Kind        string             `json:"kind"`
+	Prompt      string             `json:"prompt"`
+	Choices     []string           `json:"choices"`
+	Answer      string             `json:"answer"`
+	GivenAnswer *string            `json:"given_answer"`
+	IsCorrect   *bool              `json:"is_correct"`
+	AnsweredAt  pgtype.Timestamptz `json:"answered_at"`
+}
+
+type ImportJob struct {
+	ID             pgtype.UUID        `json:"id"`
+	CreatedBy      pgtype.UUID        `json:"created_by"`
+	Filename       string             `json:"filename"`
+	Format         string             `json:"format"`
+	SourceLang     string             `json:"source_lang"`
+	TargetLang     string             `json:"target_lang"`
+	WordSetID      pgtype.UUID        `json:"word_set_id"`
+	Status         string             `json:"status"`
+	TotalRows      int32              `json:"total_rows"`
+	ProcessedRows  int32              `json:"processed_rows"`
+	CreatedWords   int32              `json:"created_words"`
+	ExistingWords  int32              `json:"existing_words"`
+	FailedRows     int32              `json:"failed_rows"`
+	Attempts       int32              `json:"attempts"`
+	ErrorCode      *string            `json:"error_code"`
+	LeaseExpiresAt pgtype.Timestamptz `json:"lease_expires_at"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	FinishedAt     pgtype.Timestamptz `json:"finished_at"`
+}
+
+type ImportJobError struct {
+	JobID     pgtype.UUID        `json:"job_id"`
+	Line      int32              `json:"line"`
+	Error     []byte             `json:"error"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type ImportJobPayload struct {
+	JobID pgtype.UUID `json:"job_id"`
+	Data  []byte      `json:"data"`
+}
+
+type RefreshToken struct {
+	ID        pgtype.UUID        `json:"id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	FamilyID  pgtype.UUID        `json:"family_id"`
+	TokenHash []byte             `json:"token_hash"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	RevokedAt pgtype.Timestamptz `json:"revoked_at"`
+}
+
 type User struct {
 	ID        pgtype.UUID        `json:"id"`
 	Username  string             `json:"username"`
 	Email     string             `json:"email"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 	IsActive  bool               `json:"is_active"`
+	Role      string             `json:"role"`
+	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+}
+
+type UserCredential struct {
+	UserID       pgtype.UUID        `json:"user_id"`
+	PasswordHash string             `json:"password_hash"`
+	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
 }
 
 type UserProgress struct {
@@ -23,14 +89,28 @@ type UserProgress struct {
 	CorrectCount   int32              `json:"correct_count"`
 	IncorrectCount int32              `json:"incorrect_count"`
 	LastAttempt    pgtype.Timestamptz `json:"last_attempt"`
+	EaseFactor     float64            `json:"ease_factor"`
+	IntervalDays   int32              `json:"interval_days"`
+	Repetitions    int32              `json:"repetitions"`
+	DueAt          pgtype.Timestamptz `json:"due_at"`
 }
 
 type UserSession struct {
-	ID        pgtype.UUID        `json:"id"`
-	UserID    pgtype.UUID        `json:"user_id"`
-	StartedAt pgtype.Timestamptz `json:"started_at"`
-	EndedAt   pgtype.Timestamptz `json:"ended_at"`
-	Status    string             `json:"status"`
+	ID            pgtype.UUID        `json:"id"`
+	UserID        pgtype.UUID        `json:"user_id"`
+	StartedAt     pgtype.Timestamptz `json:"started_at"`
+	EndedAt       pgtype.Timestamptz `json:"ended_at"`
+	Status        string             `json:"status"`
+	LastActiveAt  pgtype.Timestamptz `json:"last_active_at"`
+	ActiveSeconds int32              `json:"active_seconds"`
+	Paused        bool               `json:"paused"`
+}
+
+type UserSessionEvent struct {
+	ID         pgtype.UUID        `json:"id"`
+	SessionID  pgtype.UUID        `json:"session_id"`
+	Kind       string             `json:"kind"`
+	OccurredAt pgtype.Timestamptz `json:"occurred_at"`
 }
 
 type UserStatistic struct {
@@ -39,6 +119,8 @@ type UserStatistic struct {
 	Accuracy          pgtype.Numeric     `json:"accuracy"`
 	TotalTime         int32              `json:"total_time"`
 	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
+	CorrectAttempts   int64              `json:"correct_attempts"`
+	IncorrectAttempts int64              `json:"incorrect_attempts"`
 }
 
 type UserWordSet struct {
@@ -47,3 +129,34 @@ type UserWordSet struct {
 	WordSetID pgtype.UUID        `json:"word_set_id"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 }
+
+type Word struct {
+	ID           pgtype.UUID        `json:"id"`
+	Lemma        string             `json:"lemma"`
+	Translation  string             `json:"translation"`
+	PartOfSpeech string             `json:"part_of_speech"`
+	SourceLang   string             `json:"source_lang"`
+	TargetLang   string             `json:"target_lang"`
+	Examples     []string           `json:"examples"`
+	Difficulty   int16              `json:"difficulty"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+}
+
+type WordSet struct {
+	ID          pgtype.UUID        `json:"id"`
+	Title       string             `json:"title"`
+	Description string             `json:"description"`
+	Language    string             `json:"language"`
+	OwnerID     pgtype.UUID        `json:"owner_id"`
+	Visibility  string             `json:"visibility"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
+type WordSetItem struct {
+	WordSetID pgtype.UUID        `json:"word_set_id"`
+	WordID    pgtype.UUID        `json:"word_id"`
+	Position  int32              `json:"position"`
+	AddedAt   pgtype.Timestamptz `json:"added_at"`
+}
@@ -0,0 +1,40 @@
+-- name: RegisterUser :one
+WITH new_user AS (
+  INSERT INTO users (username, email)
+  SELECT sqlc.arg('username')::text, sqlc.arg('email')::citext
+  WHERE NOT EXISTS (SELECT 1 FROM users WHERE email = sqlc.arg('email')::citext AND deleted_at IS NULL)
+  RETURNING *
+), credentials AS (
+  INSERT INTO user_credentials (user_id, password_hash)
+  SELECT id, sqlc.arg('password_hash')::text FROM new_user
+)
+SELECT * FROM new_user;
+
+-- name: GetUserCredentialsByEmail :one
+SELECT users.id, users.is_active, users.role, user_credentials.password_hash
+FROM users
+JOIN user_credentials ON user_credentials.user_id = users.id
+WHERE users.email = $1 AND users.deleted_at IS NULL
+LIMIT 1;
+
+-- name: CreateRefreshToken :one
+INSERT INTO refresh_tokens (
+  user_id, family_id, token_hash, expires_at
+) VALUES (
+  $1, $2, $3, $4
+)
+RETURNING *;
+
+-- name: GetRefreshTokenByHash :one
+SELECT * FROM refresh_tokens
+WHERE token_hash = $1 LIMIT 1;
+
+-- name: RevokeRefreshToken :execrows
+UPDATE refresh_tokens
+SET revoked_at = NOW()
+WHERE id = $1 AND revoked_at IS NULL;
+
+-- name: RevokeRefreshTokenFamily :exec
+UPDATE refresh_tokens
+SET revoked_at = NOW()
+WHERE family_id = $1 AND revoked_at IS NULL;
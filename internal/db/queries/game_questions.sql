@@ -0,0 +1,45 @@
+-- name: PickRoundWords :many
+SELECT words.* FROM word_set_items
+JOIN words ON words.id = word_set_items.word_id
+WHERE word_set_items.word_set_id = $1
+ORDER BY random()
+LIMIT $2;
+
+-- name: PickDistractors :many
+SELECT translation FROM (
+  SELECT DISTINCT translation FROM words
+  WHERE source_lang = $1 AND target_lang = $2 AND translation <> sqlc.arg('answer')
+) AS candidates
+ORDER BY random()
+LIMIT $3;
+
+-- name: CreateGameQuestion :one
+INSERT INTO game_questions (
+  session_id, word_id, position, kind, prompt, choices, answer
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING *;
+
+-- name: GetGameQuestion :one
+SELECT * FROM game_questions
+WHERE id = $1 LIMIT 1;
+
+-- name: GetNextGameQuestion :one
+SELECT * FROM game_questions
+WHERE session_id = $1 AND answered_at IS NULL
+ORDER BY position
+LIMIT 1;
+
+-- name: AnswerGameQuestion :one
+UPDATE game_questions
+SET given_answer = $1, is_correct = $2, answered_at = NOW()
+WHERE id = $3 AND answered_at IS NULL
+RETURNING *;
+
+-- name: GetGameSummary :one
+SELECT COUNT(*) AS total,
+       COUNT(answered_at) AS answered,
+       COUNT(*) FILTER (WHERE is_correct) AS correct
+FROM game_questions
+WHERE session_id = $1;
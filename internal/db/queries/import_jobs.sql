@@ -0,0 +1,73 @@
+-- name: CreateImportJob :one
+INSERT INTO import_jobs (
+  created_by, filename, format, source_lang, target_lang, word_set_id, total_rows
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING *;
+
+-- name: CreateImportJobPayload :exec
+INSERT INTO import_job_payloads (job_id, data)
+VALUES ($1, $2);
+
+-- name: GetImportJob :one
+SELECT * FROM import_jobs
+WHERE id = $1 LIMIT 1;
+
+-- name: GetImportJobPayload :one
+SELECT data FROM import_job_payloads
+WHERE job_id = $1;
+
+-- name: ClaimImportJob :one
+-- Leases the oldest job that is pending or whose runner stopped renewing its lease.
+-- A NULL id claims any such job. SKIP LOCKED lets several runners poll side by side.
+UPDATE import_jobs
+SET status = 'running', lease_expires_at = sqlc.arg('lease_expires_at'), attempts = attempts + 1, updated_at = NOW()
+WHERE id = (
+  SELECT j.id FROM import_jobs AS j
+  WHERE (sqlc.narg('id')::uuid IS NULL OR j.id = sqlc.narg('id'))
+    AND (j.status = 'pending' OR (j.status = 'running' AND j.lease_expires_at < NOW()))
+  ORDER BY j.created_at
+  LIMIT 1
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: CheckpointImportJob :one
+-- Records a processed batch and renews the lease. The batch only counts when the job
+-- is still where the runner started it, so a runner whose lease was taken over
+-- gets no row back and rolls its batch back.
+UPDATE import_jobs
+SET processed_rows = sqlc.arg('processed_rows'),
+    created_words = created_words + sqlc.arg('created_words'),
+    existing_words = existing_words + sqlc.arg('existing_words'),
+    failed_rows = failed_rows + sqlc.arg('failed_rows'),
+    lease_expires_at = sqlc.arg('lease_expires_at'),
+    updated_at = NOW()
+WHERE id = sqlc.arg('id') AND status = 'running' AND processed_rows = sqlc.arg('from_row')
+RETURNING *;
+
+-- name: FinishImportJob :one
+UPDATE import_jobs
+SET status = $1, error_code = $2, lease_expires_at = NULL, finished_at = NOW(), updated_at = NOW()
+WHERE id = $3
+RETURNING *;
+
+-- name: RetryImportJob :one
+-- Queues a failed job again; it resumes after the last processed row.
+UPDATE import_jobs
+SET status = 'pending', error_code = NULL, finished_at = NULL, updated_at = NOW()
+WHERE id = $1 AND status = 'failed'
+RETURNING *;
+
+-- name: CreateImportJobError :exec
+-- A batch replayed after a lost lease reports its rows again; the first report wins.
+INSERT INTO import_job_errors (job_id, line, error)
+VALUES ($1, $2, $3)
+ON CONFLICT (job_id, line) DO NOTHING;
+
+-- name: ListImportJobErrors :many
+SELECT * FROM import_job_errors
+WHERE job_id = $1
+ORDER BY line
+LIMIT $2 OFFSET $3;
@@ -6,12 +6,33 @@ WHERE id = $1 LIMIT 1;
 SELECT * FROM user_progress
 WHERE user_id = $1 AND word_id = $2 LIMIT 1;
 
+-- name: ListUserProgressByWords :many
+SELECT * FROM user_progress
+WHERE user_id = sqlc.arg('user_id') AND word_id = ANY(sqlc.arg('word_ids')::uuid[]);
+
 -- name: ListUserProgress :many
 SELECT * FROM user_progress
 WHERE user_id = $1
-ORDER BY last_attempt DESC
+ORDER BY last_attempt DESC, id DESC
 LIMIT $2 OFFSET $3;
 
+-- name: ListUserProgressAfter :many
+-- Keyset page of progress rows strictly after the cursor, newest first.
+SELECT * FROM user_progress
+WHERE user_id = sqlc.arg('user_id')
+  AND (last_attempt, id) < (sqlc.arg('last_attempt')::timestamptz, sqlc.arg('id')::uuid)
+ORDER BY last_attempt DESC, id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: ListUserProgressBefore :many
+-- Keyset page of progress rows strictly before the cursor, oldest first;
+-- callers reverse the rows to restore newest-first order.
+SELECT * FROM user_progress
+WHERE user_id = sqlc.arg('user_id')
+  AND (last_attempt, id) > (sqlc.arg('last_attempt')::timestamptz, sqlc.arg('id')::uuid)
+ORDER BY last_attempt ASC, id ASC
+LIMIT sqlc.arg('limit');
+
 -- name: CreateUserProgress :one
 INSERT INTO user_progress (
   user_id, word_id, correct_count, incorrect_count
@@ -23,9 +44,56 @@ RETURNING *;
 -- name: UpdateUserProgress :one
 UPDATE user_progress
 SET correct_count = $1, incorrect_count = $2, last_attempt = NOW()
-WHERE id = $3
+WHERE id = $3 AND user_id = $4
 RETURNING *;
 
--- name: DeleteUserProgress :exec
+-- name: DeleteUserProgress :execrows
 DELETE FROM user_progress
-WHERE id = $1;
+WHERE id = $1 AND user_id = $2;
+
+-- name: RecordUserProgressAttempt :one
+-- Counters and SM-2 scheduling state are updated in the same statement so
+-- concurrent answers for one word never interleave. quality is the SM-2
+-- grade (0-5); grades below 3 reset the repetition streak. attempted_at
+-- defaults to now; history replayed from elsewhere passes the original time,
+-- and last_attempt never moves backwards.
+INSERT INTO user_progress (
+  user_id, word_id, correct_count, incorrect_count, last_attempt,
+  ease_factor, interval_days, repetitions, due_at
+) VALUES (
+  sqlc.arg('user_id'), sqlc.arg('word_id'),
+  CASE WHEN sqlc.arg('correct')::bool THEN 1 ELSE 0 END,
+  CASE WHEN sqlc.arg('correct')::bool THEN 0 ELSE 1 END,
+  COALESCE(sqlc.narg('attempted_at')::timestamptz, NOW()),
+  GREATEST(1.3, 2.5 + 0.1 - (5 - sqlc.arg('quality')::smallint) * (0.08 + (5 - sqlc.arg('quality')::smallint) * 0.02)),
+  1,
+  CASE WHEN sqlc.arg('quality')::smallint >= 3 THEN 1 ELSE 0 END,
+  COALESCE(sqlc.narg('attempted_at')::timestamptz, NOW()) + INTERVAL '1 day'
+)
+ON CONFLICT (user_id, word_id) DO UPDATE
+SET correct_count = user_progress.correct_count + EXCLUDED.correct_count,
+    incorrect_count = user_progress.incorrect_count + EXCLUDED.incorrect_count,
+    last_attempt = GREATEST(user_progress.last_attempt, EXCLUDED.last_attempt),
+    ease_factor = GREATEST(1.3, user_progress.ease_factor + 0.1 - (5 - sqlc.arg('quality')::smallint) * (0.08 + (5 - sqlc.arg('quality')::smallint) * 0.02)),
+    interval_days = CASE
+      WHEN sqlc.arg('quality')::smallint < 3 THEN 1
+      WHEN user_progress.repetitions = 0 THEN 1
+      WHEN user_progress.repetitions = 1 THEN 6
+      ELSE GREATEST(1, ROUND(user_progress.interval_days * user_progress.ease_factor))::int
+    END,
+    repetitions = CASE WHEN sqlc.arg('quality')::smallint >= 3 THEN user_progress.repetitions + 1 ELSE 0 END,
+    due_at = EXCLUDED.last_attempt + make_interval(days => CASE
+      WHEN sqlc.arg('quality')::smallint < 3 THEN 1
+      WHEN user_progress.repetitions = 0 THEN 1
+      WHEN user_progress.repetitions = 1 THEN 6
+      ELSE GREATEST(1, ROUND(user_progress.interval_days * user_progress.ease_factor))::int
+    END)
+RETURNING *;
+
+-- name: ListDueReviews :many
+SELECT sqlc.embed(user_progress), sqlc.embed(words)
+FROM user_progress
+JOIN words ON words.id = user_progress.word_id
+WHERE user_progress.user_id = sqlc.arg('user_id') AND user_progress.due_at <= sqlc.arg('due_before')
+ORDER BY user_progress.due_at ASC, user_progress.ease_factor ASC
+LIMIT sqlc.arg('limit');
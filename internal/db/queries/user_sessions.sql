@@ -3,16 +3,37 @@ SELECT * FROM user_sessions
 WHERE id = $1 LIMIT 1;
 
 -- name: ListUserSessions :many
-SELECT * FROM user_sessions  
-WHERE user_id = $1
-ORDER BY started_at DESC
-LIMIT $2 OFFSET $3;
+-- A NULL status lists sessions in every state.
+SELECT * FROM user_sessions
+WHERE user_id = sqlc.arg('user_id')
+  AND (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status'))
+ORDER BY started_at DESC, id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ListUserSessionsAfter :many
+-- Keyset page of sessions strictly after the cursor, newest first.
+SELECT * FROM user_sessions
+WHERE user_id = sqlc.arg('user_id')
+  AND (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status'))
+  AND (started_at, id) < (sqlc.arg('started_at')::timestamptz, sqlc.arg('id')::uuid)
+ORDER BY started_at DESC, id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: ListUserSessionsBefore :many
+-- Keyset page of sessions strictly before the cursor, oldest first;
+-- callers reverse the rows to restore newest-first order.
+SELECT * FROM user_sessions
+WHERE user_id = sqlc.arg('user_id')
+  AND (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status'))
+  AND (started_at, id) > (sqlc.arg('started_at')::timestamptz, sqlc.arg('id')::uuid)
+ORDER BY started_at ASC, id ASC
+LIMIT sqlc.arg('limit');
 
 -- name: ListActiveSessions :many
 SELECT * FROM user_sessions
-WHERE status = 'active'
+WHERE user_id = $1 AND status = 'active'
 ORDER BY started_at DESC
-LIMIT $1 OFFSET $2;
+LIMIT $2 OFFSET $3;
 
 -- name: CreateUserSession :one
 INSERT INTO user_sessions (
@@ -23,11 +44,63 @@ INSERT INTO user_sessions (
 RETURNING *;
 
 -- name: UpdateUserSession :one
+-- Ends an active session; ended sessions are final, so the row is only
+-- returned when it was still active.
+UPDATE user_sessions
+SET status = $1, ended_at = NOW()
+WHERE id = $2 AND user_id = $3 AND status = 'active'
+RETURNING *;
+
+-- name: RecordSessionActivity :one
+-- Credits the time since the previous activity to active_seconds unless the
+-- session was paused or the gap exceeds max_gap_seconds, i.e. the learner was idle.
 UPDATE user_sessions
-SET status = $1, ended_at = $2
-WHERE id = $3
+SET active_seconds = active_seconds + CASE
+      WHEN paused OR NOW() - last_active_at > make_interval(secs => sqlc.arg('max_gap_seconds')::int) THEN 0
+      ELSE ROUND(EXTRACT(EPOCH FROM NOW() - last_active_at))::int
+    END,
+    paused = CASE sqlc.arg('kind')::text
+      WHEN 'pause' THEN TRUE
+      WHEN 'resume' THEN FALSE
+      WHEN 'answer' THEN FALSE
+      ELSE paused
+    END,
+    last_active_at = NOW()
+WHERE id = sqlc.arg('id') AND user_id = sqlc.arg('user_id') AND status = 'active'
+RETURNING *;
+
+-- name: CreateCompletedUserSession :one
+-- Records a session that already happened elsewhere, e.g. replayed from an
+-- imported review log; it never passes through the active state.
+INSERT INTO user_sessions (
+  user_id, status, started_at, ended_at, last_active_at, active_seconds
+) VALUES (
+  sqlc.arg('user_id'), 'completed', sqlc.arg('started_at'), sqlc.arg('ended_at'), sqlc.arg('ended_at'), sqlc.arg('active_seconds')
+)
 RETURNING *;
 
--- name: DeleteUserSession :exec
+-- name: CreateUserSessionEvent :one
+INSERT INTO user_session_events (
+  session_id, kind
+) VALUES (
+  $1, $2
+)
+RETURNING *;
+
+-- name: CreateAnswerSessionEvents :execrows
+INSERT INTO user_session_events (session_id, kind, occurred_at)
+SELECT sqlc.arg('session_id')::uuid, 'answer', unnest(sqlc.arg('occurred_at')::timestamptz[]);
+
+-- name: AbandonActiveUserSessions :execrows
+UPDATE user_sessions
+SET status = 'abandoned', ended_at = NOW()
+WHERE user_id = $1 AND status = 'active';
+
+-- name: AbandonIdleSessions :execrows
+UPDATE user_sessions
+SET status = 'abandoned', ended_at = NOW()
+WHERE status = 'active' AND last_active_at < sqlc.arg('idle_before');
+
+-- name: DeleteUserSession :execrows
 DELETE FROM user_sessions
-WHERE id = $1;
+WHERE id = $1 AND user_id = $2;
@@ -3,24 +3,30 @@ SELECT * FROM user_statistics
 WHERE user_id = $1 LIMIT 1;
 
 -- name: ListUserStatistics :many
+-- Statistics of deactivated or soft-deleted users are hidden from listings.
 SELECT * FROM user_statistics
-ORDER BY updated_at DESC
+WHERE user_id IN (SELECT id FROM users WHERE is_active AND deleted_at IS NULL)
+ORDER BY updated_at DESC, user_id DESC
 LIMIT $1 OFFSET $2;
 
--- name: CreateUserStatistics :one
-INSERT INTO user_statistics (
-  user_id, total_words_learned, accuracy, total_time
-) VALUES (
-  $1, $2, $3, $4
-)
-RETURNING *;
+-- name: ListUserStatisticsAfter :many
+-- Keyset page of statistics strictly after the cursor, newest first.
+SELECT * FROM user_statistics
+WHERE user_id IN (SELECT id FROM users WHERE is_active AND deleted_at IS NULL)
+  AND (updated_at, user_id) < (sqlc.arg('updated_at')::timestamptz, sqlc.arg('user_id')::uuid)
+ORDER BY updated_at DESC, user_id DESC
+LIMIT sqlc.arg('limit');
 
--- name: UpdateUserStatistics :one
-UPDATE user_statistics
-SET total_words_learned = $1, accuracy = $2, total_time = $3, updated_at = NOW()
-WHERE user_id = $4
-RETURNING *;
+-- name: ListUserStatisticsBefore :many
+-- Keyset page of statistics strictly before the cursor, oldest first;
+-- callers reverse the rows to restore newest-first order.
+SELECT * FROM user_statistics
+WHERE user_id IN (SELECT id FROM users WHERE is_active AND deleted_at IS NULL)
+  AND (updated_at, user_id) > (sqlc.arg('updated_at')::timestamptz, sqlc.arg('user_id')::uuid)
+ORDER BY updated_at ASC, user_id ASC
+LIMIT sqlc.arg('limit');
 
--- name: DeleteUserStatistics :exec
-DELETE FROM user_statistics
-WHERE user_id = $1;
+-- name: RecomputeUserStatistics :one
+-- Statistics are maintained by triggers on user_progress and user_sessions;
+-- this forces a full recompute for one user.
+SELECT * FROM refresh_user_statistics(sqlc.arg('user_id')::uuid);
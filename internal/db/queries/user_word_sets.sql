@@ -5,8 +5,8 @@ WHERE id = $1 LIMIT 1;
 -- name: ListUserWordSets :many
 SELECT * FROM user_word_sets
 WHERE user_id = $1
-ORDER BY created_at DESC
-LIMIT $2 OFFSET $3; 
+ORDER BY created_at DESC, id DESC
+LIMIT $2 OFFSET $3;
 
 -- name: CreateUserWordSet :one
 INSERT INTO user_word_sets (
@@ -19,9 +19,9 @@ RETURNING *;
 -- name: UpdateUserWordSet :one
 UPDATE user_word_sets
 SET word_set_id = $2
-WHERE id = $1
+WHERE id = $1 AND user_id = $3
 RETURNING *;
 
--- name: DeleteUserWordSet :exec
+-- name: DeleteUserWordSet :execrows
 DELETE FROM user_word_sets
-WHERE id = $1;
+WHERE id = $1 AND user_id = $2;
@@ -1,18 +1,75 @@
 -- name: GetUser :one
+-- Deactivated and soft-deleted users are invisible to reads.
 SELECT * FROM users
-WHERE id = $1 LIMIT 1;
+WHERE id = $1 AND is_active AND deleted_at IS NULL LIMIT 1;
 
 -- name: GetUserByEmail :one
 SELECT * FROM users
-WHERE email = $1 LIMIT 1;
+WHERE email = $1 AND is_active AND deleted_at IS NULL LIMIT 1;
+
+-- name: GetUserIncludingDeleted :one
+-- Deactivated and soft-deleted users too, for reads that must reach them until the purge.
+SELECT * FROM users
+WHERE id = $1 LIMIT 1;
 
 -- name: ListUsers :many
+-- Every filter except deleted is optional: NULL matches all rows. username_prefix is a LIKE
+-- pattern prefix, so callers escape % and _. deleted selects soft-deleted rows
+-- instead of live ones. sort is one of the orderings accepted by the service;
+-- anything else falls back to newest first.
+SELECT * FROM users
+WHERE (sqlc.narg('username_prefix')::text IS NULL OR lower(username) LIKE lower(sqlc.narg('username_prefix')) || '%')
+  AND (sqlc.narg('email_domain')::text IS NULL OR lower(split_part(email, '@', 2)) = lower(sqlc.narg('email_domain')))
+  AND (sqlc.narg('is_active')::bool IS NULL OR is_active = sqlc.narg('is_active'))
+  AND (sqlc.narg('created_from')::timestamptz IS NULL OR created_at >= sqlc.narg('created_from'))
+  AND (sqlc.narg('created_to')::timestamptz IS NULL OR created_at < sqlc.narg('created_to'))
+  AND (deleted_at IS NOT NULL) = sqlc.arg('deleted')::bool
+ORDER BY
+  CASE WHEN sqlc.arg('sort')::text = 'username' THEN username END ASC,
+  CASE WHEN sqlc.arg('sort')::text = '-username' THEN username END DESC,
+  CASE WHEN sqlc.arg('sort')::text = 'email' THEN email END ASC,
+  CASE WHEN sqlc.arg('sort')::text = '-email' THEN email END DESC,
+  CASE WHEN sqlc.arg('sort')::text = 'created_at' THEN created_at END ASC,
+  created_at DESC,
+  id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: ListUsersAfter :many
+-- Keyset page of users strictly after the cursor, newest first.
+SELECT * FROM users
+WHERE (sqlc.narg('username_prefix')::text IS NULL OR lower(username) LIKE lower(sqlc.narg('username_prefix')) || '%')
+  AND (sqlc.narg('email_domain')::text IS NULL OR lower(split_part(email, '@', 2)) = lower(sqlc.narg('email_domain')))
+  AND (sqlc.narg('is_active')::bool IS NULL OR is_active = sqlc.narg('is_active'))
+  AND (sqlc.narg('created_from')::timestamptz IS NULL OR created_at >= sqlc.narg('created_from'))
+  AND (sqlc.narg('created_to')::timestamptz IS NULL OR created_at < sqlc.narg('created_to'))
+  AND (deleted_at IS NOT NULL) = sqlc.arg('deleted')::bool
+  AND (created_at, id) < (sqlc.arg('created_at')::timestamptz, sqlc.arg('id')::uuid)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('limit');
+
+-- name: ListUsersBefore :many
+-- Keyset page of users strictly before the cursor, oldest first;
+-- callers reverse the rows to restore newest-first order.
 SELECT * FROM users
-ORDER BY created_at DESC
-LIMIT $1 OFFSET $2;
+WHERE (sqlc.narg('username_prefix')::text IS NULL OR lower(username) LIKE lower(sqlc.narg('username_prefix')) || '%')
+  AND (sqlc.narg('email_domain')::text IS NULL OR lower(split_part(email, '@', 2)) = lower(sqlc.narg('email_domain')))
+  AND (sqlc.narg('is_active')::bool IS NULL OR is_active = sqlc.narg('is_active'))
+  AND (sqlc.narg('created_from')::timestamptz IS NULL OR created_at >= sqlc.narg('created_from'))
+  AND (sqlc.narg('created_to')::timestamptz IS NULL OR created_at < sqlc.narg('created_to'))
+  AND (deleted_at IS NOT NULL) = sqlc.arg('deleted')::bool
+  AND (created_at, id) > (sqlc.arg('created_at')::timestamptz, sqlc.arg('id')::uuid)
+ORDER BY created_at ASC, id ASC
+LIMIT sqlc.arg('limit');
 
 -- name: CountUsers :one
-SELECT COUNT(*) FROM users;
+-- Takes the same filters as ListUsers.
+SELECT COUNT(*) FROM users
+WHERE (sqlc.narg('username_prefix')::text IS NULL OR lower(username) LIKE lower(sqlc.narg('username_prefix')) || '%')
+  AND (sqlc.narg('email_domain')::text IS NULL OR lower(split_part(email, '@', 2)) = lower(sqlc.narg('email_domain')))
+  AND (sqlc.narg('is_active')::bool IS NULL OR is_active = sqlc.narg('is_active'))
+  AND (sqlc.narg('created_from')::timestamptz IS NULL OR created_at >= sqlc.narg('created_from'))
+  AND (sqlc.narg('created_to')::timestamptz IS NULL OR created_at < sqlc.narg('created_to'))
+  AND (deleted_at IS NOT NULL) = sqlc.arg('deleted')::bool;
 
 -- name: CreateUser :one
 INSERT INTO users (
@@ -25,9 +82,28 @@ RETURNING *;
 -- name: UpdateUser :one
 UPDATE users
 SET username = $1, email = $2
-WHERE id = $3
+WHERE id = $3 AND is_active AND deleted_at IS NULL
+RETURNING *;
+
+-- name: SetUserActive :one
+UPDATE users
+SET is_active = sqlc.arg('is_active')
+WHERE id = sqlc.arg('id') AND deleted_at IS NULL
+RETURNING *;
+
+-- name: DeleteUser :execrows
+-- Soft delete: the row is kept until PurgeDeletedUsers removes it after the restore period.
+UPDATE users
+SET deleted_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: RestoreUser :one
+UPDATE users
+SET deleted_at = NULL
+WHERE id = sqlc.arg('id') AND deleted_at >= sqlc.arg('deleted_after')
 RETURNING *;
 
--- name: DeleteUser :exec
+-- name: PurgeDeletedUsers :execrows
+-- Hard delete cascades to sessions, progress, statistics and credentials.
 DELETE FROM users
-WHERE id = $1;
\ No newline at end of file
+WHERE deleted_at < sqlc.arg('deleted_before');
\ No newline at end of file
@@ -0,0 +1,76 @@
+-- name: GetWordSet :one
+SELECT * FROM word_sets
+WHERE id = $1 LIMIT 1;
+
+-- name: ListWordSets :many
+SELECT * FROM word_sets
+WHERE (visibility = 'public' OR owner_id = sqlc.narg('owner_id'))
+  AND (sqlc.narg('language')::text IS NULL OR language = sqlc.narg('language'))
+ORDER BY created_at DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CreateWordSet :one
+INSERT INTO word_sets (
+  title, description, language, owner_id, visibility
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: UpdateWordSet :one
+UPDATE word_sets
+SET title = $1, description = $2, language = $3, visibility = $4, updated_at = NOW()
+WHERE id = $5
+RETURNING *;
+
+-- name: DeleteWordSet :exec
+DELETE FROM word_sets
+WHERE id = $1;
+
+-- name: LockWordSet :one
+-- Appends compute the next position from MAX(position), so they lock the set first
+-- and run in a later statement of the same transaction.
+SELECT id FROM word_sets
+WHERE id = $1
+FOR UPDATE;
+
+-- name: AddWordSetItem :one
+INSERT INTO word_set_items (word_set_id, word_id, position)
+SELECT sqlc.arg('word_set_id')::uuid, sqlc.arg('word_id')::uuid, COALESCE(MAX(position), 0) + 1
+FROM word_set_items
+WHERE word_set_id = sqlc.arg('word_set_id')
+RETURNING *;
+
+-- name: AddWordSetItemIfMissing :execrows
+-- Appends the word unless the set already holds it.
+INSERT INTO word_set_items (word_set_id, word_id, position)
+SELECT sqlc.arg('word_set_id')::uuid, sqlc.arg('word_id')::uuid, COALESCE(MAX(position), 0) + 1
+FROM word_set_items
+WHERE word_set_id = sqlc.arg('word_set_id')
+ON CONFLICT (word_set_id, word_id) DO NOTHING;
+
+-- name: RemoveWordSetItem :execrows
+DELETE FROM word_set_items
+WHERE word_set_id = $1 AND word_id = $2;
+
+-- name: ReorderWordSetItems :execrows
+UPDATE word_set_items AS i
+SET position = o.ord
+FROM unnest(sqlc.arg('word_ids')::uuid[]) WITH ORDINALITY AS o(word_id, ord)
+WHERE i.word_set_id = sqlc.arg('word_set_id') AND i.word_id = o.word_id
+  AND NOT EXISTS (
+    SELECT 1 FROM word_set_items AS s
+    WHERE s.word_set_id = sqlc.arg('word_set_id') AND s.word_id <> ALL(sqlc.arg('word_ids')::uuid[])
+  );
+
+-- name: CountWordSetItems :one
+SELECT COUNT(*) FROM word_set_items
+WHERE word_set_id = $1;
+
+-- name: ListWordSetItems :many
+SELECT sqlc.embed(words), word_set_items.position, word_set_items.added_at
+FROM word_set_items
+JOIN words ON words.id = word_set_items.word_id
+WHERE word_set_items.word_set_id = $1
+ORDER BY word_set_items.position
+LIMIT $2 OFFSET $3;
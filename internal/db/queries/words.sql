@@ -0,0 +1,59 @@
+-- name: GetWord :one
+SELECT * FROM words
+WHERE id = $1 LIMIT 1;
+
+-- name: ListWordsByIDs :many
+SELECT * FROM words
+WHERE id = ANY(sqlc.arg('ids')::uuid[])
+ORDER BY lemma;
+
+-- name: SearchWords :many
+-- query is a LIKE pattern fragment: callers escape its wildcards so it matches literally.
+SELECT * FROM words
+WHERE (sqlc.narg('query')::text IS NULL OR lemma ILIKE sqlc.narg('query') || '%' OR translation ILIKE '%' || sqlc.narg('query') || '%')
+  AND (sqlc.narg('source_lang')::text IS NULL OR source_lang = sqlc.narg('source_lang'))
+  AND (sqlc.narg('target_lang')::text IS NULL OR target_lang = sqlc.narg('target_lang'))
+  AND (sqlc.narg('part_of_speech')::text IS NULL OR part_of_speech = sqlc.narg('part_of_speech'))
+  AND (sqlc.narg('difficulty')::smallint IS NULL OR difficulty = sqlc.narg('difficulty'))
+ORDER BY lemma, id
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CreateWord :one
+INSERT INTO words (
+  lemma, translation, part_of_speech, source_lang, target_lang, examples, difficulty
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING *;
+
+-- name: UpdateWord :one
+UPDATE words
+SET lemma = $1, translation = $2, part_of_speech = $3, source_lang = $4, target_lang = $5,
+    examples = $6, difficulty = $7, updated_at = NOW()
+WHERE id = $8
+RETURNING *;
+
+-- name: DeleteWord :exec
+DELETE FROM words
+WHERE id = $1;
+
+-- name: CreateWordIfMissing :one
+-- Words are unique per lemma and language pair. An existing word is left untouched
+-- and no row comes back.
+INSERT INTO words (
+  lemma, translation, part_of_speech, source_lang, target_lang, examples, difficulty
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+)
+ON CONFLICT ON CONSTRAINT unique_word_lemma_lang_pair DO NOTHING
+RETURNING *;
+
+-- name: GetWordByLemma :one
+SELECT * FROM words
+WHERE lemma = $1 AND source_lang = $2 AND target_lang = $3 LIMIT 1;
+
+-- name: ListWordsByLemmas :many
+-- Lemmas compare case-insensitively, so callers match rows back on lower case.
+SELECT * FROM words
+WHERE lemma = ANY(sqlc.arg('lemmas')::citext[])
+  AND source_lang = sqlc.arg('source_lang') AND target_lang = sqlc.arg('target_lang');
@@ -0,0 +1,92 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	// SQLSTATEs after which the whole transaction can simply be replayed.
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+
+	txRetryBaseDelay = 10 * time.Millisecond
+)
+
+// TxBeginner is satisfied by *pgxpool.Pool and *pgx.Conn.
+type TxBeginner interface {
+	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
+}
+
+// Runner is the pgx-backed TxRunner.
+type Runner struct {
+	db          TxBeginner
+	opts        pgx.TxOptions
+	maxAttempts int
+}
+
+// NewTxRunner returns a runner that opens transactions with opts and replays a
+// transaction up to maxAttempts times when Postgres aborts it with a
+// serialization failure or a deadlock. Serialization failures only happen at
+// REPEATABLE READ or SERIALIZABLE; at the default READ COMMITTED only deadlocks
+// are replayed.
+func NewTxRunner(db TxBeginner, opts pgx.TxOptions, maxAttempts int) *Runner {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	return &Runner{
+		db:          db,
+		opts:        opts,
+		maxAttempts: maxAttempts,
+	}
+}
+
+// InTx runs fn inside a transaction and commits when fn returns nil. fn may be
+// called more than once, so it must not have side effects outside the database.
+func (r *Runner) InTx(ctx context.Context, fn func(tx Tx) error) error {
+	for attempt := 1; ; attempt++ {
+		err := pgx.BeginTxFunc(ctx, r.db, r.opts, func(tx pgx.Tx) error {
+			return fn(queriesTx{New(tx)})
+		})
+		if err == nil || attempt >= r.maxAttempts || !IsRetryable(err) {
+			return err
+		}
+
+		delay := txRetryBaseDelay << (attempt - 1)
+		delay += rand.N(delay)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+}
+
+// IsRetryable reports whether err aborted a transaction that can be replayed as is.
+func IsRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == pgSerializationFailure || pgErr.Code == pgDeadlockDetected
+}
+
+type queriesTx struct {
+	q *Queries
+}
+
+func (t queriesTx) Users() UserRepo                { return t.q }
+func (t queriesTx) Auth() AuthRepo                 { return t.q }
+func (t queriesTx) Sessions() UserSessionRepo      { return t.q }
+func (t queriesTx) Progress() UserProgressRepo     { return t.q }
+func (t queriesTx) Statistics() UserStatisticsRepo { return t.q }
+func (t queriesTx) UserWordSets() UserWordSetRepo  { return t.q }
+func (t queriesTx) Words() WordRepo                { return t.q }
+func (t queriesTx) WordSets() WordSetRepo          { return t.q }
+func (t queriesTx) Games() GameRepo                { return t.q }
+func (t queriesTx) Imports() ImportRepo            { return t.q }
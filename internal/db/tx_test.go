@@ -0,0 +1,87 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// stubTx implements just enough of pgx.Tx for pgx.BeginTxFunc.
+type stubTx struct {
+	pgx.Tx
+	b *stubBeginner
+}
+
+func (t *stubTx) Commit(context.Context) error {
+	t.b.commits++
+	return nil
+}
+
+func (t *stubTx) Rollback(context.Context) error { return nil }
+
+type stubBeginner struct {
+	begins  int
+	commits int
+}
+
+func (b *stubBeginner) BeginTx(context.Context, pgx.TxOptions) (pgx.Tx, error) {
+	b.begins++
+	return &stubTx{b: b}, nil
+}
+
+func TestRunner_InTx(t *testing.T) {
+	serialization := &pgconn.PgError{Code: pgSerializationFailure}
+	unique := &pgconn.PgError{Code: "23505"}
+
+	tests := []struct {
+		name        string
+		failures    []error
+		maxAttempts int
+		wantErr     error
+		wantBegins  int
+		wantCommits int
+	}{
+		{name: "commits first try", maxAttempts: 3, wantBegins: 1, wantCommits: 1},
+		{name: "replays serialization failure", failures: []error{serialization, serialization}, maxAttempts: 3, wantBegins: 3, wantCommits: 1},
+		{name: "gives up after max attempts", failures: []error{serialization, serialization}, maxAttempts: 2, wantErr: serialization, wantBegins: 2},
+		{name: "does not replay other errors", failures: []error{unique}, maxAttempts: 3, wantErr: unique, wantBegins: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &stubBeginner{}
+			runner := NewTxRunner(b, pgx.TxOptions{}, tt.maxAttempts)
+
+			calls := 0
+			err := runner.InTx(context.Background(), func(tx Tx) error {
+				if tx.Progress() == nil {
+					t.Fatal("expected transaction-bound repositories")
+				}
+				calls++
+				if calls <= len(tt.failures) {
+					return tt.failures[calls-1]
+				}
+				return nil
+			})
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if b.begins != tt.wantBegins || b.commits != tt.wantCommits {
+				t.Fatalf("got %d begins/%d commits, want %d/%d", b.begins, b.commits, tt.wantBegins, tt.wantCommits)
+			}
+		})
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	if !IsRetryable(&pgconn.PgError{Code: pgDeadlockDetected}) {
+		t.Fatal("deadlock should be retryable")
+	}
+	if IsRetryable(errors.New("boom")) || IsRetryable(pgx.ErrNoRows) {
+		t.Fatal("non-Postgres errors must not be retried")
+	}
+}
@@ -17,7 +17,7 @@ INSERT INTO user_progress (
 ) VALUES (
   $1, $2, $3, $4
 )
-RETURNING id, user_id, word_id, correct_count, incorrect_count, last_attempt
+RETURNING id, user_id, word_id, correct_count, incorrect_count, last_attempt, ease_factor, interval_days, repetitions, due_at
 `
 
 type CreateUserProgressParams struct {
@@ -42,22 +42,34 @@ func (q *Queries) CreateUserProgress(ctx context.Context, arg CreateUserProgress
 		&i.CorrectCount,
 		&i.IncorrectCount,
 		&i.LastAttempt,
+		&i.EaseFactor,
+		&i.IntervalDays,
+		&i.Repetitions,
+		&i.DueAt,
 	)
 	return i, err
 }
 
-const deleteUserProgress = `-- name: DeleteUserProgress :exec
+const deleteUserProgress = `-- name: DeleteUserProgress :execrows
 DELETE FROM user_progress
-WHERE id = $1
+WHERE id = $1 AND user_id = $2
 `
 
-func (q *Queries) DeleteUserProgress(ctx context.Context, id pgtype.UUID) error {
-	_, err := q.db.Exec(ctx, deleteUserProgress, id)
-	return err
+type DeleteUserProgressParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) DeleteUserProgress(ctx context.Context, arg DeleteUserProgressParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteUserProgress, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const getUserProgress = `-- name: GetUserProgress :one
-SELECT id, user_id, word_id, correct_count, incorrect_count, last_attempt FROM user_progress
+SELECT id, user_id, word_id, correct_count, incorrect_count, last_attempt, ease_factor, interval_days, repetitions, due_at FROM user_progress
 WHERE id = $1 LIMIT 1
 `
 
@@ -71,12 +83,16 @@ func (q *Queries) GetUserProgress(ctx context.Context, id pgtype.UUID) (UserProg
 		&i.CorrectCount,
 		&i.IncorrectCount,
 		&i.LastAttempt,
+		&i.EaseFactor,
+		&i.IntervalDays,
+		&i.Repetitions,
+		&i.DueAt,
 	)
 	return i, err
 }
 
 const getUserProgressByUserAndWord = `-- name: GetUserProgressByUserAndWord :one
-SELECT id, user_id, word_id, correct_count, incorrect_count, last_attempt FROM user_progress
+SELECT id, user_id, word_id, correct_count, incorrect_count, last_attempt, ease_factor, interval_days, repetitions, due_at FROM user_progress
 WHERE user_id = $1 AND word_id = $2 LIMIT 1
 `
 
@@ -95,14 +111,79 @@ func (q *Queries) GetUserProgressByUserAndWord(ctx context.Context, arg GetUserP
 		&i.CorrectCount,
 		&i.IncorrectCount,
 		&i.LastAttempt,
+		&i.EaseFactor,
+		&i.IntervalDays,
+		&i.Repetitions,
+		&i.DueAt,
 	)
 	return i, err
 }
 
+const listDueReviews = `-- name: ListDueReviews :many
+SELECT user_progress.id, user_progress.user_id, user_progress.word_id, user_progress.correct_count, user_progress.incorrect_count, user_progress.last_attempt, user_progress.ease_factor, user_progress.interval_days, user_progress.repetitions, user_progress.due_at, words.id, words.lemma, words.translation, words.part_of_speech, words.source_lang, words.target_lang, words.examples, words.difficulty, words.created_at, words.updated_at
+FROM user_progress
+JOIN words ON words.id = user_progress.word_id
+WHERE user_progress.user_id = $1 AND user_progress.due_at <= $2
+ORDER BY user_progress.due_at ASC, user_progress.ease_factor ASC
+LIMIT $3
+`
+
+type ListDueReviewsParams struct {
+	UserID    pgtype.UUID        `json:"user_id"`
+	DueBefore pgtype.Timestamptz `json:"due_before"`
+	Limit     int32              `json:"limit"`
+}
+
+type ListDueReviewsRow struct {
+	UserProgress UserProgress `json:"user_progress"`
+	Word         Word         `json:"word"`
+}
+
+func (q *Queries) ListDueReviews(ctx context.Context, arg ListDueReviewsParams) ([]ListDueReviewsRow, error) {
+	rows, err := q.db.Query(ctx, listDueReviews, arg.UserID, arg.DueBefore, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListDueReviewsRow{}
+	for rows.Next() {
+		var i ListDueReviewsRow
+		if err := rows.Scan(
+			&i.UserProgress.ID,
+			&i.UserProgress.UserID,
+			&i.UserProgress.WordID,
+			&i.UserProgress.CorrectCount,
+			&i.UserProgress.IncorrectCount,
+			&i.UserProgress.LastAttempt,
+			&i.UserProgress.EaseFactor,
+			&i.UserProgress.IntervalDays,
+			&i.UserProgress.Repetitions,
+			&i.UserProgress.DueAt,
+			&i.Word.ID,
+			&i.Word.Lemma,
+			&i.Word.Translation,
+			&i.Word.PartOfSpeech,
+			&i.Word.SourceLang,
+			&i.Word.TargetLang,
+			&i.Word.Examples,
+			&i.Word.Difficulty,
+			&i.Word.CreatedAt,
+			&i.Word.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listUserProgress = `-- name: ListUserProgress :many
-SELECT id, user_id, word_id, correct_count, incorrect_count, last_attempt FROM user_progress
+SELECT id, user_id, word_id, correct_count, incorrect_count, last_attempt, ease_factor, interval_days, repetitions, due_at FROM user_progress
 WHERE user_id = $1
-ORDER BY last_attempt DESC
+ORDER BY last_attempt DESC, id DESC
 LIMIT $2 OFFSET $3
 `
 
@@ -128,6 +209,156 @@ func (q *Queries) ListUserProgress(ctx context.Context, arg ListUserProgressPara
 			&i.CorrectCount,
 			&i.IncorrectCount,
 			&i.LastAttempt,
+			&i.EaseFactor,
+			&i.IntervalDays,
+			&i.Repetitions,
+			&i.DueAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserProgressAfter = `-- name: ListUserProgressAfter :many
+SELECT id, user_id, word_id, correct_count, incorrect_count, last_attempt, ease_factor, interval_days, repetitions, due_at FROM user_progress
+WHERE user_id = $1
+  AND (last_attempt, id) < ($2::timestamptz, $3::uuid)
+ORDER BY last_attempt DESC, id DESC
+LIMIT $4
+`
+
+type ListUserProgressAfterParams struct {
+	UserID      pgtype.UUID        `json:"user_id"`
+	LastAttempt pgtype.Timestamptz `json:"last_attempt"`
+	ID          pgtype.UUID        `json:"id"`
+	Limit       int32              `json:"limit"`
+}
+
+// Keyset page of progress rows strictly after the cursor, newest first.
+func (q *Queries) ListUserProgressAfter(ctx context.Context, arg ListUserProgressAfterParams) ([]UserProgress, error) {
+	rows, err := q.db.Query(ctx, listUserProgressAfter,
+		arg.UserID,
+		arg.LastAttempt,
+		arg.ID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []UserProgress{}
+	for rows.Next() {
+		var i UserProgress
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.WordID,
+			&i.CorrectCount,
+			&i.IncorrectCount,
+			&i.LastAttempt,
+			&i.EaseFactor,
+			&i.IntervalDays,
+			&i.Repetitions,
+			&i.DueAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserProgressBefore = `-- name: ListUserProgressBefore :many
+SELECT id, user_id, word_id, correct_count, incorrect_count, last_attempt, ease_factor, interval_days, repetitions, due_at FROM user_progress
+WHERE user_id = $1
+  AND (last_attempt, id) > ($2::timestamptz, $3::uuid)
+ORDER BY last_attempt ASC, id ASC
+LIMIT $4
+`
+
+type ListUserProgressBeforeParams struct {
+	UserID      pgtype.UUID        `json:"user_id"`
+	LastAttempt pgtype.Timestamptz `json:"last_attempt"`
+	ID          pgtype.UUID        `json:"id"`
+	Limit       int32              `json:"limit"`
+}
+
+// Keyset page of progress rows strictly before the cursor, oldest first;
+// callers reverse the rows to restore newest-first order.
+func (q *Queries) ListUserProgressBefore(ctx context.Context, arg ListUserProgressBeforeParams) ([]UserProgress, error) {
+	rows, err := q.db.Query(ctx, listUserProgressBefore,
+		arg.UserID,
+		arg.LastAttempt,
+		arg.ID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []UserProgress{}
+	for rows.Next() {
+		var i UserProgress
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.WordID,
+			&i.CorrectCount,
+			&i.IncorrectCount,
+			&i.LastAttempt,
+			&i.EaseFactor,
+			&i.IntervalDays,
+			&i.Repetitions,
+			&i.DueAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserProgressByWords = `-- name: ListUserProgressByWords :many
+SELECT id, user_id, word_id, correct_count, incorrect_count, last_attempt, ease_factor, interval_days, repetitions, due_at FROM user_progress
+WHERE user_id = $1 AND word_id = ANY($2::uuid[])
+`
+
+type ListUserProgressByWordsParams struct {
+	UserID  pgtype.UUID   `json:"user_id"`
+	WordIds []pgtype.UUID `json:"word_ids"`
+}
+
+func (q *Queries) ListUserProgressByWords(ctx context.Context, arg ListUserProgressByWordsParams) ([]UserProgress, error) {
+	rows, err := q.db.Query(ctx, listUserProgressByWords, arg.UserID, arg.WordIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []UserProgress{}
+	for rows.Next() {
+		var i UserProgress
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.WordID,
+			&i.CorrectCount,
+			&i.IncorrectCount,
+			&i.LastAttempt,
+			&i.EaseFactor,
+			&i.IntervalDays,
+			&i.Repetitions,
+			&i.DueAt,
 		); err != nil {
 			return nil, err
 		}
@@ -139,21 +370,94 @@ func (q *Queries) ListUserProgress(ctx context.Context, arg ListUserProgressPara
 	return items, nil
 }
 
+const recordUserProgressAttempt = `-- name: RecordUserProgressAttempt :one
+INSERT INTO user_progress (
+  user_id, word_id, correct_count, incorrect_count, last_attempt,
+  ease_factor, interval_days, repetitions, due_at
+) VALUES (
+  $1, $2,
+  CASE WHEN $3::bool THEN 1 ELSE 0 END,
+  CASE WHEN $3::bool THEN 0 ELSE 1 END,
+  COALESCE($4::timestamptz, NOW()),
+  GREATEST(1.3, 2.5 + 0.1 - (5 - $5::smallint) * (0.08 + (5 - $5::smallint) * 0.02)),
+  1,
+  CASE WHEN $5::smallint >= 3 THEN 1 ELSE 0 END,
+  COALESCE($4::timestamptz, NOW()) + INTERVAL '1 day'
+)
+ON CONFLICT (user_id, word_id) DO UPDATE
+SET correct_count = user_progress.correct_count + EXCLUDED.correct_count,
+    incorrect_count = user_progress.incorrect_count + EXCLUDED.incorrect_count,
+    last_attempt = GREATEST(user_progress.last_attempt, EXCLUDED.last_attempt),
+    ease_factor = GREATEST(1.3, user_progress.ease_factor + 0.1 - (5 - $5::smallint) * (0.08 + (5 - $5::smallint) * 0.02)),
+    interval_days = CASE
+      WHEN $5::smallint < 3 THEN 1
+      WHEN user_progress.repetitions = 0 THEN 1
+      WHEN user_progress.repetitions = 1 THEN 6
+      ELSE GREATEST(1, ROUND(user_progress.interval_days * user_progress.ease_factor))::int
+    END,
+    repetitions = CASE WHEN $5::smallint >= 3 THEN user_progress.repetitions + 1 ELSE 0 END,
+    due_at = EXCLUDED.last_attempt + make_interval(days => CASE
+      WHEN $5::smallint < 3 THEN 1
+      WHEN user_progress.repetitions = 0 THEN 1
+      WHEN user_progress.repetitions = 1 THEN 6
+      ELSE GREATEST(1, ROUND(user_progress.interval_days * user_progress.ease_factor))::int
+    END)
+RETURNING id, user_id, word_id, correct_count, incorrect_count, last_attempt, ease_factor, interval_days, repetitions, due_at
+`
+
+type RecordUserProgressAttemptParams struct {
+	UserID      pgtype.UUID        `json:"user_id"`
+	WordID      pgtype.UUID        `json:"word_id"`
+	Correct     bool               `json:"correct"`
+	AttemptedAt pgtype.Timestamptz `json:"attempted_at"`
+	Quality     int16              `json:"quality"`
+}
+
+func (q *Queries) RecordUserProgressAttempt(ctx context.Context, arg RecordUserProgressAttemptParams) (UserProgress, error) {
+	row := q.db.QueryRow(ctx, recordUserProgressAttempt,
+		arg.UserID,
+		arg.WordID,
+		arg.Correct,
+		arg.AttemptedAt,
+		arg.Quality,
+	)
+	var i UserProgress
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.WordID,
+		&i.CorrectCount,
+		&i.IncorrectCount,
+		&i.LastAttempt,
+		&i.EaseFactor,
+		&i.IntervalDays,
+		&i.Repetitions,
+		&i.DueAt,
+	)
+	return i, err
+}
+
 const updateUserProgress = `-- name: UpdateUserProgress :one
 UPDATE user_progress
 SET correct_count = $1, incorrect_count = $2, last_attempt = NOW()
-WHERE id = $3
-RETURNING id, user_id, word_id, correct_count, incorrect_count, last_attempt
+WHERE id = $3 AND user_id = $4
+RETURNING id, user_id, word_id, correct_count, incorrect_count, last_attempt, ease_factor, interval_days, repetitions, due_at
 `
 
 type UpdateUserProgressParams struct {
 	CorrectCount   int32       `json:"correct_count"`
 	IncorrectCount int32       `json:"incorrect_count"`
 	ID             pgtype.UUID `json:"id"`
+	UserID         pgtype.UUID `json:"user_id"`
 }
 
 func (q *Queries) UpdateUserProgress(ctx context.Context, arg UpdateUserProgressParams) (UserProgress, error) {
-	row := q.db.QueryRow(ctx, updateUserProgress, arg.CorrectCount, arg.IncorrectCount, arg.ID)
+	row := q.db.QueryRow(ctx, updateUserProgress,
+		arg.CorrectCount,
+		arg.IncorrectCount,
+		arg.ID,
+		arg.UserID,
+	)
 	var i UserProgress
 	err := row.Scan(
 		&i.ID,
@@ -162,6 +466,10 @@ func (q *Queries) UpdateUserProgress(ctx context.Context, arg UpdateUserProgress
 		&i.CorrectCount,
 		&i.IncorrectCount,
 		&i.LastAttempt,
+		&i.EaseFactor,
+		&i.IntervalDays,
+		&i.Repetitions,
+		&i.DueAt,
 	)
 	return i, err
 }
@@ -11,13 +11,98 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const abandonActiveUserSessions = `-- name: AbandonActiveUserSessions :execrows
+UPDATE user_sessions
+SET status = 'abandoned', ended_at = NOW()
+WHERE user_id = $1 AND status = 'active'
+`
+
+func (q *Queries) AbandonActiveUserSessions(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, abandonActiveUserSessions, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const abandonIdleSessions = `-- name: AbandonIdleSessions :execrows
+UPDATE user_sessions
+SET status = 'abandoned', ended_at = NOW()
+WHERE status = 'active' AND last_active_at < $1
+`
+
+func (q *Queries) AbandonIdleSessions(ctx context.Context, idleBefore pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, abandonIdleSessions, idleBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const createAnswerSessionEvents = `-- name: CreateAnswerSessionEvents :execrows
+INSERT INTO user_session_events (session_id, kind, occurred_at)
+SELECT $1::uuid, 'answer', unnest($2::timestamptz[])
+`
+
+type CreateAnswerSessionEventsParams struct {
+	SessionID  pgtype.UUID          `json:"session_id"`
+	OccurredAt []pgtype.Timestamptz `json:"occurred_at"`
+}
+
+func (q *Queries) CreateAnswerSessionEvents(ctx context.Context, arg CreateAnswerSessionEventsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, createAnswerSessionEvents, arg.SessionID, arg.OccurredAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const createCompletedUserSession = `-- name: CreateCompletedUserSession :one
+INSERT INTO user_sessions (
+  user_id, status, started_at, ended_at, last_active_at, active_seconds
+) VALUES (
+  $1, 'completed', $2, $3, $3, $4
+)
+RETURNING id, user_id, started_at, ended_at, status, last_active_at, active_seconds, paused
+`
+
+type CreateCompletedUserSessionParams struct {
+	UserID        pgtype.UUID        `json:"user_id"`
+	StartedAt     pgtype.Timestamptz `json:"started_at"`
+	EndedAt       pgtype.Timestamptz `json:"ended_at"`
+	ActiveSeconds int32              `json:"active_seconds"`
+}
+
+// Records a session that already happened elsewhere, e.g. replayed from an
+// imported review log; it never passes through the active state.
+func (q *Queries) CreateCompletedUserSession(ctx context.Context, arg CreateCompletedUserSessionParams) (UserSession, error) {
+	row := q.db.QueryRow(ctx, createCompletedUserSession,
+		arg.UserID,
+		arg.StartedAt,
+		arg.EndedAt,
+		arg.ActiveSeconds,
+	)
+	var i UserSession
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.StartedAt,
+		&i.EndedAt,
+		&i.Status,
+		&i.LastActiveAt,
+		&i.ActiveSeconds,
+		&i.Paused,
+	)
+	return i, err
+}
+
 const createUserSession = `-- name: CreateUserSession :one
 INSERT INTO user_sessions (
   user_id, status
 ) VALUES (
   $1, $2
 )
-RETURNING id, user_id, started_at, ended_at, status
+RETURNING id, user_id, started_at, ended_at, status, last_active_at, active_seconds, paused
 `
 
 type CreateUserSessionParams struct {
@@ -34,22 +119,59 @@ func (q *Queries) CreateUserSession(ctx context.Context, arg CreateUserSessionPa
 		&i.StartedAt,
 		&i.EndedAt,
 		&i.Status,
+		&i.LastActiveAt,
+		&i.ActiveSeconds,
+		&i.Paused,
+	)
+	return i, err
+}
+
+const createUserSessionEvent = `-- name: CreateUserSessionEvent :one
+INSERT INTO user_session_events (
+  session_id, kind
+) VALUES (
+  $1, $2
+)
+RETURNING id, session_id, kind, occurred_at
+`
+
+type CreateUserSessionEventParams struct {
+	SessionID pgtype.UUID `json:"session_id"`
+	Kind      string      `json:"kind"`
+}
+
+func (q *Queries) CreateUserSessionEvent(ctx context.Context, arg CreateUserSessionEventParams) (UserSessionEvent, error) {
+	row := q.db.QueryRow(ctx, createUserSessionEvent, arg.SessionID, arg.Kind)
+	var i UserSessionEvent
+	err := row.Scan(
+		&i.ID,
+		&i.SessionID,
+		&i.Kind,
+		&i.OccurredAt,
 	)
 	return i, err
 }
 
-const deleteUserSession = `-- name: DeleteUserSession :exec
+const deleteUserSession = `-- name: DeleteUserSession :execrows
 DELETE FROM user_sessions
-WHERE id = $1
+WHERE id = $1 AND user_id = $2
 `
 
-func (q *Queries) DeleteUserSession(ctx context.Context, id pgtype.UUID) error {
-	_, err := q.db.Exec(ctx, deleteUserSession, id)
-	return err
+type DeleteUserSessionParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) DeleteUserSession(ctx context.Context, arg DeleteUserSessionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteUserSession, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const getUserSession = `-- name: GetUserSession :one
-SELECT id, user_id, started_at, ended_at, status FROM user_sessions
+SELECT id, user_id, started_at, ended_at, status, last_active_at, active_seconds, paused FROM user_sessions
 WHERE id = $1 LIMIT 1
 `
 
@@ -62,24 +184,28 @@ func (q *Queries) GetUserSession(ctx context.Context, id pgtype.UUID) (UserSessi
 		&i.StartedAt,
 		&i.EndedAt,
 		&i.Status,
+		&i.LastActiveAt,
+		&i.ActiveSeconds,
+		&i.Paused,
 	)
 	return i, err
 }
 
 const listActiveSessions = `-- name: ListActiveSessions :many
-SELECT id, user_id, started_at, ended_at, status FROM user_sessions
-WHERE status = 'active'
-ORDER BY started_at DESC
-LIMIT $1 OFFSET $2
+SELECT id, user_id, started_at, ended_at, status, last_active_at, active_seconds, paused FROM user_sessions
+WHERE user_id = $1 AND status = 'active'
+ORDER BY started_at DESC, id DESC
+LIMIT $2 OFFSET $3
 `
 
 type ListActiveSessionsParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	UserID pgtype.UUID `json:"user_id"`
+	Limit  int32       `json:"limit"`
+	Offset int32       `json:"offset"`
 }
 
 func (q *Queries) ListActiveSessions(ctx context.Context, arg ListActiveSessionsParams) ([]UserSession, error) {
-	rows, err := q.db.Query(ctx, listActiveSessions, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listActiveSessions, arg.UserID, arg.Limit, arg.Offset)
 	if err != nil {
 		return nil, err
 	}
@@ -93,6 +219,9 @@ func (q *Queries) ListActiveSessions(ctx context.Context, arg ListActiveSessions
 			&i.StartedAt,
 			&i.EndedAt,
 			&i.Status,
+			&i.LastActiveAt,
+			&i.ActiveSeconds,
+			&i.Paused,
 		); err != nil {
 			return nil, err
 		}
@@ -105,20 +234,28 @@ func (q *Queries) ListActiveSessions(ctx context.Context, arg ListActiveSessions
 }
 
 const listUserSessions = `-- name: ListUserSessions :many
-SELECT id, user_id, started_at, ended_at, status FROM user_sessions  
+SELECT id, user_id, started_at, ended_at, status, last_active_at, active_seconds, paused FROM user_sessions
 WHERE user_id = $1
+  AND ($2::text IS NULL OR status = $2)
 ORDER BY started_at DESC
-LIMIT $2 OFFSET $3
+LIMIT $3 OFFSET $4
 `
 
 type ListUserSessionsParams struct {
 	UserID pgtype.UUID `json:"user_id"`
+	Status pgtype.Text `json:"status"`
 	Limit  int32       `json:"limit"`
 	Offset int32       `json:"offset"`
 }
 
+// A NULL status lists sessions in every state.
 func (q *Queries) ListUserSessions(ctx context.Context, arg ListUserSessionsParams) ([]UserSession, error) {
-	rows, err := q.db.Query(ctx, listUserSessions, arg.UserID, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listUserSessions,
+		arg.UserID,
+		arg.Status,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -132,6 +269,9 @@ func (q *Queries) ListUserSessions(ctx context.Context, arg ListUserSessionsPara
 			&i.StartedAt,
 			&i.EndedAt,
 			&i.Status,
+			&i.LastActiveAt,
+			&i.ActiveSeconds,
+			&i.Paused,
 		); err != nil {
 			return nil, err
 		}
@@ -143,21 +283,177 @@ func (q *Queries) ListUserSessions(ctx context.Context, arg ListUserSessionsPara
 	return items, nil
 }
 
+const listUserSessionsAfter = `-- name: ListUserSessionsAfter :many
+SELECT id, user_id, started_at, ended_at, status, last_active_at, active_seconds, paused FROM user_sessions
+WHERE user_id = $1
+  AND ($2::text IS NULL OR status = $2)
+  AND (started_at, id) < ($3::timestamptz, $4::uuid)
+ORDER BY started_at DESC, id DESC
+LIMIT $5
+`
+
+type ListUserSessionsAfterParams struct {
+	UserID    pgtype.UUID        `json:"user_id"`
+	Status    pgtype.Text        `json:"status"`
+	StartedAt pgtype.Timestamptz `json:"started_at"`
+	ID        pgtype.UUID        `json:"id"`
+	Limit     int32              `json:"limit"`
+}
+
+// Keyset page of sessions strictly after the cursor, newest first.
+func (q *Queries) ListUserSessionsAfter(ctx context.Context, arg ListUserSessionsAfterParams) ([]UserSession, error) {
+	rows, err := q.db.Query(ctx, listUserSessionsAfter,
+		arg.UserID,
+		arg.Status,
+		arg.StartedAt,
+		arg.ID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []UserSession{}
+	for rows.Next() {
+		var i UserSession
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.StartedAt,
+			&i.EndedAt,
+			&i.Status,
+			&i.LastActiveAt,
+			&i.ActiveSeconds,
+			&i.Paused,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserSessionsBefore = `-- name: ListUserSessionsBefore :many
+SELECT id, user_id, started_at, ended_at, status, last_active_at, active_seconds, paused FROM user_sessions
+WHERE user_id = $1
+  AND ($2::text IS NULL OR status = $2)
+  AND (started_at, id) > ($3::timestamptz, $4::uuid)
+ORDER BY started_at ASC, id ASC
+LIMIT $5
+`
+
+type ListUserSessionsBeforeParams struct {
+	UserID    pgtype.UUID        `json:"user_id"`
+	Status    pgtype.Text        `json:"status"`
+	StartedAt pgtype.Timestamptz `json:"started_at"`
+	ID        pgtype.UUID        `json:"id"`
+	Limit     int32              `json:"limit"`
+}
+
+// Keyset page of sessions strictly before the cursor, oldest first;
+// callers reverse the rows to restore newest-first order.
+func (q *Queries) ListUserSessionsBefore(ctx context.Context, arg ListUserSessionsBeforeParams) ([]UserSession, error) {
+	rows, err := q.db.Query(ctx, listUserSessionsBefore,
+		arg.UserID,
+		arg.Status,
+		arg.StartedAt,
+		arg.ID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []UserSession{}
+	for rows.Next() {
+		var i UserSession
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.StartedAt,
+			&i.EndedAt,
+			&i.Status,
+			&i.LastActiveAt,
+			&i.ActiveSeconds,
+			&i.Paused,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const recordSessionActivity = `-- name: RecordSessionActivity :one
+UPDATE user_sessions
+SET active_seconds = active_seconds + CASE
+      WHEN paused OR NOW() - last_active_at > make_interval(secs => $1::int) THEN 0
+      ELSE ROUND(EXTRACT(EPOCH FROM NOW() - last_active_at))::int
+    END,
+    paused = CASE $2::text
+      WHEN 'pause' THEN TRUE
+      WHEN 'resume' THEN FALSE
+      WHEN 'answer' THEN FALSE
+      ELSE paused
+    END,
+    last_active_at = NOW()
+WHERE id = $3 AND user_id = $4 AND status = 'active'
+RETURNING id, user_id, started_at, ended_at, status, last_active_at, active_seconds, paused
+`
+
+type RecordSessionActivityParams struct {
+	MaxGapSeconds int32       `json:"max_gap_seconds"`
+	Kind          string      `json:"kind"`
+	ID            pgtype.UUID `json:"id"`
+	UserID        pgtype.UUID `json:"user_id"`
+}
+
+// Credits the time since the previous activity to active_seconds unless the
+// session was paused or the gap exceeds max_gap_seconds, i.e. the learner was idle.
+func (q *Queries) RecordSessionActivity(ctx context.Context, arg RecordSessionActivityParams) (UserSession, error) {
+	row := q.db.QueryRow(ctx, recordSessionActivity,
+		arg.MaxGapSeconds,
+		arg.Kind,
+		arg.ID,
+		arg.UserID,
+	)
+	var i UserSession
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.StartedAt,
+		&i.EndedAt,
+		&i.Status,
+		&i.LastActiveAt,
+		&i.ActiveSeconds,
+		&i.Paused,
+	)
+	return i, err
+}
+
 const updateUserSession = `-- name: UpdateUserSession :one
 UPDATE user_sessions
-SET status = $1, ended_at = $2
-WHERE id = $3
-RETURNING id, user_id, started_at, ended_at, status
+SET status = $1, ended_at = NOW()
+WHERE id = $2 AND user_id = $3 AND status = 'active'
+RETURNING id, user_id, started_at, ended_at, status, last_active_at, active_seconds, paused
 `
 
 type UpdateUserSessionParams struct {
-	Status  string             `json:"status"`
-	EndedAt pgtype.Timestamptz `json:"ended_at"`
-	ID      pgtype.UUID        `json:"id"`
+	Status string      `json:"status"`
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
 }
 
+// Ends an active session; ended sessions are final, so the row is only
+// returned when it was still active.
 func (q *Queries) UpdateUserSession(ctx context.Context, arg UpdateUserSessionParams) (UserSession, error) {
-	row := q.db.QueryRow(ctx, updateUserSession, arg.Status, arg.EndedAt, arg.ID)
+	row := q.db.QueryRow(ctx, updateUserSession, arg.Status, arg.ID, arg.UserID)
 	var i UserSession
 	err := row.Scan(
 		&i.ID,
@@ -165,6 +461,9 @@ func (q *Queries) UpdateUserSession(ctx context.Context, arg UpdateUserSessionPa
 		&i.StartedAt,
 		&i.EndedAt,
 		&i.Status,
+		&i.LastActiveAt,
+		&i.ActiveSeconds,
+		&i.Paused,
 	)
 	return i, err
 }
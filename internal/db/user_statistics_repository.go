@@ -11,52 +11,8 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const createUserStatistics = `-- name: CreateUserStatistics :one
-INSERT INTO user_statistics (
-  user_id, total_words_learned, accuracy, total_time
-) VALUES (
-  $1, $2, $3, $4
-)
-RETURNING user_id, total_words_learned, accuracy, total_time, updated_at
-`
-
-type CreateUserStatisticsParams struct {
-	UserID            pgtype.UUID    `json:"user_id"`
-	TotalWordsLearned int32          `json:"total_words_learned"`
-	Accuracy          pgtype.Numeric `json:"accuracy"`
-	TotalTime         int32          `json:"total_time"`
-}
-
-func (q *Queries) CreateUserStatistics(ctx context.Context, arg CreateUserStatisticsParams) (UserStatistic, error) {
-	row := q.db.QueryRow(ctx, createUserStatistics,
-		arg.UserID,
-		arg.TotalWordsLearned,
-		arg.Accuracy,
-		arg.TotalTime,
-	)
-	var i UserStatistic
-	err := row.Scan(
-		&i.UserID,
-		&i.TotalWordsLearned,
-		&i.Accuracy,
-		&i.TotalTime,
-		&i.UpdatedAt,
-	)
-	return i, err
-}
-
-const deleteUserStatistics = `-- name: DeleteUserStatistics :exec
-DELETE FROM user_statistics
-WHERE user_id = $1
-`
-
-func (q *Queries) DeleteUserStatistics(ctx context.Context, userID pgtype.UUID) error {
-	_, err := q.db.Exec(ctx, deleteUserStatistics, userID)
-	return err
-}
-
 const getUserStatistics = `-- name: GetUserStatistics :one
-SELECT user_id, total_words_learned, accuracy, total_time, updated_at FROM user_statistics
+SELECT user_id, total_words_learned, accuracy, total_time, updated_at, correct_attempts, incorrect_attempts FROM user_statistics
 WHERE user_id = $1 LIMIT 1
 `
 
@@ -69,13 +25,16 @@ func (q *Queries) GetUserStatistics(ctx context.Context, userID pgtype.UUID) (Us
 		&i.Accuracy,
 		&i.TotalTime,
 		&i.UpdatedAt,
+		&i.CorrectAttempts,
+		&i.IncorrectAttempts,
 	)
 	return i, err
 }
 
 const listUserStatistics = `-- name: ListUserStatistics :many
-SELECT user_id, total_words_learned, accuracy, total_time, updated_at FROM user_statistics
-ORDER BY updated_at DESC
+SELECT user_id, total_words_learned, accuracy, total_time, updated_at, correct_attempts, incorrect_attempts FROM user_statistics
+WHERE user_id IN (SELECT id FROM users WHERE is_active AND deleted_at IS NULL)
+ORDER BY updated_at DESC, user_id DESC
 LIMIT $1 OFFSET $2
 `
 
@@ -84,6 +43,7 @@ type ListUserStatisticsParams struct {
 	Offset int32 `json:"offset"`
 }
 
+// Statistics of deactivated or soft-deleted users are hidden from listings.
 func (q *Queries) ListUserStatistics(ctx context.Context, arg ListUserStatisticsParams) ([]UserStatistic, error) {
 	rows, err := q.db.Query(ctx, listUserStatistics, arg.Limit, arg.Offset)
 	if err != nil {
@@ -99,6 +59,8 @@ func (q *Queries) ListUserStatistics(ctx context.Context, arg ListUserStatistics
 			&i.Accuracy,
 			&i.TotalTime,
 			&i.UpdatedAt,
+			&i.CorrectAttempts,
+			&i.IncorrectAttempts,
 		); err != nil {
 			return nil, err
 		}
@@ -110,27 +72,99 @@ func (q *Queries) ListUserStatistics(ctx context.Context, arg ListUserStatistics
 	return items, nil
 }
 
-const updateUserStatistics = `-- name: UpdateUserStatistics :one
-UPDATE user_statistics
-SET total_words_learned = $1, accuracy = $2, total_time = $3, updated_at = NOW()
-WHERE user_id = $4
-RETURNING user_id, total_words_learned, accuracy, total_time, updated_at
+const listUserStatisticsAfter = `-- name: ListUserStatisticsAfter :many
+SELECT user_id, total_words_learned, accuracy, total_time, updated_at, correct_attempts, incorrect_attempts FROM user_statistics
+WHERE user_id IN (SELECT id FROM users WHERE is_active AND deleted_at IS NULL)
+  AND (updated_at, user_id) < ($1::timestamptz, $2::uuid)
+ORDER BY updated_at DESC, user_id DESC
+LIMIT $3
 `
 
-type UpdateUserStatisticsParams struct {
-	TotalWordsLearned int32          `json:"total_words_learned"`
-	Accuracy          pgtype.Numeric `json:"accuracy"`
-	TotalTime         int32          `json:"total_time"`
-	UserID            pgtype.UUID    `json:"user_id"`
+type ListUserStatisticsAfterParams struct {
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	Limit     int32              `json:"limit"`
 }
 
-func (q *Queries) UpdateUserStatistics(ctx context.Context, arg UpdateUserStatisticsParams) (UserStatistic, error) {
-	row := q.db.QueryRow(ctx, updateUserStatistics,
-		arg.TotalWordsLearned,
-		arg.Accuracy,
-		arg.TotalTime,
-		arg.UserID,
-	)
+// Keyset page of statistics strictly after the cursor, newest first.
+func (q *Queries) ListUserStatisticsAfter(ctx context.Context, arg ListUserStatisticsAfterParams) ([]UserStatistic, error) {
+	rows, err := q.db.Query(ctx, listUserStatisticsAfter, arg.UpdatedAt, arg.UserID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []UserStatistic{}
+	for rows.Next() {
+		var i UserStatistic
+		if err := rows.Scan(
+			&i.UserID,
+			&i.TotalWordsLearned,
+			&i.Accuracy,
+			&i.TotalTime,
+			&i.UpdatedAt,
+			&i.CorrectAttempts,
+			&i.IncorrectAttempts,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserStatisticsBefore = `-- name: ListUserStatisticsBefore :many
+SELECT user_id, total_words_learned, accuracy, total_time, updated_at, correct_attempts, incorrect_attempts FROM user_statistics
+WHERE user_id IN (SELECT id FROM users WHERE is_active AND deleted_at IS NULL)
+  AND (updated_at, user_id) > ($1::timestamptz, $2::uuid)
+ORDER BY updated_at ASC, user_id ASC
+LIMIT $3
+`
+
+type ListUserStatisticsBeforeParams struct {
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	Limit     int32              `json:"limit"`
+}
+
+// Keyset page of statistics strictly before the cursor, oldest first;
+// callers reverse the rows to restore newest-first order.
+func (q *Queries) ListUserStatisticsBefore(ctx context.Context, arg ListUserStatisticsBeforeParams) ([]UserStatistic, error) {
+	rows, err := q.db.Query(ctx, listUserStatisticsBefore, arg.UpdatedAt, arg.UserID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []UserStatistic{}
+	for rows.Next() {
+		var i UserStatistic
+		if err := rows.Scan(
+			&i.UserID,
+			&i.TotalWordsLearned,
+			&i.Accuracy,
+			&i.TotalTime,
+			&i.UpdatedAt,
+			&i.CorrectAttempts,
+			&i.IncorrectAttempts,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const recomputeUserStatistics = `-- name: RecomputeUserStatistics :one
+SELECT user_id, total_words_learned, accuracy, total_time, updated_at, correct_attempts, incorrect_attempts FROM refresh_user_statistics($1::uuid)
+`
+
+func (q *Queries) RecomputeUserStatistics(ctx context.Context, userID pgtype.UUID) (UserStatistic, error) {
+	row := q.db.QueryRow(ctx, recomputeUserStatistics, userID)
 	var i UserStatistic
 	err := row.Scan(
 		&i.UserID,
@@ -138,6 +172,8 @@ func (q *Queries) UpdateUserStatistics(ctx context.Context, arg UpdateUserStatis
 		&i.Accuracy,
 		&i.TotalTime,
 		&i.UpdatedAt,
+		&i.CorrectAttempts,
+		&i.IncorrectAttempts,
 	)
 	return i, err
 }
@@ -37,14 +37,22 @@ func (q *Queries) CreateUserWordSet(ctx context.Context, arg CreateUserWordSetPa
 	return i, err
 }
 
-const deleteUserWordSet = `-- name: DeleteUserWordSet :exec
+const deleteUserWordSet = `-- name: DeleteUserWordSet :execrows
 DELETE FROM user_word_sets
-WHERE id = $1
+WHERE id = $1 AND user_id = $2
 `
 
-func (q *Queries) DeleteUserWordSet(ctx context.Context, id pgtype.UUID) error {
-	_, err := q.db.Exec(ctx, deleteUserWordSet, id)
-	return err
+type DeleteUserWordSetParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) DeleteUserWordSet(ctx context.Context, arg DeleteUserWordSetParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteUserWordSet, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const getUserWordSet = `-- name: GetUserWordSet :one
@@ -67,7 +75,7 @@ func (q *Queries) GetUserWordSet(ctx context.Context, id pgtype.UUID) (UserWordS
 const listUserWordSets = `-- name: ListUserWordSets :many
 SELECT id, user_id, word_set_id, created_at FROM user_word_sets
 WHERE user_id = $1
-ORDER BY created_at DESC
+ORDER BY created_at DESC, id DESC
 LIMIT $2 OFFSET $3
 `
 
@@ -105,17 +113,18 @@ func (q *Queries) ListUserWordSets(ctx context.Context, arg ListUserWordSetsPara
 const updateUserWordSet = `-- name: UpdateUserWordSet :one
 UPDATE user_word_sets
 SET word_set_id = $2
-WHERE id = $1
+WHERE id = $1 AND user_id = $3
 RETURNING id, user_id, word_set_id, created_at
 `
 
 type UpdateUserWordSetParams struct {
 	ID        pgtype.UUID `json:"id"`
 	WordSetID pgtype.UUID `json:"word_set_id"`
+	UserID    pgtype.UUID `json:"user_id"`
 }
 
 func (q *Queries) UpdateUserWordSet(ctx context.Context, arg UpdateUserWordSetParams) (UserWordSet, error) {
-	row := q.db.QueryRow(ctx, updateUserWordSet, arg.ID, arg.WordSetID)
+	row := q.db.QueryRow(ctx, updateUserWordSet, arg.ID, arg.WordSetID, arg.UserID)
 	var i UserWordSet
 	err := row.Scan(
 		&i.ID,
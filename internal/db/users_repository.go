@@ -13,10 +13,33 @@ import (
 
 const countUsers = `-- name: CountUsers :one
 SELECT COUNT(*) FROM users
+WHERE ($1::text IS NULL OR lower(username) LIKE lower($1) || '%')
+  AND ($2::text IS NULL OR lower(split_part(email, '@', 2)) = lower($2))
+  AND ($3::bool IS NULL OR is_active = $3)
+  AND ($4::timestamptz IS NULL OR created_at >= $4)
+  AND ($5::timestamptz IS NULL OR created_at < $5)
+  AND (deleted_at IS NOT NULL) = $6::bool
 `
 
-func (q *Queries) CountUsers(ctx context.Context) (int64, error) {
-	row := q.db.QueryRow(ctx, countUsers)
+type CountUsersParams struct {
+	UsernamePrefix pgtype.Text        `json:"username_prefix"`
+	EmailDomain    pgtype.Text        `json:"email_domain"`
+	IsActive       pgtype.Bool        `json:"is_active"`
+	CreatedFrom    pgtype.Timestamptz `json:"created_from"`
+	CreatedTo      pgtype.Timestamptz `json:"created_to"`
+	Deleted        bool               `json:"deleted"`
+}
+
+// Takes the same filters as ListUsers.
+func (q *Queries) CountUsers(ctx context.Context, arg CountUsersParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countUsers,
+		arg.UsernamePrefix,
+		arg.EmailDomain,
+		arg.IsActive,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.Deleted,
+	)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
@@ -28,7 +51,7 @@ INSERT INTO users (
 ) VALUES (
   $1, $2
 )
-RETURNING id, username, email, created_at, is_active
+RETURNING id, username, email, created_at, is_active, role, deleted_at
 `
 
 type CreateUserParams struct {
@@ -45,25 +68,33 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.Email,
 		&i.CreatedAt,
 		&i.IsActive,
+		&i.Role,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
-const deleteUser = `-- name: DeleteUser :exec
-DELETE FROM users
-WHERE id = $1
+const deleteUser = `-- name: DeleteUser :execrows
+UPDATE users
+SET deleted_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
 `
 
-func (q *Queries) DeleteUser(ctx context.Context, id pgtype.UUID) error {
-	_, err := q.db.Exec(ctx, deleteUser, id)
-	return err
+// Soft delete: the row is kept until PurgeDeletedUsers removes it after the restore period.
+func (q *Queries) DeleteUser(ctx context.Context, id pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteUser, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const getUser = `-- name: GetUser :one
-SELECT id, username, email, created_at, is_active FROM users
-WHERE id = $1 LIMIT 1
+SELECT id, username, email, created_at, is_active, role, deleted_at FROM users
+WHERE id = $1 AND is_active AND deleted_at IS NULL LIMIT 1
 `
 
+// Deactivated and soft-deleted users are invisible to reads.
 func (q *Queries) GetUser(ctx context.Context, id pgtype.UUID) (User, error) {
 	row := q.db.QueryRow(ctx, getUser, id)
 	var i User
@@ -73,13 +104,15 @@ func (q *Queries) GetUser(ctx context.Context, id pgtype.UUID) (User, error) {
 		&i.Email,
 		&i.CreatedAt,
 		&i.IsActive,
+		&i.Role,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, username, email, created_at, is_active FROM users
-WHERE email = $1 LIMIT 1
+SELECT id, username, email, created_at, is_active, role, deleted_at FROM users
+WHERE email = $1 AND is_active AND deleted_at IS NULL LIMIT 1
 `
 
 func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
@@ -91,23 +124,80 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.Email,
 		&i.CreatedAt,
 		&i.IsActive,
+		&i.Role,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getUserIncludingDeleted = `-- name: GetUserIncludingDeleted :one
+SELECT id, username, email, created_at, is_active, role, deleted_at FROM users
+WHERE id = $1 LIMIT 1
+`
+
+// Deactivated and soft-deleted users too, for reads that must reach them until the purge.
+func (q *Queries) GetUserIncludingDeleted(ctx context.Context, id pgtype.UUID) (User, error) {
+	row := q.db.QueryRow(ctx, getUserIncludingDeleted, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.CreatedAt,
+		&i.IsActive,
+		&i.Role,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
 const listUsers = `-- name: ListUsers :many
-SELECT id, username, email, created_at, is_active FROM users
-ORDER BY created_at DESC
-LIMIT $1 OFFSET $2
+SELECT id, username, email, created_at, is_active, role, deleted_at FROM users
+WHERE ($1::text IS NULL OR lower(username) LIKE lower($1) || '%')
+  AND ($2::text IS NULL OR lower(split_part(email, '@', 2)) = lower($2))
+  AND ($3::bool IS NULL OR is_active = $3)
+  AND ($4::timestamptz IS NULL OR created_at >= $4)
+  AND ($5::timestamptz IS NULL OR created_at < $5)
+  AND (deleted_at IS NOT NULL) = $6::bool
+ORDER BY
+  CASE WHEN $7::text = 'username' THEN username END ASC,
+  CASE WHEN $7::text = '-username' THEN username END DESC,
+  CASE WHEN $7::text = 'email' THEN email END ASC,
+  CASE WHEN $7::text = '-email' THEN email END DESC,
+  CASE WHEN $7::text = 'created_at' THEN created_at END ASC,
+  created_at DESC,
+  id DESC
+LIMIT $8 OFFSET $9
 `
 
 type ListUsersParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	UsernamePrefix pgtype.Text        `json:"username_prefix"`
+	EmailDomain    pgtype.Text        `json:"email_domain"`
+	IsActive       pgtype.Bool        `json:"is_active"`
+	CreatedFrom    pgtype.Timestamptz `json:"created_from"`
+	CreatedTo      pgtype.Timestamptz `json:"created_to"`
+	Deleted        bool               `json:"deleted"`
+	Sort           string             `json:"sort"`
+	Limit          int32              `json:"limit"`
+	Offset         int32              `json:"offset"`
 }
 
+// Every filter except deleted is optional: NULL matches all rows. username_prefix is a LIKE
+// pattern prefix, so callers escape % and _. deleted selects soft-deleted rows
+// instead of live ones. sort is one of the orderings accepted by the service;
+// anything else falls back to newest first.
 func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error) {
-	rows, err := q.db.Query(ctx, listUsers, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listUsers,
+		arg.UsernamePrefix,
+		arg.EmailDomain,
+		arg.IsActive,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.Deleted,
+		arg.Sort,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -121,6 +211,8 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, e
 			&i.Email,
 			&i.CreatedAt,
 			&i.IsActive,
+			&i.Role,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -132,11 +224,208 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, e
 	return items, nil
 }
 
+const listUsersAfter = `-- name: ListUsersAfter :many
+SELECT id, username, email, created_at, is_active, role, deleted_at FROM users
+WHERE ($1::text IS NULL OR lower(username) LIKE lower($1) || '%')
+  AND ($2::text IS NULL OR lower(split_part(email, '@', 2)) = lower($2))
+  AND ($3::bool IS NULL OR is_active = $3)
+  AND ($4::timestamptz IS NULL OR created_at >= $4)
+  AND ($5::timestamptz IS NULL OR created_at < $5)
+  AND (deleted_at IS NOT NULL) = $6::bool
+  AND (created_at, id) < ($7::timestamptz, $8::uuid)
+ORDER BY created_at DESC, id DESC
+LIMIT $9
+`
+
+type ListUsersAfterParams struct {
+	UsernamePrefix pgtype.Text        `json:"username_prefix"`
+	EmailDomain    pgtype.Text        `json:"email_domain"`
+	IsActive       pgtype.Bool        `json:"is_active"`
+	CreatedFrom    pgtype.Timestamptz `json:"created_from"`
+	CreatedTo      pgtype.Timestamptz `json:"created_to"`
+	Deleted        bool               `json:"deleted"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	ID             pgtype.UUID        `json:"id"`
+	Limit          int32              `json:"limit"`
+}
+
+// Keyset page of users strictly after the cursor, newest first.
+func (q *Queries) ListUsersAfter(ctx context.Context, arg ListUsersAfterParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, listUsersAfter,
+		arg.UsernamePrefix,
+		arg.EmailDomain,
+		arg.IsActive,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.Deleted,
+		arg.CreatedAt,
+		arg.ID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.Email,
+			&i.CreatedAt,
+			&i.IsActive,
+			&i.Role,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsersBefore = `-- name: ListUsersBefore :many
+SELECT id, username, email, created_at, is_active, role, deleted_at FROM users
+WHERE ($1::text IS NULL OR lower(username) LIKE lower($1) || '%')
+  AND ($2::text IS NULL OR lower(split_part(email, '@', 2)) = lower($2))
+  AND ($3::bool IS NULL OR is_active = $3)
+  AND ($4::timestamptz IS NULL OR created_at >= $4)
+  AND ($5::timestamptz IS NULL OR created_at < $5)
+  AND (deleted_at IS NOT NULL) = $6::bool
+  AND (created_at, id) > ($7::timestamptz, $8::uuid)
+ORDER BY created_at ASC, id ASC
+LIMIT $9
+`
+
+type ListUsersBeforeParams struct {
+	UsernamePrefix pgtype.Text        `json:"username_prefix"`
+	EmailDomain    pgtype.Text        `json:"email_domain"`
+	IsActive       pgtype.Bool        `json:"is_active"`
+	CreatedFrom    pgtype.Timestamptz `json:"created_from"`
+	CreatedTo      pgtype.Timestamptz `json:"created_to"`
+	Deleted        bool               `json:"deleted"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	ID             pgtype.UUID        `json:"id"`
+	Limit          int32              `json:"limit"`
+}
+
+// Keyset page of users strictly before the cursor, oldest first;
+// callers reverse the rows to restore newest-first order.
+func (q *Queries) ListUsersBefore(ctx context.Context, arg ListUsersBeforeParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, listUsersBefore,
+		arg.UsernamePrefix,
+		arg.EmailDomain,
+		arg.IsActive,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.Deleted,
+		arg.CreatedAt,
+		arg.ID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.Email,
+			&i.CreatedAt,
+			&i.IsActive,
+			&i.Role,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const purgeDeletedUsers = `-- name: PurgeDeletedUsers :execrows
+DELETE FROM users
+WHERE deleted_at < $1
+`
+
+// Hard delete cascades to sessions, progress, statistics and credentials.
+func (q *Queries) PurgeDeletedUsers(ctx context.Context, deletedBefore pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, purgeDeletedUsers, deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const restoreUser = `-- name: RestoreUser :one
+UPDATE users
+SET deleted_at = NULL
+WHERE id = $1 AND deleted_at >= $2
+RETURNING id, username, email, created_at, is_active, role, deleted_at
+`
+
+type RestoreUserParams struct {
+	ID           pgtype.UUID        `json:"id"`
+	DeletedAfter pgtype.Timestamptz `json:"deleted_after"`
+}
+
+func (q *Queries) RestoreUser(ctx context.Context, arg RestoreUserParams) (User, error) {
+	row := q.db.QueryRow(ctx, restoreUser, arg.ID, arg.DeletedAfter)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.CreatedAt,
+		&i.IsActive,
+		&i.Role,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const setUserActive = `-- name: SetUserActive :one
+UPDATE users
+SET is_active = $1
+WHERE id = $2 AND deleted_at IS NULL
+RETURNING id, username, email, created_at, is_active, role, deleted_at
+`
+
+type SetUserActiveParams struct {
+	IsActive bool        `json:"is_active"`
+	ID       pgtype.UUID `json:"id"`
+}
+
+func (q *Queries) SetUserActive(ctx context.Context, arg SetUserActiveParams) (User, error) {
+	row := q.db.QueryRow(ctx, setUserActive, arg.IsActive, arg.ID)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.Email,
+		&i.CreatedAt,
+		&i.IsActive,
+		&i.Role,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
 const updateUser = `-- name: UpdateUser :one
 UPDATE users
 SET username = $1, email = $2
-WHERE id = $3
-RETURNING id, username, email, created_at, is_active
+WHERE id = $3 AND is_active AND deleted_at IS NULL
+RETURNING id, username, email, created_at, is_active, role, deleted_at
 `
 
 type UpdateUserParams struct {
@@ -154,6 +443,8 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.Email,
 		&i.CreatedAt,
 		&i.IsActive,
+		&i.Role,
+		&i.DeletedAt,
 	)
 	return i, err
 }
@@ -0,0 +1,64 @@
+package dto
+
+import (
+	"test-http/internal/db"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type StartGameRequest struct {
+	UserID        pgtype.UUID `json:"user_id" validate:"required,uuid"`
+	UserWordSetID pgtype.UUID `json:"user_word_set_id" validate:"required,uuid"`
+	Mode          string      `json:"mode" validate:"required,oneof=multiple_choice typing flashcard mixed"`
+	Size          int32       `json:"size" validate:"gte=0,lte=50"`
+	// AbandonActive ends the user's unfinished session first. Without it a user with an
+	// active session gets USER_SESSION_ALREADY_ACTIVE, as with POST /sessions.
+	AbandonActive bool `json:"abandon_active"`
+}
+
+type GetGameQuestionRequest struct {
+	SessionID pgtype.UUID
+}
+
+type AnswerGameRequest struct {
+	SessionID  pgtype.UUID
+	QuestionID pgtype.UUID `json:"question_id" validate:"required,uuid"`
+	Answer     string      `json:"answer" validate:"max=500"`
+	Known      *bool       `json:"known"`
+}
+
+type FinishGameRequest struct {
+	SessionID pgtype.UUID
+}
+
+// GameQuestion is what the client sees: the expected answer is only revealed for flashcards.
+type GameQuestion struct {
+	ID       pgtype.UUID `json:"id"`
+	Position int32       `json:"position"`
+	Kind     string      `json:"kind"`
+	Prompt   string      `json:"prompt"`
+	Choices  []string    `json:"choices,omitempty"`
+	Answer   string      `json:"answer,omitempty"`
+}
+
+type GameSummary struct {
+	SessionID pgtype.UUID `json:"session_id"`
+	Status    string      `json:"status"`
+	Total     int64       `json:"total"`
+	Answered  int64       `json:"answered"`
+	Correct   int64       `json:"correct"`
+}
+
+type StartGameResponse struct {
+	Session  db.UserSession `json:"session"`
+	Total    int            `json:"total"`
+	Question GameQuestion   `json:"question"`
+}
+
+type AnswerGameResponse struct {
+	Correct       bool            `json:"correct"`
+	CorrectAnswer string          `json:"correct_answer"`
+	Progress      db.UserProgress `json:"progress"`
+	Finished      bool            `json:"finished"`
+	Summary       GameSummary     `json:"summary"`
+}
@@ -0,0 +1,49 @@
+package dto
+
+import (
+	"encoding/json"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// CreateImportRequest queues a word list for import. Words land in WordSetID when it
+// is set, in a new private catalog set named WordSetTitle when that is set, or in no
+// set at all. CreatedBy is empty for imports started from the command line.
+type CreateImportRequest struct {
+	CreatedBy    pgtype.UUID
+	Filename     string      `json:"filename" validate:"required,max=255"`
+	Format       string      `json:"format" validate:"required,oneof=csv tsv anki_text apkg"`
+	SourceLang   string      `json:"source_lang" validate:"required,min=2,max=8"`
+	TargetLang   string      `json:"target_lang" validate:"required,min=2,max=8,nefield=SourceLang"`
+	WordSetID    pgtype.UUID `json:"word_set_id"`
+	WordSetTitle string      `json:"word_set_title" validate:"omitempty,max=200"`
+	Data         []byte      `json:"-" validate:"required"`
+}
+
+type GetImportRequest struct {
+	ID pgtype.UUID
+}
+
+type RetryImportRequest struct {
+	ID pgtype.UUID
+}
+
+type ListImportErrorsRequest struct {
+	ID     pgtype.UUID
+	Limit  int32 `json:"limit" validate:"gte=0,lte=100"`
+	Offset int32 `json:"offset" validate:"gte=0"`
+}
+
+// ImportRowError is a row the import skipped. Error is the fault the row failed with:
+// IMPORT_ROW_INVALID with the source line in args and the offending fields.
+type ImportRowError struct {
+	Line  int32           `json:"line"`
+	Error json.RawMessage `json:"error"`
+}
+
+type ImportErrorsPage struct {
+	Items  []ImportRowError `json:"items"`
+	Total  int64            `json:"total"`
+	Limit  int32            `json:"limit"`
+	Offset int32            `json:"offset"`
+}
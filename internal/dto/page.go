@@ -0,0 +1,16 @@
+package dto
+
+// Page is one page of a keyset-paginated listing. The cursors are opaque, signed
+// tokens; pass one back as ?cursor= to fetch the neighbouring page.
+type Page[T any] struct {
+	Items      []T       `json:"items"`
+	NextCursor string    `json:"next_cursor,omitempty"`
+	PrevCursor string    `json:"prev_cursor,omitempty"`
+	Links      PageLinks `json:"links"`
+}
+
+// PageLinks are ready-made URLs for the neighbouring pages; empty at either end.
+type PageLinks struct {
+	Next string `json:"next,omitempty"`
+	Prev string `json:"prev,omitempty"`
+}
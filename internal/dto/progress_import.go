@@ -0,0 +1,33 @@
+package dto
+
+import "github.com/jackc/pgx/v5/pgtype"
+
+// ImportProgressRequest replays a review log exported by another app into the
+// user's progress. With DryRun nothing is written; the report says what would be.
+type ImportProgressRequest struct {
+	UserID     pgtype.UUID
+	SourceLang string `json:"source_lang" validate:"required,min=2,max=8"`
+	TargetLang string `json:"target_lang" validate:"required,min=2,max=8,nefield=SourceLang"`
+	DryRun     bool   `json:"dry_run"`
+	Data       []byte `json:"-" validate:"required"`
+}
+
+// UnmatchedWord is a word of the log that is not in the catalog for the language pair.
+type UnmatchedWord struct {
+	Word     string `json:"word"`
+	Attempts int    `json:"attempts"`
+}
+
+// ProgressImportReport sums up a progress import. Attempts counts the readable rows;
+// those are Replayed, Skipped because the word already has a later or equal attempt
+// on record (so importing the same log twice changes nothing), or left Unmatched.
+type ProgressImportReport struct {
+	DryRun    bool             `json:"dry_run"`
+	Attempts  int              `json:"attempts"`
+	Replayed  int              `json:"replayed"`
+	Skipped   int              `json:"skipped"`
+	Words     int              `json:"words"`
+	Sessions  int              `json:"sessions"`
+	Unmatched []UnmatchedWord  `json:"unmatched"`
+	Errors    []ImportRowError `json:"errors"`
+}
@@ -0,0 +1,14 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type ListDueReviewsRequest struct {
+	UserID pgtype.UUID `json:"user_id" validate:"required,uuid"`
+	// At defaults to the current time when zero.
+	At    time.Time `json:"at"`
+	Limit int32     `json:"limit" validate:"gte=0,lte=100"`
+}
@@ -1,6 +1,12 @@
 package dto
 
-import "github.com/jackc/pgx/v5/pgtype"
+import (
+	"time"
+
+	"test-http/internal/db"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
 
 type CreateUserRequest struct {
 	Username string `json:"username" validate:"required,min=3,max=50"`
@@ -25,7 +31,45 @@ type DeleteUserRequest struct {
 	ID pgtype.UUID
 }
 
+type DeactivateUserRequest struct {
+	ID pgtype.UUID
+}
+
+type ReactivateUserRequest struct {
+	ID pgtype.UUID
+}
+
+type RestoreUserRequest struct {
+	ID pgtype.UUID
+}
+
+// ListUsersRequest searches users. Every filter is optional; CreatedFrom is
+// inclusive and CreatedTo exclusive. Only active users are listed unless IsActive
+// is set, and Deleted lists soft-deleted users awaiting purge instead. Sort prefixed with "-" is descending and
+// defaults to -created_at, the only order that pages by Cursor; other orders
+// page by Offset.
 type ListUsersRequest struct {
-	Limit  int32 `json:"limit" validate:"gte=0,lte=100"`
-	Offset int32 `json:"offset" validate:"gte=0"`
+	UsernamePrefix string     `json:"username_prefix" validate:"omitempty,max=50"`
+	EmailDomain    string     `json:"email_domain" validate:"omitempty,hostname"`
+	IsActive       *bool      `json:"is_active"`
+	CreatedFrom    *time.Time `json:"created_from"`
+	CreatedTo      *time.Time `json:"created_to"`
+	Deleted        bool       `json:"deleted"`
+	Sort           string     `json:"sort" validate:"omitempty,oneof=created_at -created_at username -username email -email"`
+	Limit          int32      `json:"limit" validate:"gte=0,lte=100"`
+	Offset         int32      `json:"offset" validate:"gte=0"`
+	Cursor         string     `json:"cursor" validate:"omitempty,max=512"`
+}
+
+// UsersPage is a page of users plus the number of users matching the filters.
+type UsersPage struct {
+	Page[db.User]
+	Total int64 `json:"total"`
+}
+
+// ExportUserRequest asks for everything held about a user, as one JSON document
+// or as a ZIP archive with one JSON file per table. Format defaults to json.
+type ExportUserRequest struct {
+	ID     pgtype.UUID
+	Format string `json:"format" validate:"omitempty,oneof=json zip"`
 }
@@ -11,12 +11,14 @@ type CreateUserProgressRequest struct {
 
 type UpdateUserProgressRequest struct {
 	ID             pgtype.UUID `json:"id" validate:"required,uuid"`
+	UserID         pgtype.UUID `json:"user_id" validate:"required,uuid"`
 	CorrectCount   int32       `json:"correct_count" validate:"gte=0"`
 	IncorrectCount int32       `json:"incorrect_count" validate:"gte=0"`
 }
 
 type GetUserProgressRequest struct {
-	ID pgtype.UUID `json:"id" validate:"required,uuid"`
+	ID     pgtype.UUID `json:"id" validate:"required,uuid"`
+	UserID pgtype.UUID `json:"user_id" validate:"required,uuid"`
 }
 
 type GetUserProgressByUserAndWordRequest struct {
@@ -24,12 +26,28 @@ type GetUserProgressByUserAndWordRequest struct {
 	WordID pgtype.UUID `json:"word_id" validate:"required,uuid"`
 }
 
+// ListUserProgressRequest pages by Cursor when set; Offset is kept for older clients.
 type ListUserProgressRequest struct {
 	UserID pgtype.UUID `json:"user_id" validate:"required,uuid"`
 	Limit  int32       `json:"limit" validate:"gte=0,lte=100"`
 	Offset int32       `json:"offset" validate:"gte=0"`
+	Cursor string      `json:"cursor" validate:"omitempty,max=512"`
 }
 
 type DeleteUserProgressRequest struct {
-	ID pgtype.UUID `json:"id" validate:"required,uuid"`
+	ID     pgtype.UUID `json:"id" validate:"required,uuid"`
+	UserID pgtype.UUID `json:"user_id" validate:"required,uuid"`
+}
+
+type ProgressAttempt struct {
+	WordID  pgtype.UUID `json:"word_id" validate:"required,uuid"`
+	Correct bool        `json:"correct"`
+	// Quality is the SM-2 grade (0-5); when omitted it is derived from Correct.
+	Quality *int16 `json:"quality" validate:"omitempty,gte=0,lte=5"`
+}
+
+// RecordProgressAttemptsRequest records a batch of answers atomically.
+type RecordProgressAttemptsRequest struct {
+	UserID   pgtype.UUID       `json:"user_id" validate:"required,uuid"`
+	Attempts []ProgressAttempt `json:"attempts" validate:"required,min=1,max=100,dive"`
 }
@@ -2,31 +2,48 @@ package dto
 
 import "github.com/jackc/pgx/v5/pgtype"
 
+// CreateUserSessionRequest opens a new session; sessions always start active.
 type CreateUserSessionRequest struct {
 	UserID pgtype.UUID `json:"user_id" validate:"required,uuid"`
-	Status string      `json:"status" validate:"required,oneof=active completed"`
 }
 
+// UpdateUserSessionRequest ends an active session. ended_at is set by the server.
 type UpdateUserSessionRequest struct {
-	ID      pgtype.UUID        `json:"id" validate:"required,uuid"`
-	Status  string             `json:"status" validate:"required,oneof=active completed"`
-	EndedAt pgtype.Timestamptz `json:"ended_at"`
+	ID     pgtype.UUID `json:"id" validate:"required,uuid"`
+	UserID pgtype.UUID `json:"user_id" validate:"required,uuid"`
+	Status string      `json:"status" validate:"required,oneof=completed abandoned"`
 }
 
 type GetUserSessionRequest struct {
-	ID pgtype.UUID `json:"id" validate:"required,uuid"`
+	ID     pgtype.UUID `json:"id" validate:"required,uuid"`
+	UserID pgtype.UUID `json:"user_id" validate:"required,uuid"`
 }
 
+// ListUserSessionsRequest lists a user's sessions, optionally only those in Status.
+// It pages by Cursor when set; Offset is kept for older clients.
 type ListUserSessionsRequest struct {
 	UserID pgtype.UUID `json:"user_id" validate:"required,uuid"`
+	Status string      `json:"status" validate:"omitempty,oneof=active completed abandoned"`
 	Limit  int32       `json:"limit" validate:"gte=0,lte=100"`
 	Offset int32       `json:"offset" validate:"gte=0"`
+	Cursor string      `json:"cursor" validate:"omitempty,max=512"`
 }
 
 type ListActiveUserSessionsRequest struct {
 	UserID pgtype.UUID `json:"user_id" validate:"required,uuid"`
+	Limit  int32       `json:"limit" validate:"gte=0,lte=100"`
+	Offset int32       `json:"offset" validate:"gte=0"`
+}
+
+// RecordSessionEventRequest reports client activity on an active session. Answers are
+// recorded by the game endpoints, so only heartbeat, pause and resume are accepted here.
+type RecordSessionEventRequest struct {
+	ID     pgtype.UUID `json:"id" validate:"required,uuid"`
+	UserID pgtype.UUID `json:"user_id" validate:"required,uuid"`
+	Kind   string      `json:"kind" validate:"required,oneof=heartbeat pause resume"`
 }
 
 type DeleteUserSessionRequest struct {
-	ID pgtype.UUID `json:"id" validate:"required,uuid"`
+	ID     pgtype.UUID `json:"id" validate:"required,uuid"`
+	UserID pgtype.UUID `json:"user_id" validate:"required,uuid"`
 }
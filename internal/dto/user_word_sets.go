@@ -8,7 +8,8 @@ type CreateUserWordSetRequest struct {
 }
 
 type GetUserWordSetRequest struct {
-	ID pgtype.UUID `json:"id" validate:"required,uuid"`
+	ID     pgtype.UUID `json:"id" validate:"required,uuid"`
+	UserID pgtype.UUID `json:"user_id" validate:"required,uuid"`
 }
 
 type ListUserWordSetsRequest struct {
@@ -19,9 +20,11 @@ type ListUserWordSetsRequest struct {
 
 type UpdateUserWordSetRequest struct {
 	ID        pgtype.UUID `json:"id" validate:"required,uuid"`
+	UserID    pgtype.UUID `json:"user_id" validate:"required,uuid"`
 	WordSetID pgtype.UUID `json:"word_set_id" validate:"required,uuid"`
 }
 
 type DeleteUserWordSetRequest struct {
-	ID pgtype.UUID `json:"id" validate:"required,uuid"`
+	ID     pgtype.UUID `json:"id" validate:"required,uuid"`
+	UserID pgtype.UUID `json:"user_id" validate:"required,uuid"`
 }
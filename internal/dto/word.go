@@ -0,0 +1,46 @@
+package dto
+
+import "github.com/jackc/pgx/v5/pgtype"
+
+type CreateWordRequest struct {
+	Lemma        string   `json:"lemma" validate:"required,max=200"`
+	Translation  string   `json:"translation" validate:"required,max=500"`
+	PartOfSpeech string   `json:"part_of_speech" validate:"required,oneof=noun verb adjective adverb pronoun preposition conjunction interjection phrase other"`
+	SourceLang   string   `json:"source_lang" validate:"required,min=2,max=8"`
+	TargetLang   string   `json:"target_lang" validate:"required,min=2,max=8,nefield=SourceLang"`
+	Examples     []string `json:"examples" validate:"max=20,dive,required,max=1000"`
+	Difficulty   int16    `json:"difficulty" validate:"gte=1,lte=5"`
+}
+
+type UpdateWordRequest struct {
+	ID           pgtype.UUID
+	Lemma        string   `json:"lemma" validate:"required,max=200"`
+	Translation  string   `json:"translation" validate:"required,max=500"`
+	PartOfSpeech string   `json:"part_of_speech" validate:"required,oneof=noun verb adjective adverb pronoun preposition conjunction interjection phrase other"`
+	SourceLang   string   `json:"source_lang" validate:"required,min=2,max=8"`
+	TargetLang   string   `json:"target_lang" validate:"required,min=2,max=8,nefield=SourceLang"`
+	Examples     []string `json:"examples" validate:"max=20,dive,required,max=1000"`
+	Difficulty   int16    `json:"difficulty" validate:"gte=1,lte=5"`
+}
+
+type GetWordRequest struct {
+	ID pgtype.UUID
+}
+
+type ResolveWordsRequest struct {
+	IDs []pgtype.UUID `json:"ids" validate:"required,min=1,max=100"`
+}
+
+type SearchWordsRequest struct {
+	Query        string `json:"q" validate:"omitempty,max=200"`
+	SourceLang   string `json:"source_lang" validate:"omitempty,min=2,max=8"`
+	TargetLang   string `json:"target_lang" validate:"omitempty,min=2,max=8"`
+	PartOfSpeech string `json:"part_of_speech" validate:"omitempty,oneof=noun verb adjective adverb pronoun preposition conjunction interjection phrase other"`
+	Difficulty   int16  `json:"difficulty" validate:"omitempty,gte=1,lte=5"`
+	Limit        int32  `json:"limit" validate:"gte=0,lte=100"`
+	Offset       int32  `json:"offset" validate:"gte=0"`
+}
+
+type DeleteWordRequest struct {
+	ID pgtype.UUID
+}
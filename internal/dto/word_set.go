@@ -0,0 +1,66 @@
+package dto
+
+import (
+	"test-http/internal/db"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type CreateWordSetRequest struct {
+	Title       string      `json:"title" validate:"required,min=1,max=200"`
+	Description string      `json:"description" validate:"max=2000"`
+	Language    string      `json:"language" validate:"required,min=2,max=8"`
+	OwnerID     pgtype.UUID `json:"owner_id"`
+	Visibility  string      `json:"visibility" validate:"required,oneof=public private"`
+}
+
+type UpdateWordSetRequest struct {
+	ID          pgtype.UUID
+	Title       string `json:"title" validate:"required,min=1,max=200"`
+	Description string `json:"description" validate:"max=2000"`
+	Language    string `json:"language" validate:"required,min=2,max=8"`
+	Visibility  string `json:"visibility" validate:"required,oneof=public private"`
+}
+
+type GetWordSetRequest struct {
+	ID pgtype.UUID
+}
+
+type ListWordSetsRequest struct {
+	OwnerID  pgtype.UUID `json:"owner_id"`
+	Language string      `json:"language" validate:"omitempty,min=2,max=8"`
+	Limit    int32       `json:"limit" validate:"gte=0,lte=100"`
+	Offset   int32       `json:"offset" validate:"gte=0"`
+}
+
+type DeleteWordSetRequest struct {
+	ID pgtype.UUID
+}
+
+type AddWordSetItemRequest struct {
+	WordSetID pgtype.UUID
+	WordID    pgtype.UUID `json:"word_id" validate:"required"`
+}
+
+type RemoveWordSetItemRequest struct {
+	WordSetID pgtype.UUID
+	WordID    pgtype.UUID
+}
+
+type ReorderWordSetItemsRequest struct {
+	WordSetID pgtype.UUID
+	WordIDs   []pgtype.UUID `json:"word_ids" validate:"required,min=1,max=1000"`
+}
+
+type ListWordSetItemsRequest struct {
+	WordSetID pgtype.UUID
+	Limit     int32 `json:"limit" validate:"gte=0,lte=100"`
+	Offset    int32 `json:"offset" validate:"gte=0"`
+}
+
+type WordSetItemsPage struct {
+	Items  []db.ListWordSetItemsRow `json:"items"`
+	Total  int64                    `json:"total"`
+	Limit  int32                    `json:"limit"`
+	Offset int32                    `json:"offset"`
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"test-http/internal/dto"
+	"test-http/internal/middleware"
+	"test-http/internal/service"
+	errorsPkg "test-http/pkg/errors_pkg"
+	"test-http/pkg/fault"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type AuthHandler struct {
+	logger   *slog.Logger
+	validate *validator.Validate
+	service  *service.AuthService
+}
+
+func NewAuthHandler(service *service.AuthService, validate *validator.Validate, logger *slog.Logger) *AuthHandler {
+	return &AuthHandler{
+		logger:   logger,
+		validate: validate,
+		service:  service,
+	}
+}
+
+func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("Register handler called")
+
+	defer r.Body.Close()
+
+	var req dto.RegisterRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("DecodeJSON failed", "err", err)
+		return fault.HTTPError(w, r, errorsPkg.DecodeFailed.Err())
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		log.Error("validation failed", "err", err)
+		return fault.HTTPError(w, r, validationFault(err))
+	}
+
+	resp, err := h.service.Register(ctx, req)
+	if err != nil {
+		log.Error("AuthService.Register failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextAuthenticatingMissing))
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, resp)
+	return nil
+}
+
+func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("Login handler called")
+
+	defer r.Body.Close()
+
+	var req dto.LoginRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("DecodeJSON failed", "err", err)
+		return fault.HTTPError(w, r, errorsPkg.DecodeFailed.Err())
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		log.Error("validation failed", "err", err)
+		return fault.HTTPError(w, r, validationFault(err))
+	}
+
+	tokens, err := h.service.Login(ctx, req)
+	if err != nil {
+		log.Error("AuthService.Login failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextAuthenticatingMissing))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, tokens)
+	return nil
+}
+
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("Refresh handler called")
+
+	defer r.Body.Close()
+
+	var req dto.RefreshRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("DecodeJSON failed", "err", err)
+		return fault.HTTPError(w, r, errorsPkg.DecodeFailed.Err())
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		log.Error("validation failed", "err", err)
+		return fault.HTTPError(w, r, validationFault(err))
+	}
+
+	tokens, err := h.service.Refresh(ctx, req)
+	if err != nil {
+		log.Error("AuthService.Refresh failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextAuthenticatingMissing))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, tokens)
+	return nil
+}
+
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("Logout handler called")
+
+	defer r.Body.Close()
+
+	var req dto.LogoutRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("DecodeJSON failed", "err", err)
+		return fault.HTTPError(w, r, errorsPkg.DecodeFailed.Err())
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		log.Error("validation failed", "err", err)
+		return fault.HTTPError(w, r, validationFault(err))
+	}
+
+	if err := h.service.Logout(ctx, req); err != nil {
+		log.Error("AuthService.Logout failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextAuthenticatingMissing))
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+
+	errorsPkg "test-http/pkg/errors_pkg"
+	"test-http/pkg/fault"
+)
+
+// serviceFault keeps domain faults returned by a service (not found, mismatch, ...)
+// and replaces infrastructure failures with the handler's fallback code.
+func serviceFault(err error, fallback fault.Code) error {
+	var f *fault.Fault
+	if errors.As(err, &f) && f.Code != string(errorsPkg.InfrastructureUnexpected) {
+		return f
+	}
+	return fallback.Err()
+}
+
+// validationFault reports which fields failed validator.Struct and on which tag.
+func validationFault(err error) *fault.Fault {
+	f := errorsPkg.ValidationError.Err()
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return f
+	}
+	for _, fe := range fieldErrs {
+		f.WithFields(fault.FieldViolation{Field: fe.Field(), Tag: fe.Tag(), Param: fe.Param()})
+	}
+	return f
+}
@@ -1 +1,143 @@
 package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"test-http/internal/dto"
+	"test-http/internal/middleware"
+	"test-http/internal/service"
+	errorsPkg "test-http/pkg/errors_pkg"
+	"test-http/pkg/fault"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type GameHandler struct {
+	logger   *slog.Logger
+	validate *validator.Validate
+	service  *service.GameService
+}
+
+func NewGameHandler(service *service.GameService, validate *validator.Validate, logger *slog.Logger) *GameHandler {
+	return &GameHandler{
+		logger:   logger,
+		validate: validate,
+		service:  service,
+	}
+}
+
+func (h *GameHandler) StartGame(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("StartGame handler called")
+
+	defer r.Body.Close()
+
+	var req dto.StartGameRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("DecodeJSON failed", "err", err)
+		return fault.HTTPError(w, r, errorsPkg.DecodeFailed.Err())
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		log.Error("validation failed", "err", err)
+		return fault.HTTPError(w, r, validationFault(err))
+	}
+
+	round, err := h.service.Start(ctx, req)
+	if err != nil {
+		log.Error("GameService.Start failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextPlayingGameMissing))
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, round)
+	return nil
+}
+
+func (h *GameHandler) NextQuestion(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("NextQuestion handler called")
+
+	defer r.Body.Close()
+
+	sessionID, err := uuidURLParam(r, "session_id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+
+	question, err := h.service.Next(ctx, dto.GetGameQuestionRequest{SessionID: sessionID})
+	if err != nil {
+		log.Error("GameService.Next failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextPlayingGameMissing))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, question)
+	return nil
+}
+
+func (h *GameHandler) AnswerQuestion(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("AnswerQuestion handler called")
+
+	defer r.Body.Close()
+
+	var req dto.AnswerGameRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("DecodeJSON failed", "err", err)
+		return fault.HTTPError(w, r, errorsPkg.DecodeFailed.Err())
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		log.Error("validation failed", "err", err)
+		return fault.HTTPError(w, r, validationFault(err))
+	}
+
+	sessionID, err := uuidURLParam(r, "session_id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+	req.SessionID = sessionID
+
+	result, err := h.service.Answer(ctx, req)
+	if err != nil {
+		log.Error("GameService.Answer failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextPlayingGameMissing))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, result)
+	return nil
+}
+
+func (h *GameHandler) FinishGame(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("FinishGame handler called")
+
+	defer r.Body.Close()
+
+	sessionID, err := uuidURLParam(r, "session_id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+
+	summary, err := h.service.Finish(ctx, dto.FinishGameRequest{SessionID: sessionID})
+	if err != nil {
+		log.Error("GameService.Finish failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextPlayingGameMissing))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, summary)
+	return nil
+}
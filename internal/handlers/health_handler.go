@@ -1 +1,62 @@
 package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"test-http/pkg/health"
+
+	"github.com/go-chi/render"
+)
+
+type HealthHandler struct {
+	registry *health.Registry
+	verbose  bool
+	logger   *slog.Logger
+}
+
+// NewHealthHandler serves the probes of registry. Unless verbose is set, ?verbose is
+// ignored and every response is brief.
+func NewHealthHandler(registry *health.Registry, verbose bool, logger *slog.Logger) *HealthHandler {
+	return &HealthHandler{
+		registry: registry,
+		verbose:  verbose,
+		logger:   logger,
+	}
+}
+
+// LivezHandler reports whether the process can serve at all; it does not depend on
+// the database, so an outage there does not get every replica restarted.
+func (h *HealthHandler) LivezHandler(w http.ResponseWriter, r *http.Request) error {
+	return h.probe(w, r, health.Liveness)
+}
+
+// ReadyzHandler reports whether the replica should receive traffic.
+func (h *HealthHandler) ReadyzHandler(w http.ResponseWriter, r *http.Request) error {
+	return h.probe(w, r, health.Readiness)
+}
+
+// StartupzHandler reports whether the replica has finished starting up.
+func (h *HealthHandler) StartupzHandler(w http.ResponseWriter, r *http.Request) error {
+	return h.probe(w, r, health.Startup)
+}
+
+// probe answers 200 or 503 with a report of every check. Errors and details are only
+// included with ?verbose, and only when the handler allows verbose reports.
+func (h *HealthHandler) probe(w http.ResponseWriter, r *http.Request, probe health.Probe) error {
+	report := h.registry.Run(r.Context(), probe)
+	if report.Status != health.StatusOK {
+		h.logger.Warn("Probe failed", slog.String("probe", string(probe)), slog.Any("checks", report.Checks))
+	}
+	if !h.verbose || !r.URL.Query().Has("verbose") {
+		report = report.Brief()
+	}
+
+	if report.Status == health.StatusOK {
+		render.Status(r, http.StatusOK)
+	} else {
+		render.Status(r, http.StatusServiceUnavailable)
+	}
+	render.JSON(w, r, report)
+	return nil
+}
@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"test-http/internal/authz"
+	"test-http/internal/dto"
+	"test-http/internal/middleware"
+	"test-http/internal/service"
+	errorsPkg "test-http/pkg/errors_pkg"
+	"test-http/pkg/fault"
+	"test-http/pkg/uuidconv"
+	"test-http/pkg/wordimport"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// importFormMemory is how much of an upload is kept in memory before spilling to disk.
+const importFormMemory = 8 << 20
+
+type ImportHandler struct {
+	logger      *slog.Logger
+	validate    *validator.Validate
+	service     *service.ImportService
+	maxFileSize int64
+}
+
+func NewImportHandler(service *service.ImportService, validate *validator.Validate, maxFileSize int64, logger *slog.Logger) *ImportHandler {
+	return &ImportHandler{
+		logger:      logger,
+		validate:    validate,
+		service:     service,
+		maxFileSize: maxFileSize,
+	}
+}
+
+// CreateImport queues the multipart upload in the "file" field and answers 202 with
+// the job to poll. The format is taken from the file extension unless "format" is set.
+func (h *ImportHandler) CreateImport(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("CreateImport handler called")
+
+	defer r.Body.Close()
+
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxFileSize)
+	if err := r.ParseMultipartForm(importFormMemory); err != nil {
+		log.Error("ParseMultipartForm failed", "err", err)
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return fault.HTTPError(w, r, errorsPkg.ImportFileTooLarge.Err(&fault.Arg{K: "limit", V: strconv.FormatInt(h.maxFileSize, 10)}))
+		}
+		return fault.HTTPError(w, r, errorsPkg.DecodeFailed.Err())
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		log.Error("FormFile failed", "err", err)
+		return fault.HTTPError(w, r, errorsPkg.ValidationError.Err().WithFields(fault.FieldViolation{Field: "file", Tag: "required"}))
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		log.Error("reading upload failed", "err", err)
+		return fault.HTTPError(w, r, errorsPkg.DecodeFailed.Err())
+	}
+
+	req := dto.CreateImportRequest{
+		Filename:     header.Filename,
+		Format:       r.FormValue("format"),
+		SourceLang:   r.FormValue("source_lang"),
+		TargetLang:   r.FormValue("target_lang"),
+		WordSetTitle: r.FormValue("word_set_title"),
+		Data:         data,
+	}
+	if req.Format == "" {
+		req.Format = string(wordimport.DetectFormat(header.Filename))
+	}
+	if principal, ok := authz.FromContext(ctx); ok {
+		req.CreatedBy = principal.UserID
+	}
+	if raw := r.FormValue("word_set_id"); raw != "" {
+		wordSetID, err := uuid.Parse(raw)
+		if err != nil {
+			return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+		}
+		if req.WordSetID, err = uuidconv.SetPgUUID(wordSetID); err != nil {
+			return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+		}
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		log.Error("validation failed", "err", err)
+		return fault.HTTPError(w, r, validationFault(err))
+	}
+
+	job, err := h.service.Create(ctx, req)
+	if err != nil {
+		log.Error("ImportService.Create failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextImportingWordsMissing))
+	}
+
+	w.Header().Set("Location", "/api/v1/imports/"+job.ID.String())
+	render.Status(r, http.StatusAccepted)
+	render.JSON(w, r, job)
+	return nil
+}
+
+func (h *ImportHandler) GetImport(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("GetImport handler called")
+
+	defer r.Body.Close()
+
+	id, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+
+	job, err := h.service.Get(ctx, dto.GetImportRequest{ID: id})
+	if err != nil {
+		log.Error("ImportService.Get failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextImportingWordsMissing))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, job)
+	return nil
+}
+
+func (h *ImportHandler) ListImportErrors(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("ListImportErrors handler called")
+
+	defer r.Body.Close()
+
+	id, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+
+	req := dto.ListImportErrorsRequest{ID: id}
+	if req.Limit, err = int32QueryParam(r, "limit"); err != nil {
+		return fault.HTTPError(w, r, errorsPkg.QueryParamInvalid.Err(&fault.Arg{K: "param", V: "limit"}))
+	}
+	if req.Offset, err = int32QueryParam(r, "offset"); err != nil {
+		return fault.HTTPError(w, r, errorsPkg.QueryParamInvalid.Err(&fault.Arg{K: "param", V: "offset"}))
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		log.Error("validation failed", "err", err)
+		return fault.HTTPError(w, r, validationFault(err))
+	}
+
+	page, err := h.service.ListErrors(ctx, req)
+	if err != nil {
+		log.Error("ImportService.ListErrors failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextImportingWordsMissing))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, page)
+	return nil
+}
+
+// RetryImport queues a failed job again; the runner resumes it after the last committed batch.
+func (h *ImportHandler) RetryImport(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("RetryImport handler called")
+
+	defer r.Body.Close()
+
+	id, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+
+	job, err := h.service.Retry(ctx, dto.RetryImportRequest{ID: id})
+	if err != nil {
+		log.Error("ImportService.Retry failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextImportingWordsMissing))
+	}
+
+	render.Status(r, http.StatusAccepted)
+	render.JSON(w, r, job)
+	return nil
+}
@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"net/http"
+
+	"test-http/internal/dto"
+)
+
+// withPageLinks fills in next/prev URLs for page: the current request URL with
+// ?cursor= replaced and ?offset= dropped, since a cursor supersedes it.
+func withPageLinks[T any](r *http.Request, page dto.Page[T]) dto.Page[T] {
+	link := func(token string) string {
+		if token == "" {
+			return ""
+		}
+		q := r.URL.Query()
+		q.Del("offset")
+		q.Set("cursor", token)
+		u := *r.URL
+		u.RawQuery = q.Encode()
+		return u.RequestURI()
+	}
+	page.Links = dto.PageLinks{
+		Next: link(page.NextCursor),
+		Prev: link(page.PrevCursor),
+	}
+	return page
+}
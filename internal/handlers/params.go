@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"test-http/pkg/uuidconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// uuidURLParam parses a chi URL parameter into a pgtype.UUID.
+func uuidURLParam(r *http.Request, name string) (pgtype.UUID, error) {
+	id, err := uuid.Parse(chi.URLParam(r, name))
+	if err != nil {
+		return pgtype.UUID{}, err
+	}
+	return uuidconv.SetPgUUID(id)
+}
+
+// int32QueryParam reads an optional integer query parameter, returning 0 when it is absent.
+func int32QueryParam(r *http.Request, name string) (int32, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return 0, nil
+	}
+	v, err := strconv.ParseInt(raw, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return int32(v), nil
+}
+
+// boolQueryParam reads an optional boolean query parameter, returning nil when it is absent.
+func boolQueryParam(r *http.Request, name string) (*bool, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// timeQueryParam reads an optional RFC 3339 timestamp query parameter, returning nil when it is absent.
+func timeQueryParam(r *http.Request, name string) (*time.Time, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+	v, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
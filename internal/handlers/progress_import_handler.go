@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"test-http/internal/dto"
+	"test-http/internal/middleware"
+	"test-http/internal/service"
+	errorsPkg "test-http/pkg/errors_pkg"
+	"test-http/pkg/fault"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type ProgressImportHandler struct {
+	logger      *slog.Logger
+	validate    *validator.Validate
+	service     *service.ProgressImportService
+	maxFileSize int64
+}
+
+func NewProgressImportHandler(service *service.ProgressImportService, validate *validator.Validate, maxFileSize int64, logger *slog.Logger) *ProgressImportHandler {
+	return &ProgressImportHandler{
+		logger:      logger,
+		validate:    validate,
+		service:     service,
+		maxFileSize: maxFileSize,
+	}
+}
+
+// ImportProgress replays the review log in the multipart "file" field into the user's
+// progress and answers with the report. With ?dry_run=true nothing is written.
+func (h *ProgressImportHandler) ImportProgress(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("ImportProgress handler called")
+
+	defer r.Body.Close()
+
+	userID, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+	dryRun, err := boolQueryParam(r, "dry_run")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.QueryParamInvalid.Err(&fault.Arg{K: "param", V: "dry_run"}))
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxFileSize)
+	if err := r.ParseMultipartForm(importFormMemory); err != nil {
+		log.Error("ParseMultipartForm failed", "err", err)
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return fault.HTTPError(w, r, errorsPkg.ImportFileTooLarge.Err(&fault.Arg{K: "limit", V: strconv.FormatInt(h.maxFileSize, 10)}))
+		}
+		return fault.HTTPError(w, r, errorsPkg.DecodeFailed.Err())
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		log.Error("FormFile failed", "err", err)
+		return fault.HTTPError(w, r, errorsPkg.ValidationError.Err().WithFields(fault.FieldViolation{Field: "file", Tag: "required"}))
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		log.Error("reading upload failed", "err", err)
+		return fault.HTTPError(w, r, errorsPkg.DecodeFailed.Err())
+	}
+
+	req := dto.ImportProgressRequest{
+		UserID:     userID,
+		SourceLang: r.FormValue("source_lang"),
+		TargetLang: r.FormValue("target_lang"),
+		DryRun:     dryRun != nil && *dryRun,
+		Data:       data,
+	}
+	if err := h.validate.Struct(req); err != nil {
+		log.Error("validation failed", "err", err)
+		return fault.HTTPError(w, r, validationFault(err))
+	}
+
+	report, err := h.service.Import(ctx, req)
+	if err != nil {
+		log.Error("ProgressImportService.Import failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextImportingProgressMissing))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, report)
+	return nil
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"test-http/internal/dto"
+	"test-http/internal/middleware"
+	"test-http/internal/service"
+	errorsPkg "test-http/pkg/errors_pkg"
+	"test-http/pkg/fault"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type ReviewHandler struct {
+	logger   *slog.Logger
+	validate *validator.Validate
+	service  *service.ReviewService
+}
+
+func NewReviewHandler(service *service.ReviewService, validate *validator.Validate, logger *slog.Logger) *ReviewHandler {
+	return &ReviewHandler{
+		logger:   logger,
+		validate: validate,
+		service:  service,
+	}
+}
+
+func (h *ReviewHandler) DueReviews(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("DueReviews handler called")
+
+	defer r.Body.Close()
+
+	userID, err := uuidURLParam(r, "id")
+	if err != nil {
+		log.Error("invalid user id", "err", err)
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+
+	req := dto.ListDueReviewsRequest{UserID: userID}
+	if req.Limit, err = int32QueryParam(r, "limit"); err != nil {
+		return fault.HTTPError(w, r, errorsPkg.QueryParamInvalid.Err(&fault.Arg{K: "param", V: "limit"}))
+	}
+	if raw := r.URL.Query().Get("at"); raw != "" {
+		if req.At, err = time.Parse(time.RFC3339, raw); err != nil {
+			return fault.HTTPError(w, r, errorsPkg.QueryParamInvalid.Err(&fault.Arg{K: "param", V: "at"}))
+		}
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		log.Error("validation failed", "err", err)
+		return fault.HTTPError(w, r, validationFault(err))
+	}
+
+	reviews, err := h.service.Due(ctx, req)
+	if err != nil {
+		log.Error("ReviewService.Due failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextListingDueReviewsMissing))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, reviews)
+	return nil
+}
@@ -31,38 +31,6 @@ func NewStatisticsHandler(service *service.UserStatisticsService, validate *vali
 	}
 }
 
-func (s *StatisticsHandler) CreateStatistics(w http.ResponseWriter, r *http.Request) error {
-	ctx := r.Context()
-	traceID := middleware.GetTraceID(ctx)
-
-	log := s.logger.With(slog.String("trace_id", traceID))
-
-	log.Info("CreateStatistics handler called")
-
-	defer r.Body.Close()
-
-	var req dto.CreateStatisticsRequest
-	if err := render.DecodeJSON(r.Body, &req); err != nil {
-		log.Error("DecodeJSON failed", "err", err)
-		return fault.HTTPError(w, errorsPkg.DecodeFailed.Err())
-	}
-
-	if err := s.validate.Struct(req); err != nil {
-		log.Error("validation failed", "err", err)
-		return fault.HTTPError(w, errorsPkg.ValidationError.Err())
-	}
-
-	statistics, err := s.service.Create(ctx, req)
-	if err != nil {
-		log.Error("UserStatisticsService.Create failed", "err", err)
-		return fault.HTTPError(w, errorsPkg.ContextCreatingUserStatisticsMissing.Err())
-	}
-
-	render.Status(r, http.StatusCreated)
-	render.JSON(w, r, statistics)
-	return nil
-}
-
 func (s *StatisticsHandler) GetStatistics(w http.ResponseWriter, r *http.Request) error {
 	ctx := r.Context()
 	traceID := middleware.GetTraceID(ctx)
@@ -76,17 +44,17 @@ func (s *StatisticsHandler) GetStatistics(w http.ResponseWriter, r *http.Request
 	userIDStr := chi.URLParam(r, "user_id")
 	if userIDStr == "" {
 		log.Error("missing user_id in query parameters")
-		return fault.HTTPError(w, errorsPkg.ValidationError.Err())
+		return fault.HTTPError(w, r, errorsPkg.ValidationError.Err())
 	}
 
 	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
-		return fault.HTTPError(w, errorsPkg.UUIDParsingFailed.Err())
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
 	}
 
 	pgUUID, err := uuidconv.SetPgUUID(userID)
 	if err != nil {
-		return fault.HTTPError(w, errorsPkg.UUIDParsingFailed.Err())
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
 	}
 
 	statistics, err := s.service.GetByID(ctx, dto.GetStatisticsRequest{
@@ -94,7 +62,7 @@ func (s *StatisticsHandler) GetStatistics(w http.ResponseWriter, r *http.Request
 	})
 	if err != nil {
 		log.Error("UserStatisticsService.GetByID failed", "err", err)
-		return fault.HTTPError(w, errorsPkg.ContextGettingUserMissing.Err())
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextGettingUserStatisticsMissing))
 	}
 
 	render.Status(r, http.StatusOK)
@@ -102,71 +70,73 @@ func (s *StatisticsHandler) GetStatistics(w http.ResponseWriter, r *http.Request
 	return nil
 }
 
-func (s *StatisticsHandler) UpdateStatistics(w http.ResponseWriter, r *http.Request) error {
+// ListStatistics pages through every user's statistics, most recently updated first;
+// follow links.next/prev or pass ?cursor=.
+func (s *StatisticsHandler) ListStatistics(w http.ResponseWriter, r *http.Request) error {
 	ctx := r.Context()
 	traceID := middleware.GetTraceID(ctx)
 
 	log := s.logger.With(slog.String("trace_id", traceID))
 
-	log.Info("UpdateStatistics handler called")
+	log.Info("ListStatistics handler called")
 
 	defer r.Body.Close()
 
-	var req dto.UpdateStatisticsRequest
-	if err := render.DecodeJSON(r.Body, &req); err != nil {
-		log.Error("DecodeJSON failed", "err", err)
-		return fault.HTTPError(w, errorsPkg.DecodeFailed.Err())
+	req := dto.ListStatisticsRequest{Cursor: r.URL.Query().Get("cursor")}
+	var err error
+	if req.Limit, err = int32QueryParam(r, "limit"); err != nil {
+		return fault.HTTPError(w, r, errorsPkg.QueryParamInvalid.Err(&fault.Arg{K: "param", V: "limit"}))
+	}
+	if req.Offset, err = int32QueryParam(r, "offset"); err != nil {
+		return fault.HTTPError(w, r, errorsPkg.QueryParamInvalid.Err(&fault.Arg{K: "param", V: "offset"}))
 	}
 
 	if err := s.validate.Struct(req); err != nil {
 		log.Error("validation failed", "err", err)
-		return fault.HTTPError(w, errorsPkg.ValidationError.Err())
+		return fault.HTTPError(w, r, validationFault(err))
 	}
 
-	statistics, err := s.service.Update(ctx, req)
+	page, err := s.service.List(ctx, req)
 	if err != nil {
-		log.Error("UserStatisticsService.Update failed", "err", err)
-		return fault.HTTPError(w, errorsPkg.ContextUpdatingUserStatisticsMissing.Err())
+		log.Error("UserStatisticsService.List failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextGettingUserStatisticsMissing))
 	}
 
 	render.Status(r, http.StatusOK)
-	render.JSON(w, r, statistics)
+	render.JSON(w, r, withPageLinks(r, page))
 	return nil
 }
 
-func (s *StatisticsHandler) DeleteStatistics(w http.ResponseWriter, r *http.Request) error {
+// RecomputeStatistics refreshes the derived statistics for a user. The request
+// body is ignored; numbers are always computed server-side.
+func (s *StatisticsHandler) RecomputeStatistics(w http.ResponseWriter, r *http.Request) error {
 	ctx := r.Context()
 	traceID := middleware.GetTraceID(ctx)
 
 	log := s.logger.With(slog.String("trace_id", traceID))
 
-	log.Info("DeleteStatistics handler called")
+	log.Info("RecomputeStatistics handler called")
 
 	defer r.Body.Close()
 
-	userIDStr := chi.URLParam(r, "id")
-	if userIDStr == "" {
-		log.Error("missing user_id in query parameters")
-		return fault.HTTPError(w, errorsPkg.ValidationError.Err())
-	}
-
-	userID, err := uuid.Parse(userIDStr)
+	userID, err := uuidURLParam(r, "user_id")
 	if err != nil {
-		return fault.HTTPError(w, errorsPkg.UUIDParsingFailed.Err())
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
 	}
 
-	pgUUID, err := uuidconv.SetPgUUID(userID)
-	if err != nil {
-		return fault.HTTPError(w, errorsPkg.UUIDParsingFailed.Err())
+	req := dto.RecomputeStatisticsRequest{UserID: userID}
+	if err := s.validate.Struct(req); err != nil {
+		log.Error("validation failed", "err", err)
+		return fault.HTTPError(w, r, validationFault(err))
 	}
 
-	if err := s.service.Delete(ctx, dto.DeleteStatisticsRequest{
-		UserID: pgUUID,
-	}); err != nil {
-		log.Error("UserStatisticsService.Delete failed", "err", err)
-		return fault.HTTPError(w, errorsPkg.ContextDeletingUserStatisticsMissing.New())
+	statistics, err := s.service.Recompute(ctx, req)
+	if err != nil {
+		log.Error("UserStatisticsService.Recompute failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextUpdatingUserStatisticsMissing))
 	}
 
 	render.Status(r, http.StatusOK)
+	render.JSON(w, r, statistics)
 	return nil
 }
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"test-http/internal/dto"
+	"test-http/internal/middleware"
+	"test-http/internal/service"
+	errorsPkg "test-http/pkg/errors_pkg"
+	"test-http/pkg/fault"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type UserExportHandler struct {
+	logger   *slog.Logger
+	validate *validator.Validate
+	service  *service.UserExportService
+}
+
+func NewUserExportHandler(service *service.UserExportService, validate *validator.Validate, logger *slog.Logger) *UserExportHandler {
+	return &UserExportHandler{
+		logger:   logger,
+		validate: validate,
+		service:  service,
+	}
+}
+
+// ExportUser streams everything held about the user as an attachment, a JSON document
+// by default or a ZIP archive with ?format=zip. Errors after the first byte can no
+// longer change the status, so they only cut the download short.
+func (h *UserExportHandler) ExportUser(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	traceID := middleware.GetTraceID(ctx)
+	log := h.logger.With(slog.String("trace_id", traceID))
+
+	log.Info("ExportUser handler started")
+
+	defer r.Body.Close()
+
+	userID, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+
+	req := dto.ExportUserRequest{
+		ID:     userID,
+		Format: r.URL.Query().Get("format"),
+	}
+	if req.Format == "" {
+		req.Format = service.ExportFormatJSON
+	}
+	if err := h.validate.Struct(req); err != nil {
+		log.Error("validation failed", "err", err)
+		return fault.HTTPError(w, r, errorsPkg.QueryParamInvalid.Err(&fault.Arg{K: "param", V: "format"}))
+	}
+
+	export, err := h.service.Open(ctx, req)
+	if err != nil {
+		log.Error("UserExportService.Open failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextGettingUserMissing))
+	}
+
+	filename := "user-" + userID.String() + "-export." + req.Format
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	w.Header().Set("Cache-Control", "no-store")
+	if req.Format == service.ExportFormatZIP {
+		w.Header().Set("Content-Type", "application/zip")
+		w.WriteHeader(http.StatusOK)
+		err = export.WriteZIP(ctx, w)
+	} else {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		err = export.WriteJSON(ctx, w)
+	}
+	if err != nil {
+		log.Error("user export aborted", "err", err)
+		return err
+	}
+	return nil
+}
@@ -24,8 +24,8 @@ type UserHandler struct {
 	validate *validator.Validate
 }
 
-func NewUserHandler(log *slog.Logger, service *service.UserService) *UserHandler {
-	return &UserHandler{log: log, service: service, validate: validator.New()}
+func NewUserHandler(service *service.UserService, validate *validator.Validate, log *slog.Logger) *UserHandler {
+	return &UserHandler{log: log, service: service, validate: validate}
 }
 
 func (u *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) error {
@@ -40,12 +40,12 @@ func (u *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) error {
 	var req dto.CreateUserRequest
 	if err := render.DecodeJSON(r.Body, &req); err != nil {
 		u.log.Error("DecodeJSON failed", "err", err)
-		return fault.HTTPError(w, errorsPkg.DecodeFailed.Err())
+		return fault.HTTPError(w, r, errorsPkg.DecodeFailed.Err())
 	}
 
 	if err := u.validate.Struct(req); err != nil {
 		log.Error("validation failed", "err", err)
-		return fault.HTTPError(w, errorsPkg.ValidationError.Err())
+		return fault.HTTPError(w, r, validationFault(err))
 	}
 
 	user, err := u.service.Create(ctx, dto.CreateUserRequest{
@@ -54,7 +54,7 @@ func (u *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) error {
 	})
 	if err != nil {
 		log.Error("UserService.Create failed", "err", err)
-		return fault.HTTPError(w, errorsPkg.ContextCreatingUserMissing.Err())
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextCreatingUserMissing))
 	}
 
 	render.Status(r, http.StatusCreated)
@@ -74,17 +74,17 @@ func (u *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) error {
 	userIDStr := chi.URLParam(r, "id")
 	if userIDStr == "" {
 		log.Error("missing user id in query parameters")
-		return fault.HTTPError(w, errorsPkg.ValidationError.Err())
+		return fault.HTTPError(w, r, errorsPkg.ValidationError.Err())
 	}
 
 	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
-		return fault.HTTPError(w, errorsPkg.UUIDParsingFailed.Err())
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
 	}
 
 	pgUUID, err := uuidconv.SetPgUUID(userID)
 	if err != nil {
-		return fault.HTTPError(w, errorsPkg.UUIDParsingFailed.Err())
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
 	}
 
 	user, err := u.service.GetByID(ctx, dto.GetUserByIDRequest{
@@ -92,7 +92,7 @@ func (u *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) error {
 	})
 	if err != nil {
 		log.Error("UserService.GetByID failed", "err", err)
-		return fault.HTTPError(w, errorsPkg.ContextGettingUserMissing.Err())
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextGettingUserMissing))
 	}
 
 	render.Status(r, http.StatusOK)
@@ -101,6 +101,68 @@ func (u *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) error {
 
 }
 
+// ListUsers searches users by ?username= prefix, ?email_domain=, ?is_active= and a
+// ?created_from=/?created_to= range (RFC 3339), ordered by ?sort=. Only active users are
+// listed by default; ?deleted=true lists soft-deleted users that can still be restored. The default
+// newest-first order pages via links.next/prev or ?cursor=; other orders use ?offset=.
+func (u *UserHandler) ListUsers(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	traceID := middleware.GetTraceID(ctx)
+	log := u.log.With(slog.String("trace_id", traceID))
+
+	log.Info("ListUsers handler started")
+
+	defer r.Body.Close()
+
+	query := r.URL.Query()
+	req := dto.ListUsersRequest{
+		UsernamePrefix: query.Get("username"),
+		EmailDomain:    query.Get("email_domain"),
+		Sort:           query.Get("sort"),
+		Cursor:         query.Get("cursor"),
+	}
+	var err error
+	if req.IsActive, err = boolQueryParam(r, "is_active"); err != nil {
+		return fault.HTTPError(w, r, errorsPkg.QueryParamInvalid.Err(&fault.Arg{K: "param", V: "is_active"}))
+	}
+	deleted, err := boolQueryParam(r, "deleted")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.QueryParamInvalid.Err(&fault.Arg{K: "param", V: "deleted"}))
+	}
+	req.Deleted = deleted != nil && *deleted
+	if req.CreatedFrom, err = timeQueryParam(r, "created_from"); err != nil {
+		return fault.HTTPError(w, r, errorsPkg.QueryParamInvalid.Err(&fault.Arg{K: "param", V: "created_from"}))
+	}
+	if req.CreatedTo, err = timeQueryParam(r, "created_to"); err != nil {
+		return fault.HTTPError(w, r, errorsPkg.QueryParamInvalid.Err(&fault.Arg{K: "param", V: "created_to"}))
+	}
+	if req.CreatedFrom != nil && req.CreatedTo != nil && !req.CreatedTo.After(*req.CreatedFrom) {
+		return fault.HTTPError(w, r, errorsPkg.QueryParamInvalid.Err(&fault.Arg{K: "param", V: "created_to"}))
+	}
+	if req.Limit, err = int32QueryParam(r, "limit"); err != nil {
+		return fault.HTTPError(w, r, errorsPkg.QueryParamInvalid.Err(&fault.Arg{K: "param", V: "limit"}))
+	}
+	if req.Offset, err = int32QueryParam(r, "offset"); err != nil {
+		return fault.HTTPError(w, r, errorsPkg.QueryParamInvalid.Err(&fault.Arg{K: "param", V: "offset"}))
+	}
+
+	if err := u.validate.Struct(req); err != nil {
+		log.Error("validation failed", "err", err)
+		return fault.HTTPError(w, r, validationFault(err))
+	}
+
+	users, err := u.service.List(ctx, req)
+	if err != nil {
+		log.Error("UserService.List failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextGettingUserMissing))
+	}
+	users.Page = withPageLinks(r, users.Page)
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, users)
+	return nil
+}
+
 func (u *UserHandler) UserEmail(w http.ResponseWriter, r *http.Request) error {
 	ctx := r.Context()
 	traceID := middleware.GetTraceID(ctx)
@@ -114,7 +176,7 @@ func (u *UserHandler) UserEmail(w http.ResponseWriter, r *http.Request) error {
 	userEmail := chi.URLParam(r, "email")
 	if userEmail == "" {
 		log.Error("missing email in query parameters")
-		return fault.HTTPError(w, fault.UnhandledError.Err())
+		return fault.HTTPError(w, r, errorsPkg.ValidationError.Err())
 	}
 
 	user, err := u.service.GetByEmail(ctx, dto.GetUserByEmailRequest{
@@ -122,7 +184,7 @@ func (u *UserHandler) UserEmail(w http.ResponseWriter, r *http.Request) error {
 	})
 	if err != nil {
 		log.Error("UserService.GetByEmail failed", "email", userEmail, "err", err)
-		return fault.HTTPError(w, fault.UnhandledError.Err())
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextGettingUserMissing))
 	}
 
 	render.Status(r, http.StatusOK)
@@ -142,30 +204,114 @@ func (u *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) error {
 	userIDStr := chi.URLParam(r, "id")
 	if userIDStr == "" {
 		log.Error("missing user id in query parameters")
-		return fault.HTTPError(w, errorsPkg.ValidationError.Err())
+		return fault.HTTPError(w, r, errorsPkg.ValidationError.Err())
 	}
 
 	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
-		return fault.HTTPError(w, errorsPkg.UUIDParsingFailed.Err())
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
 	}
 
 	pgUUID, err := uuidconv.SetPgUUID(userID)
 	if err != nil {
-		return fault.HTTPError(w, errorsPkg.UUIDParsingFailed.Err())
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
 	}
 
 	if err := u.service.Delete(ctx, dto.DeleteUserRequest{
 		ID: pgUUID,
 	}); err != nil {
 		log.Error("UserService.Delete failed", "err", err)
-		return fault.HTTPError(w, fault.UnhandledError.Err())
+		return fault.HTTPError(w, r, serviceFault(err, fault.UnhandledError))
 	}
 
 	render.Status(r, http.StatusOK)
 	return nil
 }
 
+// DeactivateUser blocks sign-in and hides the user from reads without deleting any data.
+func (u *UserHandler) DeactivateUser(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	traceID := middleware.GetTraceID(ctx)
+
+	log := u.log.With(slog.String("trace_id", traceID))
+	log.Info("DeactivateUser handler started")
+
+	defer r.Body.Close()
+
+	userID, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+
+	user, err := u.service.Deactivate(ctx, dto.DeactivateUserRequest{
+		ID: userID,
+	})
+	if err != nil {
+		log.Error("UserService.Deactivate failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, fault.UnhandledError))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, user)
+	return nil
+}
+
+// ReactivateUser undoes DeactivateUser.
+func (u *UserHandler) ReactivateUser(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	traceID := middleware.GetTraceID(ctx)
+
+	log := u.log.With(slog.String("trace_id", traceID))
+	log.Info("ReactivateUser handler started")
+
+	defer r.Body.Close()
+
+	userID, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+
+	user, err := u.service.Reactivate(ctx, dto.ReactivateUserRequest{
+		ID: userID,
+	})
+	if err != nil {
+		log.Error("UserService.Reactivate failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, fault.UnhandledError))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, user)
+	return nil
+}
+
+// RestoreUser undoes DeleteUser while the restore period has not expired.
+func (u *UserHandler) RestoreUser(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	traceID := middleware.GetTraceID(ctx)
+
+	log := u.log.With(slog.String("trace_id", traceID))
+	log.Info("RestoreUser handler started")
+
+	defer r.Body.Close()
+
+	userID, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+
+	user, err := u.service.Restore(ctx, dto.RestoreUserRequest{
+		ID: userID,
+	})
+	if err != nil {
+		log.Error("UserService.Restore failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, fault.UnhandledError))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, user)
+	return nil
+}
+
 func (u *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) error {
 	ctx := r.Context()
 	traceID := middleware.GetTraceID(ctx)
@@ -178,28 +324,28 @@ func (u *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) error {
 	var req dto.UpdateUserRequest
 	if err := render.DecodeJSON(r.Body, &req); err != nil {
 		log.Error("DecodeJSON failed", "err", err)
-		return fault.HTTPError(w, errorsPkg.DecodeFailed.Err())
+		return fault.HTTPError(w, r, errorsPkg.DecodeFailed.Err())
 	}
 
 	if err := u.validate.Struct(req); err != nil {
 		log.Error("validation failed", "err", err)
-		return fault.HTTPError(w, errorsPkg.ValidationError.Err())
+		return fault.HTTPError(w, r, validationFault(err))
 	}
 
 	userIDStr := chi.URLParam(r, "id")
 	if userIDStr == "" {
 		log.Error("missing user id in query parameters")
-		return fault.HTTPError(w, errorsPkg.ValidationError.Err())
+		return fault.HTTPError(w, r, errorsPkg.ValidationError.Err())
 	}
 
 	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
-		return fault.HTTPError(w, errorsPkg.UUIDParsingFailed.Err())
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
 	}
 
 	pgUUID, err := uuidconv.SetPgUUID(userID)
 	if err != nil {
-		return fault.HTTPError(w, errorsPkg.UUIDParsingFailed.Err())
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
 	}
 
 	user, err := u.service.Update(ctx, dto.UpdateUserRequest{
@@ -207,7 +353,7 @@ func (u *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) error {
 	})
 	if err != nil {
 		log.Error("UserService.Update failed", "err", err)
-		return fault.HTTPError(w, fault.UnhandledError.Err())
+		return fault.HTTPError(w, r, serviceFault(err, fault.UnhandledError))
 	}
 
 	render.Status(r, http.StatusOK)
@@ -0,0 +1,267 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"test-http/internal/dto"
+	"test-http/internal/middleware"
+	"test-http/internal/service"
+	errorsPkg "test-http/pkg/errors_pkg"
+	"test-http/pkg/fault"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+// UserProgressHandler serves /users/{id}/progress; the owner always comes from the URL.
+type UserProgressHandler struct {
+	logger   *slog.Logger
+	validate *validator.Validate
+	service  *service.UserProgressService
+}
+
+func NewUserProgressHandler(service *service.UserProgressService, validate *validator.Validate, logger *slog.Logger) *UserProgressHandler {
+	return &UserProgressHandler{
+		logger:   logger,
+		validate: validate,
+		service:  service,
+	}
+}
+
+func (h *UserProgressHandler) CreateProgress(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("CreateProgress handler called")
+
+	defer r.Body.Close()
+
+	userID, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+
+	var req dto.CreateUserProgressRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("DecodeJSON failed", "err", err)
+		return fault.HTTPError(w, r, errorsPkg.DecodeFailed.Err())
+	}
+	req.UserID = userID
+
+	if err := h.validate.Struct(req); err != nil {
+		log.Error("validation failed", "err", err)
+		return fault.HTTPError(w, r, validationFault(err))
+	}
+
+	progress, err := h.service.Create(ctx, req)
+	if err != nil {
+		log.Error("UserProgressService.Create failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextCreatingUserProgressMissing))
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, progress)
+	return nil
+}
+
+func (h *UserProgressHandler) GetProgress(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("GetProgress handler called")
+
+	defer r.Body.Close()
+
+	userID, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+	progressID, err := uuidURLParam(r, "progress_id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+
+	progress, err := h.service.GetByID(ctx, dto.GetUserProgressRequest{ID: progressID, UserID: userID})
+	if err != nil {
+		log.Error("UserProgressService.GetByID failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextGettingUserProgressMissing))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, progress)
+	return nil
+}
+
+func (h *UserProgressHandler) GetWordProgress(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("GetWordProgress handler called")
+
+	defer r.Body.Close()
+
+	userID, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+	wordID, err := uuidURLParam(r, "word_id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+
+	progress, err := h.service.GetByUserAndWord(ctx, dto.GetUserProgressByUserAndWordRequest{UserID: userID, WordID: wordID})
+	if err != nil {
+		log.Error("UserProgressService.GetByUserAndWord failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextGettingUserProgressMissing))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, progress)
+	return nil
+}
+
+// ListProgress pages through the user's progress; follow links.next/prev or pass ?cursor=.
+func (h *UserProgressHandler) ListProgress(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("ListProgress handler called")
+
+	defer r.Body.Close()
+
+	userID, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+
+	req := dto.ListUserProgressRequest{
+		UserID: userID,
+		Cursor: r.URL.Query().Get("cursor"),
+	}
+	if req.Limit, err = int32QueryParam(r, "limit"); err != nil {
+		return fault.HTTPError(w, r, errorsPkg.QueryParamInvalid.Err(&fault.Arg{K: "param", V: "limit"}))
+	}
+	if req.Offset, err = int32QueryParam(r, "offset"); err != nil {
+		return fault.HTTPError(w, r, errorsPkg.QueryParamInvalid.Err(&fault.Arg{K: "param", V: "offset"}))
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		log.Error("validation failed", "err", err)
+		return fault.HTTPError(w, r, validationFault(err))
+	}
+
+	page, err := h.service.List(ctx, req)
+	if err != nil {
+		log.Error("UserProgressService.List failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextGettingUserProgressMissing))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, withPageLinks(r, page))
+	return nil
+}
+
+func (h *UserProgressHandler) UpdateProgress(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("UpdateProgress handler called")
+
+	defer r.Body.Close()
+
+	userID, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+	progressID, err := uuidURLParam(r, "progress_id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+
+	var req dto.UpdateUserProgressRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("DecodeJSON failed", "err", err)
+		return fault.HTTPError(w, r, errorsPkg.DecodeFailed.Err())
+	}
+	req.ID = progressID
+	req.UserID = userID
+
+	if err := h.validate.Struct(req); err != nil {
+		log.Error("validation failed", "err", err)
+		return fault.HTTPError(w, r, validationFault(err))
+	}
+
+	progress, err := h.service.Update(ctx, req)
+	if err != nil {
+		log.Error("UserProgressService.Update failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextUpdatingUserProgressMissing))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, progress)
+	return nil
+}
+
+// RecordAttempts applies a batch of answers; the whole batch succeeds or fails together.
+func (h *UserProgressHandler) RecordAttempts(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("RecordAttempts handler called")
+
+	defer r.Body.Close()
+
+	userID, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+
+	var req dto.RecordProgressAttemptsRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("DecodeJSON failed", "err", err)
+		return fault.HTTPError(w, r, errorsPkg.DecodeFailed.Err())
+	}
+	req.UserID = userID
+
+	if err := h.validate.Struct(req); err != nil {
+		log.Error("validation failed", "err", err)
+		return fault.HTTPError(w, r, validationFault(err))
+	}
+
+	progress, err := h.service.RecordAttempts(ctx, req)
+	if err != nil {
+		log.Error("UserProgressService.RecordAttempts failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextUpdatingUserProgressMissing))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, progress)
+	return nil
+}
+
+func (h *UserProgressHandler) DeleteProgress(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("DeleteProgress handler called")
+
+	defer r.Body.Close()
+
+	userID, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+	progressID, err := uuidURLParam(r, "progress_id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+
+	if err := h.service.Delete(ctx, dto.DeleteUserProgressRequest{ID: progressID, UserID: userID}); err != nil {
+		log.Error("UserProgressService.Delete failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextDeletingUserProgressMissing))
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
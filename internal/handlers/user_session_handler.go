@@ -0,0 +1,316 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"test-http/internal/dto"
+	"test-http/internal/middleware"
+	"test-http/internal/service"
+	errorsPkg "test-http/pkg/errors_pkg"
+	"test-http/pkg/fault"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+// UserSessionHandler serves /users/{id}/sessions; the owner always comes from the URL.
+type UserSessionHandler struct {
+	logger   *slog.Logger
+	validate *validator.Validate
+	service  *service.UserSessionService
+}
+
+func NewUserSessionHandler(service *service.UserSessionService, validate *validator.Validate, logger *slog.Logger) *UserSessionHandler {
+	return &UserSessionHandler{
+		logger:   logger,
+		validate: validate,
+		service:  service,
+	}
+}
+
+func (h *UserSessionHandler) CreateSession(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("CreateSession handler called")
+
+	defer r.Body.Close()
+
+	userID, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+
+	var req dto.CreateUserSessionRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("DecodeJSON failed", "err", err)
+		return fault.HTTPError(w, r, errorsPkg.DecodeFailed.Err())
+	}
+	req.UserID = userID
+
+	if err := h.validate.Struct(req); err != nil {
+		log.Error("validation failed", "err", err)
+		return fault.HTTPError(w, r, validationFault(err))
+	}
+
+	session, err := h.service.Create(ctx, req)
+	if err != nil {
+		log.Error("UserSessionService.Create failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextCreatingUserSessionMissing))
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, session)
+	return nil
+}
+
+func (h *UserSessionHandler) GetSession(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("GetSession handler called")
+
+	defer r.Body.Close()
+
+	userID, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+	sessionID, err := uuidURLParam(r, "session_id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+
+	session, err := h.service.GetByID(ctx, dto.GetUserSessionRequest{ID: sessionID, UserID: userID})
+	if err != nil {
+		log.Error("UserSessionService.GetByID failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextGettingUserSessionMissing))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, session)
+	return nil
+}
+
+// ListSessions pages through the user's sessions, newest first, optionally filtered by ?status=.
+// Follow links.next/prev or pass ?cursor=; ?offset= still works for older clients.
+func (h *UserSessionHandler) ListSessions(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("ListSessions handler called")
+
+	defer r.Body.Close()
+
+	userID, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+
+	req := dto.ListUserSessionsRequest{
+		UserID: userID,
+		Status: r.URL.Query().Get("status"),
+		Cursor: r.URL.Query().Get("cursor"),
+	}
+	if req.Limit, err = int32QueryParam(r, "limit"); err != nil {
+		return fault.HTTPError(w, r, errorsPkg.QueryParamInvalid.Err(&fault.Arg{K: "param", V: "limit"}))
+	}
+	if req.Offset, err = int32QueryParam(r, "offset"); err != nil {
+		return fault.HTTPError(w, r, errorsPkg.QueryParamInvalid.Err(&fault.Arg{K: "param", V: "offset"}))
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		log.Error("validation failed", "err", err)
+		return fault.HTTPError(w, r, validationFault(err))
+	}
+
+	page, err := h.service.List(ctx, req)
+	if err != nil {
+		log.Error("UserSessionService.List failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextGettingUserSessionMissing))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, withPageLinks(r, page))
+	return nil
+}
+
+func (h *UserSessionHandler) ListActiveSessions(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("ListActiveSessions handler called")
+
+	defer r.Body.Close()
+
+	userID, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+
+	req := dto.ListActiveUserSessionsRequest{UserID: userID}
+	if req.Limit, err = int32QueryParam(r, "limit"); err != nil {
+		return fault.HTTPError(w, r, errorsPkg.QueryParamInvalid.Err(&fault.Arg{K: "param", V: "limit"}))
+	}
+	if req.Offset, err = int32QueryParam(r, "offset"); err != nil {
+		return fault.HTTPError(w, r, errorsPkg.QueryParamInvalid.Err(&fault.Arg{K: "param", V: "offset"}))
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		log.Error("validation failed", "err", err)
+		return fault.HTTPError(w, r, validationFault(err))
+	}
+
+	sessions, err := h.service.ListActive(ctx, req)
+	if err != nil {
+		log.Error("UserSessionService.ListActive failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextGettingUserSessionMissing))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, sessions)
+	return nil
+}
+
+func (h *UserSessionHandler) UpdateSession(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("UpdateSession handler called")
+
+	defer r.Body.Close()
+
+	userID, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+	sessionID, err := uuidURLParam(r, "session_id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+
+	var req dto.UpdateUserSessionRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("DecodeJSON failed", "err", err)
+		return fault.HTTPError(w, r, errorsPkg.DecodeFailed.Err())
+	}
+	req.ID = sessionID
+	req.UserID = userID
+
+	if err := h.validate.Struct(req); err != nil {
+		log.Error("validation failed", "err", err)
+		return fault.HTTPError(w, r, validationFault(err))
+	}
+
+	session, err := h.service.Update(ctx, req)
+	if err != nil {
+		log.Error("UserSessionService.Update failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextUpdatingUserSessionMissing))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, session)
+	return nil
+}
+
+// RecordEvent reports a pause, resume or heartbeat; the kind comes from the body.
+func (h *UserSessionHandler) RecordEvent(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("RecordEvent handler called")
+
+	defer r.Body.Close()
+
+	userID, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+	sessionID, err := uuidURLParam(r, "session_id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+
+	var req dto.RecordSessionEventRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("DecodeJSON failed", "err", err)
+		return fault.HTTPError(w, r, errorsPkg.DecodeFailed.Err())
+	}
+	req.ID = sessionID
+	req.UserID = userID
+
+	if err := h.validate.Struct(req); err != nil {
+		log.Error("validation failed", "err", err)
+		return fault.HTTPError(w, r, validationFault(err))
+	}
+
+	session, err := h.service.RecordEvent(ctx, req)
+	if err != nil {
+		log.Error("UserSessionService.RecordEvent failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextUpdatingUserSessionMissing))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, session)
+	return nil
+}
+
+// Heartbeat keeps an active session alive; clients send one periodically while the learner is on task.
+func (h *UserSessionHandler) Heartbeat(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("Heartbeat handler called")
+
+	defer r.Body.Close()
+
+	userID, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+	sessionID, err := uuidURLParam(r, "session_id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+
+	session, err := h.service.RecordEvent(ctx, dto.RecordSessionEventRequest{
+		ID:     sessionID,
+		UserID: userID,
+		Kind:   service.SessionEventHeartbeat,
+	})
+	if err != nil {
+		log.Error("UserSessionService.RecordEvent failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextUpdatingUserSessionMissing))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, session)
+	return nil
+}
+
+func (h *UserSessionHandler) DeleteSession(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("DeleteSession handler called")
+
+	defer r.Body.Close()
+
+	userID, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+	sessionID, err := uuidURLParam(r, "session_id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+
+	if err := h.service.Delete(ctx, dto.DeleteUserSessionRequest{ID: sessionID, UserID: userID}); err != nil {
+		log.Error("UserSessionService.Delete failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextDeletingUserSessionMissing))
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"test-http/internal/dto"
+	"test-http/internal/middleware"
+	"test-http/internal/service"
+	errorsPkg "test-http/pkg/errors_pkg"
+	"test-http/pkg/fault"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+// UserWordSetHandler serves /users/{id}/word-sets, the user's subscriptions to catalog word sets.
+type UserWordSetHandler struct {
+	logger   *slog.Logger
+	validate *validator.Validate
+	service  *service.UserWordSetService
+}
+
+func NewUserWordSetHandler(service *service.UserWordSetService, validate *validator.Validate, logger *slog.Logger) *UserWordSetHandler {
+	return &UserWordSetHandler{
+		logger:   logger,
+		validate: validate,
+		service:  service,
+	}
+}
+
+func (h *UserWordSetHandler) Subscribe(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("Subscribe handler called")
+
+	defer r.Body.Close()
+
+	userID, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+
+	var req dto.CreateUserWordSetRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("DecodeJSON failed", "err", err)
+		return fault.HTTPError(w, r, errorsPkg.DecodeFailed.Err())
+	}
+	req.UserID = userID
+
+	if err := h.validate.Struct(req); err != nil {
+		log.Error("validation failed", "err", err)
+		return fault.HTTPError(w, r, validationFault(err))
+	}
+
+	subscription, err := h.service.Create(ctx, req)
+	if err != nil {
+		log.Error("UserWordSetService.Create failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextCreatingUserWordSetMissing))
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, subscription)
+	return nil
+}
+
+func (h *UserWordSetHandler) GetSubscription(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("GetSubscription handler called")
+
+	defer r.Body.Close()
+
+	userID, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+	subscriptionID, err := uuidURLParam(r, "subscription_id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+
+	subscription, err := h.service.GetByID(ctx, dto.GetUserWordSetRequest{ID: subscriptionID, UserID: userID})
+	if err != nil {
+		log.Error("UserWordSetService.GetByID failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextGettingUserWordSetMissing))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, subscription)
+	return nil
+}
+
+func (h *UserWordSetHandler) ListSubscriptions(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("ListSubscriptions handler called")
+
+	defer r.Body.Close()
+
+	userID, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+
+	req := dto.ListUserWordSetsRequest{UserID: userID}
+	if req.Limit, err = int32QueryParam(r, "limit"); err != nil {
+		return fault.HTTPError(w, r, errorsPkg.QueryParamInvalid.Err(&fault.Arg{K: "param", V: "limit"}))
+	}
+	if req.Offset, err = int32QueryParam(r, "offset"); err != nil {
+		return fault.HTTPError(w, r, errorsPkg.QueryParamInvalid.Err(&fault.Arg{K: "param", V: "offset"}))
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		log.Error("validation failed", "err", err)
+		return fault.HTTPError(w, r, validationFault(err))
+	}
+
+	subscriptions, err := h.service.List(ctx, req)
+	if err != nil {
+		log.Error("UserWordSetService.List failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextGettingUserWordSetMissing))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, subscriptions)
+	return nil
+}
+
+// UpdateSubscription re-points an existing subscription at another catalog word set.
+func (h *UserWordSetHandler) UpdateSubscription(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("UpdateSubscription handler called")
+
+	defer r.Body.Close()
+
+	userID, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+	subscriptionID, err := uuidURLParam(r, "subscription_id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+
+	var req dto.UpdateUserWordSetRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("DecodeJSON failed", "err", err)
+		return fault.HTTPError(w, r, errorsPkg.DecodeFailed.Err())
+	}
+	req.ID = subscriptionID
+	req.UserID = userID
+
+	if err := h.validate.Struct(req); err != nil {
+		log.Error("validation failed", "err", err)
+		return fault.HTTPError(w, r, validationFault(err))
+	}
+
+	subscription, err := h.service.Update(ctx, req)
+	if err != nil {
+		log.Error("UserWordSetService.Update failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextUpdatingUserWordSetMissing))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, subscription)
+	return nil
+}
+
+func (h *UserWordSetHandler) Unsubscribe(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("Unsubscribe handler called")
+
+	defer r.Body.Close()
+
+	userID, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+	subscriptionID, err := uuidURLParam(r, "subscription_id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+
+	if err := h.service.Delete(ctx, dto.DeleteUserWordSetRequest{ID: subscriptionID, UserID: userID}); err != nil {
+		log.Error("UserWordSetService.Delete failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextDeletingUserWordSetMissing))
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// NewValidator returns a validator that understands pgtype.UUID: an invalid (NULL) UUID
+// fails "required", a valid one is validated as its string form, so "required,uuid" works on DTOs.
+// Field errors are reported under the JSON name the client sent.
+func NewValidator() *validator.Validate {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			return field.Name
+		}
+		return name
+	})
+	validate.RegisterCustomTypeFunc(func(field reflect.Value) any {
+		id, ok := field.Interface().(pgtype.UUID)
+		if !ok || !id.Valid {
+			return nil
+		}
+		return id.String()
+	}, pgtype.UUID{})
+	return validate
+}
@@ -1 +1,219 @@
 package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"test-http/internal/dto"
+	"test-http/internal/middleware"
+	"test-http/internal/service"
+	errorsPkg "test-http/pkg/errors_pkg"
+	"test-http/pkg/fault"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+)
+
+type WordHandler struct {
+	logger   *slog.Logger
+	validate *validator.Validate
+	service  *service.WordService
+}
+
+func NewWordHandler(service *service.WordService, validate *validator.Validate, logger *slog.Logger) *WordHandler {
+	return &WordHandler{
+		logger:   logger,
+		validate: validate,
+		service:  service,
+	}
+}
+
+func (h *WordHandler) CreateWord(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("CreateWord handler called")
+
+	defer r.Body.Close()
+
+	var req dto.CreateWordRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("DecodeJSON failed", "err", err)
+		return fault.HTTPError(w, r, errorsPkg.DecodeFailed.Err())
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		log.Error("validation failed", "err", err)
+		return fault.HTTPError(w, r, validationFault(err))
+	}
+
+	word, err := h.service.Create(ctx, req)
+	if err != nil {
+		log.Error("WordService.Create failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextCreatingWordMissing))
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, word)
+	return nil
+}
+
+func (h *WordHandler) GetWord(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("GetWord handler called")
+
+	defer r.Body.Close()
+
+	id, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+
+	word, err := h.service.GetByID(ctx, dto.GetWordRequest{ID: id})
+	if err != nil {
+		log.Error("WordService.GetByID failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextGettingWordMissing))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, word)
+	return nil
+}
+
+func (h *WordHandler) SearchWords(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("SearchWords handler called")
+
+	defer r.Body.Close()
+
+	query := r.URL.Query()
+	req := dto.SearchWordsRequest{
+		Query:        query.Get("q"),
+		SourceLang:   query.Get("source_lang"),
+		TargetLang:   query.Get("target_lang"),
+		PartOfSpeech: query.Get("part_of_speech"),
+	}
+
+	var err error
+	if req.Limit, err = int32QueryParam(r, "limit"); err != nil {
+		return fault.HTTPError(w, r, errorsPkg.QueryParamInvalid.Err(&fault.Arg{K: "param", V: "limit"}))
+	}
+	if req.Offset, err = int32QueryParam(r, "offset"); err != nil {
+		return fault.HTTPError(w, r, errorsPkg.QueryParamInvalid.Err(&fault.Arg{K: "param", V: "offset"}))
+	}
+	if raw := query.Get("difficulty"); raw != "" {
+		difficulty, err := strconv.ParseInt(raw, 10, 16)
+		if err != nil {
+			return fault.HTTPError(w, r, errorsPkg.QueryParamInvalid.Err(&fault.Arg{K: "param", V: "difficulty"}))
+		}
+		req.Difficulty = int16(difficulty)
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		log.Error("validation failed", "err", err)
+		return fault.HTTPError(w, r, validationFault(err))
+	}
+
+	words, err := h.service.Search(ctx, req)
+	if err != nil {
+		log.Error("WordService.Search failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextSearchingWordsMissing))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, words)
+	return nil
+}
+
+func (h *WordHandler) ResolveWords(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("ResolveWords handler called")
+
+	defer r.Body.Close()
+
+	var req dto.ResolveWordsRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("DecodeJSON failed", "err", err)
+		return fault.HTTPError(w, r, errorsPkg.DecodeFailed.Err())
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		log.Error("validation failed", "err", err)
+		return fault.HTTPError(w, r, validationFault(err))
+	}
+
+	words, err := h.service.Resolve(ctx, req)
+	if err != nil {
+		log.Error("WordService.Resolve failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextGettingWordMissing))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, words)
+	return nil
+}
+
+func (h *WordHandler) UpdateWord(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("UpdateWord handler called")
+
+	defer r.Body.Close()
+
+	var req dto.UpdateWordRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("DecodeJSON failed", "err", err)
+		return fault.HTTPError(w, r, errorsPkg.DecodeFailed.Err())
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		log.Error("validation failed", "err", err)
+		return fault.HTTPError(w, r, validationFault(err))
+	}
+
+	id, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+	req.ID = id
+
+	word, err := h.service.Update(ctx, req)
+	if err != nil {
+		log.Error("WordService.Update failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextUpdatingWordMissing))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, word)
+	return nil
+}
+
+func (h *WordHandler) DeleteWord(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("DeleteWord handler called")
+
+	defer r.Body.Close()
+
+	id, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+
+	if err := h.service.Delete(ctx, dto.DeleteWordRequest{ID: id}); err != nil {
+		log.Error("WordService.Delete failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextDeletingWordMissing))
+	}
+
+	render.Status(r, http.StatusOK)
+	return nil
+}
@@ -1 +1,323 @@
 package handlers
+
+import (
+	"log/slog"
+	"net/http"
+
+	"test-http/internal/dto"
+	"test-http/internal/middleware"
+	"test-http/internal/service"
+	errorsPkg "test-http/pkg/errors_pkg"
+	"test-http/pkg/fault"
+	"test-http/pkg/uuidconv"
+
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type WordSetHandler struct {
+	logger   *slog.Logger
+	validate *validator.Validate
+	service  *service.WordSetService
+}
+
+func NewWordSetHandler(service *service.WordSetService, validate *validator.Validate, logger *slog.Logger) *WordSetHandler {
+	return &WordSetHandler{
+		logger:   logger,
+		validate: validate,
+		service:  service,
+	}
+}
+
+func (h *WordSetHandler) CreateWordSet(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("CreateWordSet handler called")
+
+	defer r.Body.Close()
+
+	var req dto.CreateWordSetRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("DecodeJSON failed", "err", err)
+		return fault.HTTPError(w, r, errorsPkg.DecodeFailed.Err())
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		log.Error("validation failed", "err", err)
+		return fault.HTTPError(w, r, validationFault(err))
+	}
+
+	wordSet, err := h.service.Create(ctx, req)
+	if err != nil {
+		log.Error("WordSetService.Create failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextCreatingWordSetMissing))
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, wordSet)
+	return nil
+}
+
+func (h *WordSetHandler) GetWordSet(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("GetWordSet handler called")
+
+	defer r.Body.Close()
+
+	id, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+
+	wordSet, err := h.service.GetByID(ctx, dto.GetWordSetRequest{ID: id})
+	if err != nil {
+		log.Error("WordSetService.GetByID failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextGettingWordSetMissing))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, wordSet)
+	return nil
+}
+
+func (h *WordSetHandler) ListWordSets(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("ListWordSets handler called")
+
+	defer r.Body.Close()
+
+	req := dto.ListWordSetsRequest{
+		Language: r.URL.Query().Get("language"),
+	}
+
+	if raw := r.URL.Query().Get("owner_id"); raw != "" {
+		ownerID, err := uuid.Parse(raw)
+		if err != nil {
+			return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+		}
+		if req.OwnerID, err = uuidconv.SetPgUUID(ownerID); err != nil {
+			return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+		}
+	}
+
+	var err error
+	if req.Limit, err = int32QueryParam(r, "limit"); err != nil {
+		return fault.HTTPError(w, r, errorsPkg.QueryParamInvalid.Err(&fault.Arg{K: "param", V: "limit"}))
+	}
+	if req.Offset, err = int32QueryParam(r, "offset"); err != nil {
+		return fault.HTTPError(w, r, errorsPkg.QueryParamInvalid.Err(&fault.Arg{K: "param", V: "offset"}))
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		log.Error("validation failed", "err", err)
+		return fault.HTTPError(w, r, validationFault(err))
+	}
+
+	wordSets, err := h.service.List(ctx, req)
+	if err != nil {
+		log.Error("WordSetService.List failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextGettingWordSetMissing))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, wordSets)
+	return nil
+}
+
+func (h *WordSetHandler) UpdateWordSet(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("UpdateWordSet handler called")
+
+	defer r.Body.Close()
+
+	var req dto.UpdateWordSetRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("DecodeJSON failed", "err", err)
+		return fault.HTTPError(w, r, errorsPkg.DecodeFailed.Err())
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		log.Error("validation failed", "err", err)
+		return fault.HTTPError(w, r, validationFault(err))
+	}
+
+	id, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+	req.ID = id
+
+	wordSet, err := h.service.Update(ctx, req)
+	if err != nil {
+		log.Error("WordSetService.Update failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextUpdatingWordSetMissing))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, wordSet)
+	return nil
+}
+
+func (h *WordSetHandler) DeleteWordSet(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("DeleteWordSet handler called")
+
+	defer r.Body.Close()
+
+	id, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+
+	if err := h.service.Delete(ctx, dto.DeleteWordSetRequest{ID: id}); err != nil {
+		log.Error("WordSetService.Delete failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextDeletingWordSetMissing))
+	}
+
+	render.Status(r, http.StatusOK)
+	return nil
+}
+
+func (h *WordSetHandler) ListWords(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("ListWords handler called")
+
+	defer r.Body.Close()
+
+	id, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+
+	req := dto.ListWordSetItemsRequest{WordSetID: id}
+	if req.Limit, err = int32QueryParam(r, "limit"); err != nil {
+		return fault.HTTPError(w, r, errorsPkg.QueryParamInvalid.Err(&fault.Arg{K: "param", V: "limit"}))
+	}
+	if req.Offset, err = int32QueryParam(r, "offset"); err != nil {
+		return fault.HTTPError(w, r, errorsPkg.QueryParamInvalid.Err(&fault.Arg{K: "param", V: "offset"}))
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		log.Error("validation failed", "err", err)
+		return fault.HTTPError(w, r, validationFault(err))
+	}
+
+	page, err := h.service.ListItems(ctx, req)
+	if err != nil {
+		log.Error("WordSetService.ListItems failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextGettingWordSetMissing))
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, page)
+	return nil
+}
+
+func (h *WordSetHandler) AddWord(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("AddWord handler called")
+
+	defer r.Body.Close()
+
+	var req dto.AddWordSetItemRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("DecodeJSON failed", "err", err)
+		return fault.HTTPError(w, r, errorsPkg.DecodeFailed.Err())
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		log.Error("validation failed", "err", err)
+		return fault.HTTPError(w, r, validationFault(err))
+	}
+
+	id, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+	req.WordSetID = id
+
+	item, err := h.service.AddWord(ctx, req)
+	if err != nil {
+		log.Error("WordSetService.AddWord failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextUpdatingWordSetItemsMissing))
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, item)
+	return nil
+}
+
+func (h *WordSetHandler) RemoveWord(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("RemoveWord handler called")
+
+	defer r.Body.Close()
+
+	id, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+
+	wordID, err := uuidURLParam(r, "word_id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+
+	if err := h.service.RemoveWord(ctx, dto.RemoveWordSetItemRequest{WordSetID: id, WordID: wordID}); err != nil {
+		log.Error("WordSetService.RemoveWord failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextUpdatingWordSetItemsMissing))
+	}
+
+	render.Status(r, http.StatusOK)
+	return nil
+}
+
+func (h *WordSetHandler) ReorderWords(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	log := h.logger.With(slog.String("trace_id", middleware.GetTraceID(ctx)))
+
+	log.Info("ReorderWords handler called")
+
+	defer r.Body.Close()
+
+	var req dto.ReorderWordSetItemsRequest
+	if err := render.DecodeJSON(r.Body, &req); err != nil {
+		log.Error("DecodeJSON failed", "err", err)
+		return fault.HTTPError(w, r, errorsPkg.DecodeFailed.Err())
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		log.Error("validation failed", "err", err)
+		return fault.HTTPError(w, r, validationFault(err))
+	}
+
+	id, err := uuidURLParam(r, "id")
+	if err != nil {
+		return fault.HTTPError(w, r, errorsPkg.UUIDParsingFailed.Err())
+	}
+	req.WordSetID = id
+
+	if err := h.service.Reorder(ctx, req); err != nil {
+		log.Error("WordSetService.Reorder failed", "err", err)
+		return fault.HTTPError(w, r, serviceFault(err, errorsPkg.ContextUpdatingWordSetItemsMissing))
+	}
+
+	render.Status(r, http.StatusOK)
+	return nil
+}
@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"test-http/internal/authz"
+	errorsPkg "test-http/pkg/errors_pkg"
+	"test-http/pkg/fault"
+	"test-http/pkg/jwt"
+	"test-http/pkg/uuidconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// TokenVerifier validates access tokens; *jwt.Signer satisfies it.
+type TokenVerifier interface {
+	Verify(token string) (jwt.Claims, error)
+}
+
+// AccountChecker confirms that the subject of a valid token may still use the API
+// and returns the role the account has now; *service.AuthService satisfies it.
+type AccountChecker interface {
+	CheckActive(ctx context.Context, userID pgtype.UUID) (string, error)
+}
+
+// Authenticate rejects requests without a valid "Authorization: Bearer" access
+// token, or whose user has since been deactivated or deleted, and stores the
+// caller as an authz.Principal in the request context. The principal carries the
+// account's current role rather than the one in the token, so a demoted admin
+// loses admin routes at once.
+func Authenticate(verifier TokenVerifier, accounts AccountChecker, log *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+			if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+				writeFault(w, r, errorsPkg.Unauthorized)
+				return
+			}
+
+			claims, err := verifier.Verify(token)
+			if err != nil {
+				log.Info("access token rejected",
+					slog.String("trace_id", GetTraceID(r.Context())),
+					slog.String("reason", err.Error()),
+				)
+				writeFault(w, r, errorsPkg.Unauthorized)
+				return
+			}
+
+			id, err := uuid.Parse(claims.Subject)
+			if err != nil {
+				writeFault(w, r, errorsPkg.Unauthorized)
+				return
+			}
+			userID, _ := uuidconv.SetPgUUID(id)
+
+			role, err := accounts.CheckActive(r.Context(), userID)
+			if err != nil {
+				log.Info("access token of an unavailable account rejected",
+					slog.String("trace_id", GetTraceID(r.Context())),
+					slog.String("user_id", userID.String()),
+					slog.String("reason", err.Error()),
+				)
+				writeFault(w, r, fault.Code(fault.HandleErr(err).Code))
+				return
+			}
+
+			ctx := authz.WithPrincipal(r.Context(), authz.Principal{UserID: userID, Role: role})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RequireSelf only lets the request through when the user ID in the named URL
+// parameter belongs to the caller, or the caller is an admin.
+func RequireSelf(param string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id, err := uuid.Parse(chi.URLParam(r, param))
+			if err != nil {
+				writeFault(w, r, errorsPkg.UUIDParsingFailed)
+				return
+			}
+			ownerID, _ := uuidconv.SetPgUUID(id)
+
+			if err := authz.RequireOwner(r.Context(), ownerID); err != nil {
+				writeFault(w, r, errorsPkg.Forbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireAdmin only lets admins through.
+func RequireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := authz.RequireAdmin(r.Context()); err != nil {
+			writeFault(w, r, errorsPkg.Forbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func writeFault(w http.ResponseWriter, r *http.Request, code fault.Code) {
+	if fault.Status(code) == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+	}
+	_ = fault.HTTPError(w, r, code.Err())
+}
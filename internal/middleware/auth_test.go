@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"test-http/internal/authz"
+	errorsPkg "test-http/pkg/errors_pkg"
+	"test-http/pkg/jwt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// accountsFunc adapts a function to AccountChecker.
+type accountsFunc func(ctx context.Context, userID pgtype.UUID) (string, error)
+
+func (f accountsFunc) CheckActive(ctx context.Context, userID pgtype.UUID) (string, error) {
+	return f(ctx, userID)
+}
+
+func TestAuthenticate_RejectsUnavailableAccount(t *testing.T) {
+	signer := jwt.NewSigner([]byte("0123456789abcdef0123456789abcdef"), "test-http", 15*time.Minute)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	active := uuid.New()
+	deactivated := uuid.New()
+
+	accounts := accountsFunc(func(_ context.Context, userID pgtype.UUID) (string, error) {
+		if userID.Bytes == deactivated {
+			return "", errorsPkg.Unauthorized.Err()
+		}
+		return authz.RoleUser, nil
+	})
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := authz.FromContext(r.Context()); !ok {
+			t.Error("principal missing from the request context")
+		}
+	})
+	handler := Authenticate(signer, accounts, logger)(next)
+
+	tests := []struct {
+		name   string
+		userID uuid.UUID
+		want   int
+	}{
+		{"active", active, http.StatusOK},
+		{"deactivated", deactivated, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, _, err := signer.Issue(tt.userID.String(), authz.RoleUser)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/words", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthenticate_UsesCurrentRole(t *testing.T) {
+	signer := jwt.NewSigner([]byte("0123456789abcdef0123456789abcdef"), "test-http", 15*time.Minute)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+
+	// The token was issued while the user was an admin; the account has been demoted since.
+	demoted := accountsFunc(func(context.Context, pgtype.UUID) (string, error) {
+		return authz.RoleUser, nil
+	})
+	handler := Authenticate(signer, demoted, logger)(RequireAdmin(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		t.Error("a demoted admin reached an admin route")
+	})))
+
+	token, _, err := signer.Issue(uuid.NewString(), authz.RoleAdmin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/imports", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+}
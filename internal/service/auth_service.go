@@ -0,0 +1,288 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"test-http/internal/db"
+	"test-http/internal/dto"
+	errorsPkg "test-http/pkg/errors_pkg"
+	"test-http/pkg/helper"
+	"test-http/pkg/jwt"
+	"test-http/pkg/uuidconv"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const TokenTypeBearer = "Bearer"
+
+// refreshTokenBytes is the entropy of an opaque refresh token.
+const refreshTokenBytes = 32
+
+var (
+	dummyHashOnce sync.Once
+	dummyHash     []byte
+)
+
+// compareDummyHash spends the same bcrypt work as a real login so unknown
+// emails cannot be told apart by response time.
+func compareDummyHash(password string) {
+	dummyHashOnce.Do(func() {
+		dummyHash, _ = bcrypt.GenerateFromPassword([]byte("dummy-password"), bcrypt.DefaultCost)
+	})
+	_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+}
+
+type AuthService struct {
+	authRepo   db.AuthRepo
+	signer     *jwt.Signer
+	refreshTTL time.Duration
+	logger     *slog.Logger
+}
+
+func NewAuthService(authRepo db.AuthRepo, signer *jwt.Signer, refreshTTL time.Duration, log *slog.Logger) *AuthService {
+	return &AuthService{
+		authRepo:   authRepo,
+		signer:     signer,
+		refreshTTL: refreshTTL,
+		logger:     log,
+	}
+}
+
+func (a *AuthService) Register(ctx context.Context, request dto.RegisterRequest) (dto.RegisterResponse, error) {
+	helper.LogDebug(ctx, a.logger, "AuthService.Register", "registering user",
+		slog.String("email", request.Email),
+	)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(request.Password), bcrypt.DefaultCost)
+	if err != nil {
+		helper.LogError(ctx, a.logger, "AuthService.Register", "GenerateFromPassword", "failed to hash password", err)
+		return dto.RegisterResponse{}, errorsPkg.InfrastructureUnexpected.Err()
+	}
+
+	user, err := a.authRepo.RegisterUser(ctx, db.RegisterUserParams{
+		Username:     request.Username,
+		Email:        request.Email,
+		PasswordHash: string(hash),
+	})
+	if err != nil {
+		if f := repoFault(err, errorsPkg.EmailAlreadyRegistered); f != nil {
+			// A concurrent registration slips past the NOT EXISTS guard and trips users_email_key instead.
+			if f.Code == string(errorsPkg.Conflict) {
+				f = errorsPkg.EmailAlreadyRegistered.Err()
+			}
+			return dto.RegisterResponse{}, f
+		}
+		helper.LogError(ctx, a.logger, "AuthService.Register", "RegisterUser", "failed to register user", err,
+			slog.String("email", request.Email),
+		)
+		return dto.RegisterResponse{}, errorsPkg.InfrastructureUnexpected.Err()
+	}
+
+	tokens, err := a.issueTokens(ctx, "AuthService.Register", user.ID, user.Role, newFamilyID())
+	if err != nil {
+		return dto.RegisterResponse{}, err
+	}
+
+	helper.LogInfo(ctx, a.logger, "AuthService.Register", "user registered successfully",
+		slog.String("user_id", user.ID.String()),
+	)
+
+	return dto.RegisterResponse{User: user, Tokens: tokens}, nil
+}
+
+func (a *AuthService) Login(ctx context.Context, request dto.LoginRequest) (dto.AuthTokens, error) {
+	helper.LogDebug(ctx, a.logger, "AuthService.Login", "logging in",
+		slog.String("email", request.Email),
+	)
+
+	creds, err := a.authRepo.GetUserCredentialsByEmail(ctx, request.Email)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			compareDummyHash(request.Password)
+			return dto.AuthTokens{}, errorsPkg.InvalidCredentials.Err()
+		}
+		helper.LogError(ctx, a.logger, "AuthService.Login", "GetUserCredentialsByEmail", "failed to load credentials", err,
+			slog.String("email", request.Email),
+		)
+		return dto.AuthTokens{}, errorsPkg.InfrastructureUnexpected.Err()
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(creds.PasswordHash), []byte(request.Password)); err != nil || !creds.IsActive {
+		helper.LogInfo(ctx, a.logger, "AuthService.Login", "login rejected",
+			slog.String("user_id", creds.ID.String()),
+			slog.Bool("is_active", creds.IsActive),
+		)
+		return dto.AuthTokens{}, errorsPkg.InvalidCredentials.Err()
+	}
+
+	return a.issueTokens(ctx, "AuthService.Login", creds.ID, creds.Role, newFamilyID())
+}
+
+// Refresh rotates a refresh token. Presenting a token that was already
+// rotated or revoked revokes its whole family, logging out every holder.
+func (a *AuthService) Refresh(ctx context.Context, request dto.RefreshRequest) (dto.AuthTokens, error) {
+	token, err := a.authRepo.GetRefreshTokenByHash(ctx, hashRefreshToken(request.RefreshToken))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dto.AuthTokens{}, errorsPkg.RefreshTokenInvalid.Err()
+		}
+		helper.LogError(ctx, a.logger, "AuthService.Refresh", "GetRefreshTokenByHash", "failed to load refresh token", err)
+		return dto.AuthTokens{}, errorsPkg.InfrastructureUnexpected.Err()
+	}
+
+	if token.RevokedAt.Valid {
+		helper.LogInfo(ctx, a.logger, "AuthService.Refresh", "revoked refresh token reused, revoking family",
+			slog.String("user_id", token.UserID.String()),
+			slog.String("family_id", token.FamilyID.String()),
+		)
+		if err := a.revokeFamily(ctx, "AuthService.Refresh", token.FamilyID); err != nil {
+			return dto.AuthTokens{}, err
+		}
+		return dto.AuthTokens{}, errorsPkg.RefreshTokenInvalid.Err()
+	}
+	if !token.ExpiresAt.Time.After(time.Now()) {
+		return dto.AuthTokens{}, errorsPkg.RefreshTokenInvalid.Err()
+	}
+
+	// The role is re-read on every rotation so promotions and deactivations
+	// take effect within one access token lifetime.
+	user, err := a.authRepo.GetUser(ctx, token.UserID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		helper.LogError(ctx, a.logger, "AuthService.Refresh", "GetUser", "failed to load user", err,
+			slog.String("user_id", token.UserID.String()),
+		)
+		return dto.AuthTokens{}, errorsPkg.InfrastructureUnexpected.Err()
+	}
+	if err != nil || !user.IsActive {
+		if err := a.revokeFamily(ctx, "AuthService.Refresh", token.FamilyID); err != nil {
+			return dto.AuthTokens{}, err
+		}
+		return dto.AuthTokens{}, errorsPkg.RefreshTokenInvalid.Err()
+	}
+
+	revoked, err := a.authRepo.RevokeRefreshToken(ctx, token.ID)
+	if err != nil {
+		helper.LogError(ctx, a.logger, "AuthService.Refresh", "RevokeRefreshToken", "failed to revoke refresh token", err,
+			slog.String("token_id", token.ID.String()),
+		)
+		return dto.AuthTokens{}, errorsPkg.InfrastructureUnexpected.Err()
+	}
+	if revoked == 0 {
+		// A concurrent refresh won the race with the same token.
+		if err := a.revokeFamily(ctx, "AuthService.Refresh", token.FamilyID); err != nil {
+			return dto.AuthTokens{}, err
+		}
+		return dto.AuthTokens{}, errorsPkg.RefreshTokenInvalid.Err()
+	}
+
+	return a.issueTokens(ctx, "AuthService.Refresh", token.UserID, user.Role, token.FamilyID)
+}
+
+// CheckActive confirms that userID still belongs to an active, undeleted account and
+// returns its current role. Access tokens are checked against it on every request, so
+// deactivation, deletion and a role change take effect at once instead of when the
+// token expires.
+func (a *AuthService) CheckActive(ctx context.Context, userID pgtype.UUID) (string, error) {
+	user, err := a.authRepo.GetUser(ctx, userID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		helper.LogError(ctx, a.logger, "AuthService.CheckActive", "GetUser", "failed to load user", err,
+			slog.String("user_id", userID.String()),
+		)
+		return "", errorsPkg.InfrastructureUnexpected.Err()
+	}
+	if err != nil || !user.IsActive {
+		return "", errorsPkg.Unauthorized.Err()
+	}
+	return user.Role, nil
+}
+
+// Logout revokes the family of the presented refresh token. Unknown tokens
+// are ignored so the call is idempotent.
+func (a *AuthService) Logout(ctx context.Context, request dto.LogoutRequest) error {
+	token, err := a.authRepo.GetRefreshTokenByHash(ctx, hashRefreshToken(request.RefreshToken))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		helper.LogError(ctx, a.logger, "AuthService.Logout", "GetRefreshTokenByHash", "failed to load refresh token", err)
+		return errorsPkg.InfrastructureUnexpected.Err()
+	}
+
+	if err := a.revokeFamily(ctx, "AuthService.Logout", token.FamilyID); err != nil {
+		return err
+	}
+
+	helper.LogInfo(ctx, a.logger, "AuthService.Logout", "user logged out",
+		slog.String("user_id", token.UserID.String()),
+	)
+
+	return nil
+}
+
+func (a *AuthService) issueTokens(ctx context.Context, operation string, userID pgtype.UUID, role string, familyID pgtype.UUID) (dto.AuthTokens, error) {
+	access, _, err := a.signer.Issue(userID.String(), role)
+	if err != nil {
+		helper.LogError(ctx, a.logger, operation, "Issue", "failed to sign access token", err,
+			slog.String("user_id", userID.String()),
+		)
+		return dto.AuthTokens{}, errorsPkg.InfrastructureUnexpected.Err()
+	}
+
+	raw := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		helper.LogError(ctx, a.logger, operation, "rand.Read", "failed to generate refresh token", err)
+		return dto.AuthTokens{}, errorsPkg.InfrastructureUnexpected.Err()
+	}
+	refresh := base64.RawURLEncoding.EncodeToString(raw)
+	expiresAt := time.Now().Add(a.refreshTTL)
+
+	if _, err := a.authRepo.CreateRefreshToken(ctx, db.CreateRefreshTokenParams{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: hashRefreshToken(refresh),
+		ExpiresAt: pgtype.Timestamptz{Time: expiresAt, Valid: true},
+	}); err != nil {
+		helper.LogError(ctx, a.logger, operation, "CreateRefreshToken", "failed to store refresh token", err,
+			slog.String("user_id", userID.String()),
+		)
+		return dto.AuthTokens{}, errorsPkg.InfrastructureUnexpected.Err()
+	}
+
+	return dto.AuthTokens{
+		AccessToken:      access,
+		TokenType:        TokenTypeBearer,
+		ExpiresIn:        int64(a.signer.TTL().Seconds()),
+		RefreshToken:     refresh,
+		RefreshExpiresAt: expiresAt,
+	}, nil
+}
+
+func (a *AuthService) revokeFamily(ctx context.Context, operation string, familyID pgtype.UUID) error {
+	if err := a.authRepo.RevokeRefreshTokenFamily(ctx, familyID); err != nil {
+		helper.LogError(ctx, a.logger, operation, "RevokeRefreshTokenFamily", "failed to revoke refresh token family", err,
+			slog.String("family_id", familyID.String()),
+		)
+		return errorsPkg.InfrastructureUnexpected.Err()
+	}
+	return nil
+}
+
+func hashRefreshToken(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+	return sum[:]
+}
+
+func newFamilyID() pgtype.UUID {
+	id, _ := uuidconv.SetPgUUID(uuid.New())
+	return id
+}
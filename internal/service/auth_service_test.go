@@ -0,0 +1,351 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"test-http/internal/authz"
+	db "test-http/internal/db"
+	mockdb "test-http/internal/db/mocks"
+	"test-http/internal/dto"
+	errorsPkg "test-http/pkg/errors_pkg"
+	"test-http/pkg/fault"
+	"test-http/pkg/jwt"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type authTestHelper struct {
+	ctrl    *gomock.Controller
+	repo    *mockdb.MockAuthRepo
+	signer  *jwt.Signer
+	service *AuthService
+	ctx     context.Context
+}
+
+func newAuthTestHelper(t *testing.T) *authTestHelper {
+	ctrl := gomock.NewController(t)
+	repo := mockdb.NewMockAuthRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	signer := jwt.NewSigner([]byte("0123456789abcdef0123456789abcdef"), "test-http", 15*time.Minute)
+
+	return &authTestHelper{
+		ctrl:    ctrl,
+		repo:    repo,
+		signer:  signer,
+		service: NewAuthService(repo, signer, 24*time.Hour, logger),
+		ctx:     context.Background(),
+	}
+}
+
+func randomAuthUUID() pgtype.UUID {
+	return pgtype.UUID{Bytes: uuid.New(), Valid: true}
+}
+
+func expectAuthFault(t *testing.T, err error, code fault.Code) {
+	t.Helper()
+	if err == nil || err.Error() != string(code) {
+		t.Fatalf("expected %s, got %v", code, err)
+	}
+}
+
+func TestAuthService_Register_Success(t *testing.T) {
+	h := newAuthTestHelper(t)
+	defer h.ctrl.Finish()
+
+	user := db.User{ID: randomAuthUUID(), Username: "alice", Email: "alice@example.com", IsActive: true}
+
+	h.repo.EXPECT().RegisterUser(h.ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg db.RegisterUserParams) (db.User, error) {
+			if arg.PasswordHash == "s3cret-password" {
+				t.Fatal("password stored in plain text")
+			}
+			if err := bcrypt.CompareHashAndPassword([]byte(arg.PasswordHash), []byte("s3cret-password")); err != nil {
+				t.Fatalf("stored hash does not match password: %v", err)
+			}
+			return user, nil
+		})
+	h.repo.EXPECT().CreateRefreshToken(h.ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg db.CreateRefreshTokenParams) (db.RefreshToken, error) {
+			if arg.UserID != user.ID || !arg.FamilyID.Valid || len(arg.TokenHash) == 0 {
+				t.Fatalf("unexpected refresh token params: %+v", arg)
+			}
+			return db.RefreshToken{}, nil
+		})
+
+	resp, err := h.service.Register(h.ctx, dto.RegisterRequest{
+		Username: "alice",
+		Email:    "alice@example.com",
+		Password: "s3cret-password",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.User.ID != user.ID {
+		t.Fatalf("got user %+v, want %+v", resp.User, user)
+	}
+
+	claims, err := h.signer.Verify(resp.Tokens.AccessToken)
+	if err != nil {
+		t.Fatalf("access token does not verify: %v", err)
+	}
+	if claims.Subject != user.ID.String() {
+		t.Fatalf("subject = %q, want %q", claims.Subject, user.ID.String())
+	}
+	if resp.Tokens.RefreshToken == "" || resp.Tokens.TokenType != TokenTypeBearer {
+		t.Fatalf("unexpected tokens: %+v", resp.Tokens)
+	}
+}
+
+func TestAuthService_Register_EmailTaken(t *testing.T) {
+	h := newAuthTestHelper(t)
+	defer h.ctrl.Finish()
+
+	h.repo.EXPECT().RegisterUser(h.ctx, gomock.Any()).Return(db.User{}, pgx.ErrNoRows)
+
+	_, err := h.service.Register(h.ctx, dto.RegisterRequest{Username: "alice", Email: "alice@example.com", Password: "s3cret-password"})
+	expectAuthFault(t, err, errorsPkg.EmailAlreadyRegistered)
+}
+
+func TestAuthService_Login(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("s3cret-password"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("hash: %v", err)
+	}
+	userID := randomAuthUUID()
+
+	t.Run("success", func(t *testing.T) {
+		h := newAuthTestHelper(t)
+		defer h.ctrl.Finish()
+
+		h.repo.EXPECT().GetUserCredentialsByEmail(h.ctx, "alice@example.com").
+			Return(db.GetUserCredentialsByEmailRow{ID: userID, IsActive: true, PasswordHash: string(hash)}, nil)
+		h.repo.EXPECT().CreateRefreshToken(h.ctx, gomock.Any()).Return(db.RefreshToken{}, nil)
+
+		tokens, err := h.service.Login(h.ctx, dto.LoginRequest{Email: "alice@example.com", Password: "s3cret-password"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if tokens.AccessToken == "" || tokens.RefreshToken == "" {
+			t.Fatalf("unexpected tokens: %+v", tokens)
+		}
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		h := newAuthTestHelper(t)
+		defer h.ctrl.Finish()
+
+		h.repo.EXPECT().GetUserCredentialsByEmail(h.ctx, gomock.Any()).
+			Return(db.GetUserCredentialsByEmailRow{ID: userID, IsActive: true, PasswordHash: string(hash)}, nil)
+
+		_, err := h.service.Login(h.ctx, dto.LoginRequest{Email: "alice@example.com", Password: "wrong-password"})
+		expectAuthFault(t, err, errorsPkg.InvalidCredentials)
+	})
+
+	t.Run("inactive user", func(t *testing.T) {
+		h := newAuthTestHelper(t)
+		defer h.ctrl.Finish()
+
+		h.repo.EXPECT().GetUserCredentialsByEmail(h.ctx, gomock.Any()).
+			Return(db.GetUserCredentialsByEmailRow{ID: userID, IsActive: false, PasswordHash: string(hash)}, nil)
+
+		_, err := h.service.Login(h.ctx, dto.LoginRequest{Email: "alice@example.com", Password: "s3cret-password"})
+		expectAuthFault(t, err, errorsPkg.InvalidCredentials)
+	})
+
+	t.Run("unknown email", func(t *testing.T) {
+		h := newAuthTestHelper(t)
+		defer h.ctrl.Finish()
+
+		h.repo.EXPECT().GetUserCredentialsByEmail(h.ctx, gomock.Any()).
+			Return(db.GetUserCredentialsByEmailRow{}, pgx.ErrNoRows)
+
+		_, err := h.service.Login(h.ctx, dto.LoginRequest{Email: "nobody@example.com", Password: "s3cret-password"})
+		expectAuthFault(t, err, errorsPkg.InvalidCredentials)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		h := newAuthTestHelper(t)
+		defer h.ctrl.Finish()
+
+		h.repo.EXPECT().GetUserCredentialsByEmail(h.ctx, gomock.Any()).
+			Return(db.GetUserCredentialsByEmailRow{}, errors.New("connection refused"))
+
+		_, err := h.service.Login(h.ctx, dto.LoginRequest{Email: "alice@example.com", Password: "s3cret-password"})
+		expectAuthFault(t, err, errorsPkg.InfrastructureUnexpected)
+	})
+}
+
+func TestAuthService_Refresh(t *testing.T) {
+	userID := randomAuthUUID()
+	familyID := randomAuthUUID()
+	tokenID := randomAuthUUID()
+	future := pgtype.Timestamptz{Time: time.Now().Add(time.Hour), Valid: true}
+
+	t.Run("rotates token within family", func(t *testing.T) {
+		h := newAuthTestHelper(t)
+		defer h.ctrl.Finish()
+
+		gomock.InOrder(
+			h.repo.EXPECT().GetRefreshTokenByHash(h.ctx, hashRefreshToken("old-token")).
+				Return(db.RefreshToken{ID: tokenID, UserID: userID, FamilyID: familyID, ExpiresAt: future}, nil),
+			h.repo.EXPECT().GetUser(h.ctx, userID).Return(db.User{ID: userID, IsActive: true, Role: "admin"}, nil),
+			h.repo.EXPECT().RevokeRefreshToken(h.ctx, tokenID).Return(int64(1), nil),
+			h.repo.EXPECT().CreateRefreshToken(h.ctx, gomock.Any()).
+				DoAndReturn(func(_ context.Context, arg db.CreateRefreshTokenParams) (db.RefreshToken, error) {
+					if arg.FamilyID != familyID || arg.UserID != userID {
+						t.Fatalf("rotated token left family: %+v", arg)
+					}
+					return db.RefreshToken{}, nil
+				}),
+		)
+
+		tokens, err := h.service.Refresh(h.ctx, dto.RefreshRequest{RefreshToken: "old-token"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if tokens.RefreshToken == "old-token" {
+			t.Fatal("refresh token was not rotated")
+		}
+		claims, err := h.signer.Verify(tokens.AccessToken)
+		if err != nil || claims.Role != "admin" {
+			t.Fatalf("access token should carry the current role, got %+v (%v)", claims, err)
+		}
+	})
+
+	t.Run("deactivated user revokes family", func(t *testing.T) {
+		h := newAuthTestHelper(t)
+		defer h.ctrl.Finish()
+
+		h.repo.EXPECT().GetRefreshTokenByHash(h.ctx, gomock.Any()).
+			Return(db.RefreshToken{ID: tokenID, UserID: userID, FamilyID: familyID, ExpiresAt: future}, nil)
+		h.repo.EXPECT().GetUser(h.ctx, userID).Return(db.User{ID: userID, IsActive: false}, nil)
+		h.repo.EXPECT().RevokeRefreshTokenFamily(h.ctx, familyID).Return(nil)
+
+		_, err := h.service.Refresh(h.ctx, dto.RefreshRequest{RefreshToken: "old-token"})
+		expectAuthFault(t, err, errorsPkg.RefreshTokenInvalid)
+	})
+
+	t.Run("reuse of revoked token revokes family", func(t *testing.T) {
+		h := newAuthTestHelper(t)
+		defer h.ctrl.Finish()
+
+		h.repo.EXPECT().GetRefreshTokenByHash(h.ctx, gomock.Any()).
+			Return(db.RefreshToken{ID: tokenID, UserID: userID, FamilyID: familyID, ExpiresAt: future,
+				RevokedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true}}, nil)
+		h.repo.EXPECT().RevokeRefreshTokenFamily(h.ctx, familyID).Return(nil)
+
+		_, err := h.service.Refresh(h.ctx, dto.RefreshRequest{RefreshToken: "old-token"})
+		expectAuthFault(t, err, errorsPkg.RefreshTokenInvalid)
+	})
+
+	t.Run("lost rotation race revokes family", func(t *testing.T) {
+		h := newAuthTestHelper(t)
+		defer h.ctrl.Finish()
+
+		h.repo.EXPECT().GetRefreshTokenByHash(h.ctx, gomock.Any()).
+			Return(db.RefreshToken{ID: tokenID, UserID: userID, FamilyID: familyID, ExpiresAt: future}, nil)
+		h.repo.EXPECT().GetUser(h.ctx, userID).Return(db.User{ID: userID, IsActive: true}, nil)
+		h.repo.EXPECT().RevokeRefreshToken(h.ctx, tokenID).Return(int64(0), nil)
+		h.repo.EXPECT().RevokeRefreshTokenFamily(h.ctx, familyID).Return(nil)
+
+		_, err := h.service.Refresh(h.ctx, dto.RefreshRequest{RefreshToken: "old-token"})
+		expectAuthFault(t, err, errorsPkg.RefreshTokenInvalid)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		h := newAuthTestHelper(t)
+		defer h.ctrl.Finish()
+
+		h.repo.EXPECT().GetRefreshTokenByHash(h.ctx, gomock.Any()).
+			Return(db.RefreshToken{ID: tokenID, UserID: userID, FamilyID: familyID,
+				ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(-time.Minute), Valid: true}}, nil)
+
+		_, err := h.service.Refresh(h.ctx, dto.RefreshRequest{RefreshToken: "old-token"})
+		expectAuthFault(t, err, errorsPkg.RefreshTokenInvalid)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		h := newAuthTestHelper(t)
+		defer h.ctrl.Finish()
+
+		h.repo.EXPECT().GetRefreshTokenByHash(h.ctx, gomock.Any()).Return(db.RefreshToken{}, pgx.ErrNoRows)
+
+		_, err := h.service.Refresh(h.ctx, dto.RefreshRequest{RefreshToken: "nope"})
+		expectAuthFault(t, err, errorsPkg.RefreshTokenInvalid)
+	})
+}
+
+func TestAuthService_Logout(t *testing.T) {
+	t.Run("revokes family", func(t *testing.T) {
+		h := newAuthTestHelper(t)
+		defer h.ctrl.Finish()
+
+		familyID := randomAuthUUID()
+		h.repo.EXPECT().GetRefreshTokenByHash(h.ctx, hashRefreshToken("token")).
+			Return(db.RefreshToken{ID: randomAuthUUID(), FamilyID: familyID}, nil)
+		h.repo.EXPECT().RevokeRefreshTokenFamily(h.ctx, familyID).Return(nil)
+
+		if err := h.service.Logout(h.ctx, dto.LogoutRequest{RefreshToken: "token"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("unknown token is a no-op", func(t *testing.T) {
+		h := newAuthTestHelper(t)
+		defer h.ctrl.Finish()
+
+		h.repo.EXPECT().GetRefreshTokenByHash(h.ctx, gomock.Any()).Return(db.RefreshToken{}, pgx.ErrNoRows)
+
+		if err := h.service.Logout(h.ctx, dto.LogoutRequest{RefreshToken: "token"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestAuthService_CheckActive(t *testing.T) {
+	t.Run("active user", func(t *testing.T) {
+		h := newAuthTestHelper(t)
+		defer h.ctrl.Finish()
+
+		userID := randomAuthUUID()
+		h.repo.EXPECT().GetUser(h.ctx, userID).Return(db.User{ID: userID, IsActive: true, Role: authz.RoleUser}, nil)
+
+		role, err := h.service.CheckActive(h.ctx, userID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if role != authz.RoleUser {
+			t.Fatalf("role = %q, want %q", role, authz.RoleUser)
+		}
+	})
+
+	t.Run("deactivated or deleted user", func(t *testing.T) {
+		h := newAuthTestHelper(t)
+		defer h.ctrl.Finish()
+
+		// GetUser hides deactivated and soft-deleted users.
+		h.repo.EXPECT().GetUser(h.ctx, gomock.Any()).Return(db.User{}, pgx.ErrNoRows)
+
+		_, err := h.service.CheckActive(h.ctx, randomAuthUUID())
+		expectAuthFault(t, err, errorsPkg.Unauthorized)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		h := newAuthTestHelper(t)
+		defer h.ctrl.Finish()
+
+		h.repo.EXPECT().GetUser(h.ctx, gomock.Any()).Return(db.User{}, errors.New("connection reset"))
+
+		_, err := h.service.CheckActive(h.ctx, randomAuthUUID())
+		expectAuthFault(t, err, errorsPkg.InfrastructureUnexpected)
+	})
+}
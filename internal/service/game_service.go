@@ -0,0 +1,458 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"test-http/internal/authz"
+	"test-http/internal/db"
+	"test-http/internal/dto"
+	errorsPkg "test-http/pkg/errors_pkg"
+	"test-http/pkg/helper"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	defaultGameRoundSize = 10
+	gameDistractorCount  = 3
+
+	GameKindMultipleChoice = "multiple_choice"
+	GameKindTyping         = "typing"
+	GameKindFlashcard      = "flashcard"
+	GameModeMixed          = "mixed"
+
+	SessionStatusActive    = "active"
+	SessionStatusCompleted = "completed"
+	SessionStatusAbandoned = "abandoned"
+)
+
+var mixedGameKinds = []string{GameKindMultipleChoice, GameKindTyping, GameKindFlashcard}
+
+type GameService struct {
+	gameRepo        db.GameRepo
+	sessionRepo     db.UserSessionRepo
+	progressRepo    db.UserProgressRepo
+	userWordSetRepo db.UserWordSetRepo
+	txRunner        db.TxRunner
+	activityGap     time.Duration
+	logger          *slog.Logger
+}
+
+func NewGameService(
+	gameRepo db.GameRepo,
+	sessionRepo db.UserSessionRepo,
+	progressRepo db.UserProgressRepo,
+	userWordSetRepo db.UserWordSetRepo,
+	txRunner db.TxRunner,
+	activityGap time.Duration,
+	log *slog.Logger,
+) *GameService {
+	return &GameService{
+		gameRepo:        gameRepo,
+		sessionRepo:     sessionRepo,
+		progressRepo:    progressRepo,
+		userWordSetRepo: userWordSetRepo,
+		txRunner:        txRunner,
+		activityGap:     activityGap,
+		logger:          log,
+	}
+}
+
+// Start opens a session for the user and prepares a round of questions drawn from one of their word sets.
+// Like UserSessionService.Create it refuses to run next to another active session, unless the
+// request asks to abandon that session.
+func (g *GameService) Start(ctx context.Context, request dto.StartGameRequest) (dto.StartGameResponse, error) {
+	helper.LogDebug(ctx, g.logger, "GameService.Start", "starting game round",
+		slog.String("user_id", request.UserID.String()),
+		slog.String("user_word_set_id", request.UserWordSetID.String()),
+		slog.String("mode", request.Mode),
+	)
+
+	if err := authz.RequireOwner(ctx, request.UserID); err != nil {
+		return dto.StartGameResponse{}, err
+	}
+
+	subscription, err := g.userWordSetRepo.GetUserWordSet(ctx, request.UserWordSetID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dto.StartGameResponse{}, errorsPkg.UserWordSetNotFound.Err()
+		}
+		helper.LogError(ctx, g.logger, "GameService.Start", "GetUserWordSet", "failed to get user word set", err,
+			slog.String("user_word_set_id", request.UserWordSetID.String()),
+		)
+		return dto.StartGameResponse{}, errorsPkg.InfrastructureUnexpected.Err()
+	}
+	if subscription.UserID != request.UserID {
+		return dto.StartGameResponse{}, errorsPkg.UserWordSetNotFound.Err()
+	}
+
+	size := request.Size
+	if size == 0 {
+		size = defaultGameRoundSize
+	}
+
+	words, err := g.gameRepo.PickRoundWords(ctx, db.PickRoundWordsParams{
+		WordSetID: subscription.WordSetID,
+		Limit:     size,
+	})
+	if err != nil {
+		helper.LogError(ctx, g.logger, "GameService.Start", "PickRoundWords", "failed to pick round words", err,
+			slog.String("word_set_id", subscription.WordSetID.String()),
+		)
+		return dto.StartGameResponse{}, errorsPkg.InfrastructureUnexpected.Err()
+	}
+	if len(words) == 0 {
+		return dto.StartGameResponse{}, errorsPkg.GameWordSetEmpty.Err()
+	}
+
+	// The session and its questions are written together so a failure never leaves a half-built round.
+	var (
+		session db.UserSession
+		first   db.GameQuestion
+	)
+	err = g.txRunner.InTx(ctx, func(tx db.Tx) error {
+		if request.AbandonActive {
+			if _, err := tx.Sessions().AbandonActiveUserSessions(ctx, request.UserID); err != nil {
+				helper.LogError(ctx, g.logger, "GameService.Start", "AbandonActiveUserSessions", "failed to abandon previous session", err,
+					slog.String("user_id", request.UserID.String()),
+				)
+				return err
+			}
+		}
+
+		var err error
+		session, err = tx.Sessions().CreateUserSession(ctx, db.CreateUserSessionParams{
+			UserID: request.UserID,
+			Status: SessionStatusActive,
+		})
+		if err != nil {
+			if f := createSessionFault(err); f != nil {
+				return f
+			}
+			helper.LogError(ctx, g.logger, "GameService.Start", "CreateUserSession", "failed to create session", err,
+				slog.String("user_id", request.UserID.String()),
+			)
+			return err
+		}
+
+		for i, word := range words {
+			kind := request.Mode
+			if kind == GameModeMixed {
+				kind = mixedGameKinds[i%len(mixedGameKinds)]
+			}
+
+			params, err := g.buildQuestion(ctx, tx.Games(), session.ID, int32(i+1), kind, word)
+			if err != nil {
+				return err
+			}
+
+			question, err := tx.Games().CreateGameQuestion(ctx, params)
+			if err != nil {
+				helper.LogError(ctx, g.logger, "GameService.Start", "CreateGameQuestion", "failed to create question", err,
+					slog.String("session_id", session.ID.String()),
+				)
+				return err
+			}
+			if i == 0 {
+				first = question
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return dto.StartGameResponse{}, txError(err)
+	}
+
+	helper.LogInfo(ctx, g.logger, "GameService.Start", "game round started",
+		slog.String("session_id", session.ID.String()),
+		slog.Int("questions", len(words)),
+	)
+
+	return dto.StartGameResponse{
+		Session:  session,
+		Total:    len(words),
+		Question: questionView(first),
+	}, nil
+}
+
+// Next returns the first unanswered question of an active round.
+func (g *GameService) Next(ctx context.Context, request dto.GetGameQuestionRequest) (dto.GameQuestion, error) {
+	if _, err := g.activeSession(ctx, "GameService.Next", request.SessionID); err != nil {
+		return dto.GameQuestion{}, err
+	}
+
+	question, err := g.gameRepo.GetNextGameQuestion(ctx, request.SessionID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dto.GameQuestion{}, errorsPkg.GameRoundFinished.Err()
+		}
+		helper.LogError(ctx, g.logger, "GameService.Next", "GetNextGameQuestion", "failed to get next question", err,
+			slog.String("session_id", request.SessionID.String()),
+		)
+		return dto.GameQuestion{}, errorsPkg.InfrastructureUnexpected.Err()
+	}
+
+	return questionView(question), nil
+}
+
+// Answer grades an answer, records the attempt in user_progress and completes the session after the last question.
+func (g *GameService) Answer(ctx context.Context, request dto.AnswerGameRequest) (dto.AnswerGameResponse, error) {
+	helper.LogDebug(ctx, g.logger, "GameService.Answer", "answering question",
+		slog.String("session_id", request.SessionID.String()),
+		slog.String("question_id", request.QuestionID.String()),
+	)
+
+	session, err := g.activeSession(ctx, "GameService.Answer", request.SessionID)
+	if err != nil {
+		return dto.AnswerGameResponse{}, err
+	}
+
+	question, err := g.gameRepo.GetGameQuestion(ctx, request.QuestionID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dto.AnswerGameResponse{}, errorsPkg.GameQuestionNotFound.Err()
+		}
+		helper.LogError(ctx, g.logger, "GameService.Answer", "GetGameQuestion", "failed to get question", err,
+			slog.String("question_id", request.QuestionID.String()),
+		)
+		return dto.AnswerGameResponse{}, errorsPkg.InfrastructureUnexpected.Err()
+	}
+	if question.SessionID != session.ID {
+		return dto.AnswerGameResponse{}, errorsPkg.GameQuestionNotFound.Err()
+	}
+
+	correct, err := gradeAnswer(question, request)
+	if err != nil {
+		return dto.AnswerGameResponse{}, err
+	}
+
+	// The answer, the progress update and closing a completed round commit together.
+	given := request.Answer
+	var (
+		progress db.UserProgress
+		summary  dto.GameSummary
+	)
+	err = g.txRunner.InTx(ctx, func(tx db.Tx) error {
+		if _, err := tx.Games().AnswerGameQuestion(ctx, db.AnswerGameQuestionParams{
+			ID:          question.ID,
+			GivenAnswer: &given,
+			IsCorrect:   &correct,
+		}); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return errorsPkg.GameQuestionAlreadyAnswered.Err()
+			}
+			helper.LogError(ctx, g.logger, "GameService.Answer", "AnswerGameQuestion", "failed to store answer", err,
+				slog.String("question_id", question.ID.String()),
+			)
+			return err
+		}
+
+		var err error
+		progress, err = tx.Progress().RecordUserProgressAttempt(ctx, db.RecordUserProgressAttemptParams{
+			UserID:  session.UserID,
+			WordID:  question.WordID,
+			Correct: correct,
+			Quality: reviewQuality(correct),
+		})
+		if err != nil {
+			helper.LogError(ctx, g.logger, "GameService.Answer", "RecordUserProgressAttempt", "failed to record attempt", err,
+				slog.String("user_id", session.UserID.String()),
+				slog.String("word_id", question.WordID.String()),
+			)
+			return err
+		}
+
+		if _, err := recordSessionActivity(ctx, tx.Sessions(), session, SessionEventAnswer, g.activityGap); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return errorsPkg.GameRoundClosed.Err()
+			}
+			helper.LogError(ctx, g.logger, "GameService.Answer", "RecordSessionActivity", "failed to record session activity", err,
+				slog.String("session_id", session.ID.String()),
+			)
+			return err
+		}
+
+		if summary, err = g.summary(ctx, "GameService.Answer", tx.Games(), session); err != nil {
+			return err
+		}
+		if summary.Answered == summary.Total {
+			closed, err := g.closeSession(ctx, "GameService.Answer", tx.Sessions(), session)
+			if err != nil {
+				return err
+			}
+			summary.Status = closed.Status
+		}
+		return nil
+	})
+	if err != nil {
+		return dto.AnswerGameResponse{}, txError(err)
+	}
+
+	finished := summary.Answered == summary.Total
+
+	return dto.AnswerGameResponse{
+		Correct:       correct,
+		CorrectAnswer: question.Answer,
+		Progress:      progress,
+		Finished:      finished,
+		Summary:       summary,
+	}, nil
+}
+
+// Finish completes the round early; unanswered questions are simply left unanswered.
+func (g *GameService) Finish(ctx context.Context, request dto.FinishGameRequest) (dto.GameSummary, error) {
+	active, err := g.activeSession(ctx, "GameService.Finish", request.SessionID)
+	if err != nil {
+		return dto.GameSummary{}, err
+	}
+
+	var summary dto.GameSummary
+	err = g.txRunner.InTx(ctx, func(tx db.Tx) error {
+		session, err := g.closeSession(ctx, "GameService.Finish", tx.Sessions(), active)
+		if err != nil {
+			return err
+		}
+		summary, err = g.summary(ctx, "GameService.Finish", tx.Games(), session)
+		return err
+	})
+	if err != nil {
+		return dto.GameSummary{}, txError(err)
+	}
+
+	helper.LogInfo(ctx, g.logger, "GameService.Finish", "game round finished",
+		slog.String("session_id", summary.SessionID.String()),
+	)
+
+	return summary, nil
+}
+
+func (g *GameService) buildQuestion(ctx context.Context, games db.GameRepo, sessionID pgtype.UUID, position int32, kind string, word db.Word) (db.CreateGameQuestionParams, error) {
+	params := db.CreateGameQuestionParams{
+		SessionID: sessionID,
+		WordID:    word.ID,
+		Position:  position,
+		Kind:      kind,
+		Prompt:    word.Lemma,
+		Choices:   []string{},
+		Answer:    word.Translation,
+	}
+
+	if kind != GameKindMultipleChoice {
+		return params, nil
+	}
+
+	distractors, err := games.PickDistractors(ctx, db.PickDistractorsParams{
+		SourceLang: word.SourceLang,
+		TargetLang: word.TargetLang,
+		Answer:     word.Translation,
+		Limit:      gameDistractorCount,
+	})
+	if err != nil {
+		helper.LogError(ctx, g.logger, "GameService.Start", "PickDistractors", "failed to pick distractors", err,
+			slog.String("word_id", word.ID.String()),
+		)
+		return db.CreateGameQuestionParams{}, err
+	}
+	// Without anything to choose from a multiple choice question gives the answer away.
+	if len(distractors) == 0 {
+		params.Kind = GameKindTyping
+		return params, nil
+	}
+
+	choices := append(distractors, word.Translation)
+	rand.Shuffle(len(choices), func(i, j int) { choices[i], choices[j] = choices[j], choices[i] })
+	params.Choices = choices
+
+	return params, nil
+}
+
+func (g *GameService) activeSession(ctx context.Context, operation string, sessionID pgtype.UUID) (db.UserSession, error) {
+	session, err := g.sessionRepo.GetUserSession(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.UserSession{}, errorsPkg.GameRoundNotFound.Err()
+		}
+		helper.LogError(ctx, g.logger, operation, "GetUserSession", "failed to get session", err,
+			slog.String("session_id", sessionID.String()),
+		)
+		return db.UserSession{}, errorsPkg.InfrastructureUnexpected.Err()
+	}
+	if err := authz.RequireOwner(ctx, session.UserID); err != nil {
+		return db.UserSession{}, err
+	}
+	if session.Status != SessionStatusActive {
+		return db.UserSession{}, errorsPkg.GameRoundClosed.Err()
+	}
+	return session, nil
+}
+
+// closeSession and summary run inside transactions: errors are logged and returned as is for txError.
+func (g *GameService) closeSession(ctx context.Context, operation string, sessions db.UserSessionRepo, active db.UserSession) (db.UserSession, error) {
+	session, err := sessions.UpdateUserSession(ctx, db.UpdateUserSessionParams{
+		ID:     active.ID,
+		UserID: active.UserID,
+		Status: SessionStatusCompleted,
+	})
+	if err != nil {
+		// Only active sessions are updated, e.g. the reaper may have abandoned this one meanwhile.
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.UserSession{}, errorsPkg.GameRoundClosed.Err()
+		}
+		helper.LogError(ctx, g.logger, operation, "UpdateUserSession", "failed to complete session", err,
+			slog.String("session_id", active.ID.String()),
+		)
+		return db.UserSession{}, err
+	}
+	return session, nil
+}
+
+func (g *GameService) summary(ctx context.Context, operation string, games db.GameRepo, session db.UserSession) (dto.GameSummary, error) {
+	row, err := games.GetGameSummary(ctx, session.ID)
+	if err != nil {
+		helper.LogError(ctx, g.logger, operation, "GetGameSummary", "failed to summarize round", err,
+			slog.String("session_id", session.ID.String()),
+		)
+		return dto.GameSummary{}, err
+	}
+	return dto.GameSummary{
+		SessionID: session.ID,
+		Status:    session.Status,
+		Total:     row.Total,
+		Answered:  row.Answered,
+		Correct:   row.Correct,
+	}, nil
+}
+
+func gradeAnswer(question db.GameQuestion, request dto.AnswerGameRequest) (bool, error) {
+	if question.Kind == GameKindFlashcard {
+		if request.Known == nil {
+			return false, errorsPkg.GameFlashcardVerdictMissing.Err()
+		}
+		return *request.Known, nil
+	}
+	return normalizeAnswer(request.Answer) == normalizeAnswer(question.Answer), nil
+}
+
+func normalizeAnswer(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+func questionView(question db.GameQuestion) dto.GameQuestion {
+	view := dto.GameQuestion{
+		ID:       question.ID,
+		Position: question.Position,
+		Kind:     question.Kind,
+		Prompt:   question.Prompt,
+		Choices:  question.Choices,
+	}
+	if question.Kind == GameKindFlashcard {
+		view.Answer = question.Answer
+	}
+	return view
+}
@@ -0,0 +1,289 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+
+	"test-http/internal/authz"
+	"test-http/internal/db"
+	"test-http/internal/db/mocks"
+	"test-http/internal/dto"
+	errorsPkg "test-http/pkg/errors_pkg"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type gameTestHelper struct {
+	ctrl            *gomock.Controller
+	gameRepo        *mocks.MockGameRepo
+	sessionRepo     *mocks.MockUserSessionRepo
+	progressRepo    *mocks.MockUserProgressRepo
+	userWordSetRepo *mocks.MockUserWordSetRepo
+	tx              *mocks.MockTx
+	service         *GameService
+}
+
+func newGameTestHelper(t *testing.T) *gameTestHelper {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	h := &gameTestHelper{
+		ctrl:            ctrl,
+		gameRepo:        mocks.NewMockGameRepo(ctrl),
+		sessionRepo:     mocks.NewMockUserSessionRepo(ctrl),
+		progressRepo:    mocks.NewMockUserProgressRepo(ctrl),
+		userWordSetRepo: mocks.NewMockUserWordSetRepo(ctrl),
+		tx:              mocks.NewMockTx(ctrl),
+	}
+	h.tx.EXPECT().Games().Return(h.gameRepo).AnyTimes()
+	h.tx.EXPECT().Sessions().Return(h.sessionRepo).AnyTimes()
+	h.tx.EXPECT().Progress().Return(h.progressRepo).AnyTimes()
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	h.service = NewGameService(h.gameRepo, h.sessionRepo, h.progressRepo, h.userWordSetRepo, inTx(ctrl, h.tx), 2*time.Minute, logger)
+	return h
+}
+
+func gameUUID(b byte) pgtype.UUID {
+	return pgtype.UUID{Bytes: [16]byte{b}, Valid: true}
+}
+
+// gamePlayerCtx authenticates the caller as the user gameUUID(1).
+func gamePlayerCtx() context.Context {
+	return authz.WithPrincipal(context.Background(), authz.Principal{UserID: gameUUID(1), Role: authz.RoleUser})
+}
+
+func TestGameService_Start_MultipleChoice(t *testing.T) {
+	h := newGameTestHelper(t)
+	defer h.ctrl.Finish()
+
+	userID, subID, setID, sessionID := gameUUID(1), gameUUID(2), gameUUID(3), gameUUID(4)
+	word := db.Word{ID: gameUUID(5), Lemma: "house", Translation: "дом", SourceLang: "en", TargetLang: "ru"}
+
+	h.userWordSetRepo.EXPECT().GetUserWordSet(gomock.Any(), subID).
+		Return(db.UserWordSet{ID: subID, UserID: userID, WordSetID: setID}, nil)
+	h.gameRepo.EXPECT().PickRoundWords(gomock.Any(), db.PickRoundWordsParams{WordSetID: setID, Limit: defaultGameRoundSize}).
+		Return([]db.Word{word}, nil)
+	h.sessionRepo.EXPECT().CreateUserSession(gomock.Any(), db.CreateUserSessionParams{UserID: userID, Status: SessionStatusActive}).
+		Return(db.UserSession{ID: sessionID, UserID: userID, Status: SessionStatusActive}, nil)
+	h.gameRepo.EXPECT().PickDistractors(gomock.Any(), gomock.Any()).Return([]string{"кот", "окно"}, nil)
+	h.gameRepo.EXPECT().CreateGameQuestion(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, arg db.CreateGameQuestionParams) (db.GameQuestion, error) {
+			if arg.Kind != GameKindMultipleChoice || len(arg.Choices) != 3 || !slices.Contains(arg.Choices, "дом") {
+				t.Errorf("unexpected question params: %+v", arg)
+			}
+			return db.GameQuestion{ID: gameUUID(6), SessionID: arg.SessionID, Kind: arg.Kind, Prompt: arg.Prompt, Choices: arg.Choices, Answer: arg.Answer}, nil
+		})
+
+	got, err := h.service.Start(gamePlayerCtx(), dto.StartGameRequest{UserID: userID, UserWordSetID: subID, Mode: GameKindMultipleChoice})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Total != 1 || got.Question.Prompt != "house" {
+		t.Fatalf("unexpected round: %+v", got)
+	}
+	if got.Question.Answer != "" {
+		t.Fatalf("multiple choice question must not reveal the answer")
+	}
+}
+
+func TestGameService_Start_RejectsSecondActiveSession(t *testing.T) {
+	h := newGameTestHelper(t)
+	defer h.ctrl.Finish()
+
+	userID, subID, setID := gameUUID(1), gameUUID(2), gameUUID(3)
+	h.userWordSetRepo.EXPECT().GetUserWordSet(gomock.Any(), subID).
+		Return(db.UserWordSet{ID: subID, UserID: userID, WordSetID: setID}, nil)
+	h.gameRepo.EXPECT().PickRoundWords(gomock.Any(), gomock.Any()).Return([]db.Word{{ID: gameUUID(5), Lemma: "house"}}, nil)
+	h.sessionRepo.EXPECT().CreateUserSession(gomock.Any(), gomock.Any()).
+		Return(db.UserSession{}, &pgconn.PgError{Code: pgUniqueViolation, ConstraintName: "user_sessions_one_active_per_user"})
+
+	_, err := h.service.Start(gamePlayerCtx(), dto.StartGameRequest{UserID: userID, UserWordSetID: subID, Mode: GameKindTyping})
+	if err == nil || err.Error() != string(errorsPkg.UserSessionAlreadyActive) {
+		t.Fatalf("expected %s, got %v", errorsPkg.UserSessionAlreadyActive, err)
+	}
+}
+
+func TestGameService_Start_AbandonActive(t *testing.T) {
+	h := newGameTestHelper(t)
+	defer h.ctrl.Finish()
+
+	userID, subID, setID, sessionID := gameUUID(1), gameUUID(2), gameUUID(3), gameUUID(4)
+	h.userWordSetRepo.EXPECT().GetUserWordSet(gomock.Any(), subID).
+		Return(db.UserWordSet{ID: subID, UserID: userID, WordSetID: setID}, nil)
+	h.gameRepo.EXPECT().PickRoundWords(gomock.Any(), gomock.Any()).Return([]db.Word{{ID: gameUUID(5), Lemma: "house"}}, nil)
+	gomock.InOrder(
+		h.sessionRepo.EXPECT().AbandonActiveUserSessions(gomock.Any(), userID).Return(int64(1), nil),
+		h.sessionRepo.EXPECT().CreateUserSession(gomock.Any(), gomock.Any()).
+			Return(db.UserSession{ID: sessionID, UserID: userID, Status: SessionStatusActive}, nil),
+	)
+	h.gameRepo.EXPECT().CreateGameQuestion(gomock.Any(), gomock.Any()).Return(db.GameQuestion{ID: gameUUID(6), Kind: GameKindTyping}, nil)
+
+	_, err := h.service.Start(gamePlayerCtx(), dto.StartGameRequest{UserID: userID, UserWordSetID: subID, Mode: GameKindTyping, AbandonActive: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestGameService_Start_ForeignWordSet(t *testing.T) {
+	h := newGameTestHelper(t)
+	defer h.ctrl.Finish()
+
+	subID := gameUUID(2)
+	h.userWordSetRepo.EXPECT().GetUserWordSet(gomock.Any(), subID).
+		Return(db.UserWordSet{ID: subID, UserID: gameUUID(9)}, nil)
+
+	_, err := h.service.Start(gamePlayerCtx(), dto.StartGameRequest{UserID: gameUUID(1), UserWordSetID: subID, Mode: GameKindTyping})
+	if err == nil || err.Error() != string(errorsPkg.UserWordSetNotFound) {
+		t.Fatalf("expected %s, got %v", errorsPkg.UserWordSetNotFound, err)
+	}
+}
+
+func TestGameService_Start_EmptyWordSet(t *testing.T) {
+	h := newGameTestHelper(t)
+	defer h.ctrl.Finish()
+
+	userID, subID := gameUUID(1), gameUUID(2)
+	h.userWordSetRepo.EXPECT().GetUserWordSet(gomock.Any(), subID).
+		Return(db.UserWordSet{ID: subID, UserID: userID}, nil)
+	h.gameRepo.EXPECT().PickRoundWords(gomock.Any(), gomock.Any()).Return([]db.Word{}, nil)
+
+	_, err := h.service.Start(gamePlayerCtx(), dto.StartGameRequest{UserID: userID, UserWordSetID: subID, Mode: GameKindTyping})
+	if err == nil || err.Error() != string(errorsPkg.GameWordSetEmpty) {
+		t.Fatalf("expected %s, got %v", errorsPkg.GameWordSetEmpty, err)
+	}
+}
+
+func TestGameService_Answer_LastQuestionCompletesSession(t *testing.T) {
+	h := newGameTestHelper(t)
+	defer h.ctrl.Finish()
+
+	userID, sessionID, questionID, wordID := gameUUID(1), gameUUID(4), gameUUID(6), gameUUID(5)
+
+	h.sessionRepo.EXPECT().GetUserSession(gomock.Any(), sessionID).
+		Return(db.UserSession{ID: sessionID, UserID: userID, Status: SessionStatusActive}, nil)
+	h.gameRepo.EXPECT().GetGameQuestion(gomock.Any(), questionID).
+		Return(db.GameQuestion{ID: questionID, SessionID: sessionID, WordID: wordID, Kind: GameKindTyping, Answer: "Дом"}, nil)
+	h.gameRepo.EXPECT().AnswerGameQuestion(gomock.Any(), gomock.Any()).Return(db.GameQuestion{}, nil)
+	h.progressRepo.EXPECT().RecordUserProgressAttempt(gomock.Any(), db.RecordUserProgressAttemptParams{UserID: userID, WordID: wordID, Correct: true, Quality: ReviewQualityCorrect}).
+		Return(db.UserProgress{CorrectCount: 1}, nil)
+	h.sessionRepo.EXPECT().RecordSessionActivity(gomock.Any(), db.RecordSessionActivityParams{MaxGapSeconds: 120, Kind: SessionEventAnswer, ID: sessionID, UserID: userID}).
+		Return(db.UserSession{ID: sessionID, UserID: userID, Status: SessionStatusActive}, nil)
+	h.sessionRepo.EXPECT().CreateUserSessionEvent(gomock.Any(), db.CreateUserSessionEventParams{SessionID: sessionID, Kind: SessionEventAnswer}).
+		Return(db.UserSessionEvent{}, nil)
+	h.gameRepo.EXPECT().GetGameSummary(gomock.Any(), sessionID).
+		Return(db.GetGameSummaryRow{Total: 1, Answered: 1, Correct: 1}, nil)
+	h.sessionRepo.EXPECT().UpdateUserSession(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, arg db.UpdateUserSessionParams) (db.UserSession, error) {
+			if arg.Status != SessionStatusCompleted || arg.ID != sessionID {
+				t.Errorf("expected session to be completed, got %+v", arg)
+			}
+			return db.UserSession{ID: sessionID, Status: SessionStatusCompleted}, nil
+		})
+
+	got, err := h.service.Answer(gamePlayerCtx(), dto.AnswerGameRequest{SessionID: sessionID, QuestionID: questionID, Answer: "  дом "})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !got.Correct || !got.Finished || got.Summary.Status != SessionStatusCompleted {
+		t.Fatalf("unexpected answer result: %+v", got)
+	}
+}
+
+func TestGameService_Answer_AlreadyAnswered(t *testing.T) {
+	h := newGameTestHelper(t)
+	defer h.ctrl.Finish()
+
+	sessionID, questionID := gameUUID(4), gameUUID(6)
+
+	h.sessionRepo.EXPECT().GetUserSession(gomock.Any(), sessionID).
+		Return(db.UserSession{ID: sessionID, UserID: gameUUID(1), Status: SessionStatusActive}, nil)
+	h.gameRepo.EXPECT().GetGameQuestion(gomock.Any(), questionID).
+		Return(db.GameQuestion{ID: questionID, SessionID: sessionID, Kind: GameKindTyping, Answer: "дом"}, nil)
+	h.gameRepo.EXPECT().AnswerGameQuestion(gomock.Any(), gomock.Any()).Return(db.GameQuestion{}, pgx.ErrNoRows)
+
+	_, err := h.service.Answer(gamePlayerCtx(), dto.AnswerGameRequest{SessionID: sessionID, QuestionID: questionID, Answer: "дом"})
+	if err == nil || err.Error() != string(errorsPkg.GameQuestionAlreadyAnswered) {
+		t.Fatalf("expected %s, got %v", errorsPkg.GameQuestionAlreadyAnswered, err)
+	}
+}
+
+func TestGameService_Answer_FlashcardNeedsVerdict(t *testing.T) {
+	h := newGameTestHelper(t)
+	defer h.ctrl.Finish()
+
+	sessionID, questionID := gameUUID(4), gameUUID(6)
+
+	h.sessionRepo.EXPECT().GetUserSession(gomock.Any(), sessionID).
+		Return(db.UserSession{ID: sessionID, UserID: gameUUID(1), Status: SessionStatusActive}, nil)
+	h.gameRepo.EXPECT().GetGameQuestion(gomock.Any(), questionID).
+		Return(db.GameQuestion{ID: questionID, SessionID: sessionID, Kind: GameKindFlashcard, Answer: "дом"}, nil)
+
+	_, err := h.service.Answer(gamePlayerCtx(), dto.AnswerGameRequest{SessionID: sessionID, QuestionID: questionID})
+	if err == nil || err.Error() != string(errorsPkg.GameFlashcardVerdictMissing) {
+		t.Fatalf("expected %s, got %v", errorsPkg.GameFlashcardVerdictMissing, err)
+	}
+}
+
+func TestGameService_Next_ClosedSession(t *testing.T) {
+	h := newGameTestHelper(t)
+	defer h.ctrl.Finish()
+
+	sessionID := gameUUID(4)
+	h.sessionRepo.EXPECT().GetUserSession(gomock.Any(), sessionID).
+		Return(db.UserSession{ID: sessionID, UserID: gameUUID(1), Status: SessionStatusCompleted}, nil)
+
+	_, err := h.service.Next(gamePlayerCtx(), dto.GetGameQuestionRequest{SessionID: sessionID})
+	if err == nil || err.Error() != string(errorsPkg.GameRoundClosed) {
+		t.Fatalf("expected %s, got %v", errorsPkg.GameRoundClosed, err)
+	}
+}
+
+func TestGameService_Start_ForOtherUser(t *testing.T) {
+	h := newGameTestHelper(t)
+	defer h.ctrl.Finish()
+
+	_, err := h.service.Start(gamePlayerCtx(), dto.StartGameRequest{UserID: gameUUID(9), UserWordSetID: gameUUID(2), Mode: GameKindTyping})
+	if err == nil || err.Error() != string(errorsPkg.Forbidden) {
+		t.Fatalf("expected %s, got %v", errorsPkg.Forbidden, err)
+	}
+}
+
+func TestGameService_Next_ForeignSession(t *testing.T) {
+	h := newGameTestHelper(t)
+	defer h.ctrl.Finish()
+
+	sessionID := gameUUID(4)
+	h.sessionRepo.EXPECT().GetUserSession(gomock.Any(), sessionID).
+		Return(db.UserSession{ID: sessionID, UserID: gameUUID(9), Status: SessionStatusActive}, nil)
+
+	_, err := h.service.Next(gamePlayerCtx(), dto.GetGameQuestionRequest{SessionID: sessionID})
+	if err == nil || err.Error() != string(errorsPkg.Forbidden) {
+		t.Fatalf("expected %s, got %v", errorsPkg.Forbidden, err)
+	}
+}
+
+func TestGameService_Answer_ProgressFailureAbortsTx(t *testing.T) {
+	h := newGameTestHelper(t)
+	defer h.ctrl.Finish()
+
+	sessionID, questionID := gameUUID(4), gameUUID(6)
+
+	h.sessionRepo.EXPECT().GetUserSession(gomock.Any(), sessionID).
+		Return(db.UserSession{ID: sessionID, UserID: gameUUID(1), Status: SessionStatusActive}, nil)
+	h.gameRepo.EXPECT().GetGameQuestion(gomock.Any(), questionID).
+		Return(db.GameQuestion{ID: questionID, SessionID: sessionID, Kind: GameKindTyping, Answer: "дом"}, nil)
+	h.gameRepo.EXPECT().AnswerGameQuestion(gomock.Any(), gomock.Any()).Return(db.GameQuestion{}, nil)
+	h.progressRepo.EXPECT().RecordUserProgressAttempt(gomock.Any(), gomock.Any()).
+		Return(db.UserProgress{}, &pgconn.PgError{Code: "40001"})
+
+	_, err := h.service.Answer(gamePlayerCtx(), dto.AnswerGameRequest{SessionID: sessionID, QuestionID: questionID, Answer: "дом"})
+	if err == nil || err.Error() != string(errorsPkg.InfrastructureUnexpected) {
+		t.Fatalf("expected %s, got %v", errorsPkg.InfrastructureUnexpected, err)
+	}
+}
@@ -0,0 +1,451 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"test-http/internal/db"
+	"test-http/internal/dto"
+	errorsPkg "test-http/pkg/errors_pkg"
+	"test-http/pkg/fault"
+	"test-http/pkg/helper"
+	"test-http/pkg/wordimport"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	ImportStatusPending   = "pending"
+	ImportStatusRunning   = "running"
+	ImportStatusCompleted = "completed"
+	ImportStatusFailed    = "failed"
+
+	defaultImportErrorsLimit = 50
+	defaultPartOfSpeech      = "other"
+	defaultDifficulty        = 1
+)
+
+// errLeaseLost aborts a batch whose job was claimed by another runner after our lease ran out.
+var errLeaseLost = errors.New("import job lease lost")
+
+// ImportService turns uploaded word lists into words and word set items. An upload
+// becomes a job that runners claim and work through in batches; every batch commits
+// together with its checkpoint, so a stopped or failed job resumes after the last
+// committed row. A runner that dies keeps its job until the lease expires.
+type ImportService struct {
+	importRepo db.ImportRepo
+	txRunner   db.TxRunner
+	validate   *validator.Validate
+	lease      time.Duration
+	batchSize  int
+	logger     *slog.Logger
+}
+
+func NewImportService(importRepo db.ImportRepo, txRunner db.TxRunner, validate *validator.Validate, lease time.Duration, batchSize int, log *slog.Logger) *ImportService {
+	return &ImportService{
+		importRepo: importRepo,
+		txRunner:   txRunner,
+		validate:   validate,
+		lease:      lease,
+		batchSize:  batchSize,
+		logger:     log,
+	}
+}
+
+// Create checks that the file parses and queues it. Rows are validated while the job runs.
+func (s *ImportService) Create(ctx context.Context, request dto.CreateImportRequest) (db.ImportJob, error) {
+	helper.LogDebug(ctx, s.logger, "ImportService.Create", "creating import job",
+		slog.String("filename", request.Filename),
+		slog.String("format", request.Format),
+		slog.Int("size", len(request.Data)),
+	)
+
+	rows, err := wordimport.Parse(wordimport.Format(request.Format), request.Data)
+	if err != nil {
+		return db.ImportJob{}, errorsPkg.ImportFileInvalid.Err(&fault.Arg{K: "reason", V: err.Error()})
+	}
+
+	var job db.ImportJob
+	err = s.txRunner.InTx(ctx, func(tx db.Tx) error {
+		wordSetID := request.WordSetID
+		if wordSetID.Valid {
+			if _, err := tx.WordSets().GetWordSet(ctx, wordSetID); err != nil {
+				return err
+			}
+		} else if request.WordSetTitle != "" {
+			// A catalog set without an owner, kept private until an editor has
+			// looked it over and publishes it.
+			set, err := tx.WordSets().CreateWordSet(ctx, db.CreateWordSetParams{
+				Title:       request.WordSetTitle,
+				Description: "Imported from " + request.Filename,
+				Language:    request.SourceLang,
+				Visibility:  "private",
+			})
+			if err != nil {
+				return err
+			}
+			wordSetID = set.ID
+		}
+
+		var err error
+		job, err = tx.Imports().CreateImportJob(ctx, db.CreateImportJobParams{
+			CreatedBy:  request.CreatedBy,
+			Filename:   request.Filename,
+			Format:     request.Format,
+			SourceLang: request.SourceLang,
+			TargetLang: request.TargetLang,
+			WordSetID:  wordSetID,
+			TotalRows:  int32(len(rows)),
+		})
+		if err != nil {
+			return err
+		}
+		return tx.Imports().CreateImportJobPayload(ctx, db.CreateImportJobPayloadParams{JobID: job.ID, Data: request.Data})
+	})
+	if err != nil {
+		if f := repoFault(err, errorsPkg.WordSetNotFound); f != nil {
+			return db.ImportJob{}, f
+		}
+		helper.LogError(ctx, s.logger, "ImportService.Create", "InTx", "failed to create import job", err,
+			slog.String("filename", request.Filename),
+		)
+		return db.ImportJob{}, errorsPkg.InfrastructureUnexpected.Err()
+	}
+
+	helper.LogInfo(ctx, s.logger, "ImportService.Create", "import job queued",
+		slog.String("import_id", job.ID.String()),
+		slog.Int("rows", int(job.TotalRows)),
+	)
+
+	return job, nil
+}
+
+func (s *ImportService) Get(ctx context.Context, request dto.GetImportRequest) (db.ImportJob, error) {
+	job, err := s.importRepo.GetImportJob(ctx, request.ID)
+	if err != nil {
+		if f := repoFault(err, errorsPkg.ImportJobNotFound); f != nil {
+			return db.ImportJob{}, f
+		}
+		helper.LogError(ctx, s.logger, "ImportService.Get", "GetImportJob", "failed to get import job", err,
+			slog.String("import_id", request.ID.String()),
+		)
+		return db.ImportJob{}, errorsPkg.InfrastructureUnexpected.Err()
+	}
+
+	return job, nil
+}
+
+// ListErrors pages through the rows a job skipped, in source order.
+func (s *ImportService) ListErrors(ctx context.Context, request dto.ListImportErrorsRequest) (dto.ImportErrorsPage, error) {
+	job, err := s.Get(ctx, dto.GetImportRequest{ID: request.ID})
+	if err != nil {
+		return dto.ImportErrorsPage{}, err
+	}
+
+	limit := request.Limit
+	if limit == 0 {
+		limit = defaultImportErrorsLimit
+	}
+
+	rows, err := s.importRepo.ListImportJobErrors(ctx, db.ListImportJobErrorsParams{
+		JobID:  request.ID,
+		Limit:  limit,
+		Offset: request.Offset,
+	})
+	if err != nil {
+		helper.LogError(ctx, s.logger, "ImportService.ListErrors", "ListImportJobErrors", "failed to list import errors", err,
+			slog.String("import_id", request.ID.String()),
+		)
+		return dto.ImportErrorsPage{}, errorsPkg.InfrastructureUnexpected.Err()
+	}
+
+	items := make([]dto.ImportRowError, len(rows))
+	for i, row := range rows {
+		items[i] = dto.ImportRowError{Line: row.Line, Error: row.Error}
+	}
+
+	return dto.ImportErrorsPage{
+		Items:  items,
+		Total:  int64(job.FailedRows),
+		Limit:  limit,
+		Offset: request.Offset,
+	}, nil
+}
+
+// Retry queues a failed job again. It picks up after the last committed batch.
+func (s *ImportService) Retry(ctx context.Context, request dto.RetryImportRequest) (db.ImportJob, error) {
+	job, err := s.importRepo.RetryImportJob(ctx, request.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		if _, err := s.Get(ctx, dto.GetImportRequest{ID: request.ID}); err != nil {
+			return db.ImportJob{}, err
+		}
+		return db.ImportJob{}, errorsPkg.ImportJobNotRetryable.Err()
+	}
+	if err != nil {
+		helper.LogError(ctx, s.logger, "ImportService.Retry", "RetryImportJob", "failed to retry import job", err,
+			slog.String("import_id", request.ID.String()),
+		)
+		return db.ImportJob{}, errorsPkg.InfrastructureUnexpected.Err()
+	}
+
+	helper.LogInfo(ctx, s.logger, "ImportService.Retry", "import job queued again",
+		slog.String("import_id", job.ID.String()),
+		slog.Int("processed_rows", int(job.ProcessedRows)),
+	)
+
+	return job, nil
+}
+
+// Run works through queued jobs until none is left, then looks again every interval,
+// until ctx is cancelled.
+func (s *ImportService) Run(ctx context.Context, interval time.Duration) {
+	runPeriodically(ctx, interval, func(ctx context.Context) {
+		for ctx.Err() == nil {
+			if _, ok, err := s.RunNext(ctx, pgtype.UUID{}); !ok || err != nil {
+				return
+			}
+		}
+	})
+}
+
+// RunNext claims a job, the given one when id is valid or else the oldest waiting one,
+// and processes it to the end. ok is false when there was no job to claim. A job that
+// fails is recorded as failed and returned without an error; the error is reserved for
+// a run that was cut short, which the lease hands over to the next runner.
+func (s *ImportService) RunNext(ctx context.Context, id pgtype.UUID) (job db.ImportJob, ok bool, err error) {
+	job, err = s.importRepo.ClaimImportJob(ctx, db.ClaimImportJobParams{
+		LeaseExpiresAt: s.leaseUntil(),
+		ID:             id,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.ImportJob{}, false, nil
+	}
+	if err != nil {
+		helper.LogError(ctx, s.logger, "ImportService.RunNext", "ClaimImportJob", "failed to claim import job", err)
+		return db.ImportJob{}, false, errorsPkg.InfrastructureUnexpected.Err()
+	}
+
+	helper.LogInfo(ctx, s.logger, "ImportService.RunNext", "import job claimed",
+		slog.String("import_id", job.ID.String()),
+		slog.Int("processed_rows", int(job.ProcessedRows)),
+		slog.Int("total_rows", int(job.TotalRows)),
+		slog.Int("attempt", int(job.Attempts)),
+	)
+
+	job, err = s.process(ctx, job)
+	return job, true, err
+}
+
+func (s *ImportService) process(ctx context.Context, job db.ImportJob) (db.ImportJob, error) {
+	data, err := s.importRepo.GetImportJobPayload(ctx, job.ID)
+	if err != nil {
+		helper.LogError(ctx, s.logger, "ImportService.process", "GetImportJobPayload", "failed to load import file", err,
+			slog.String("import_id", job.ID.String()),
+		)
+		return s.finish(ctx, job, ImportStatusFailed, errorsPkg.InfrastructureUnexpected)
+	}
+
+	rows, err := wordimport.Parse(wordimport.Format(job.Format), data)
+	if err != nil || len(rows) != int(job.TotalRows) {
+		helper.LogError(ctx, s.logger, "ImportService.process", "Parse", "import file no longer parses as queued", err,
+			slog.String("import_id", job.ID.String()),
+		)
+		return s.finish(ctx, job, ImportStatusFailed, errorsPkg.ImportFileInvalid)
+	}
+
+	for from := int(job.ProcessedRows); from < len(rows); from += s.batchSize {
+		batch := rows[from:min(from+s.batchSize, len(rows))]
+
+		var next db.ImportJob
+		err := s.txRunner.InTx(ctx, func(tx db.Tx) error {
+			var err error
+			next, err = s.importBatch(ctx, tx, job, from, batch)
+			return err
+		})
+		switch {
+		case err == nil:
+			job = next
+		case errors.Is(err, errLeaseLost):
+			helper.LogInfo(ctx, s.logger, "ImportService.process", "import job taken over by another runner",
+				slog.String("import_id", job.ID.String()),
+			)
+			return job, nil
+		case ctx.Err() != nil:
+			return job, ctx.Err()
+		default:
+			helper.LogError(ctx, s.logger, "ImportService.process", "InTx", "failed to import batch", err,
+				slog.String("import_id", job.ID.String()),
+				slog.Int("from_row", from),
+			)
+			return s.finish(ctx, job, ImportStatusFailed, errorsPkg.InfrastructureUnexpected)
+		}
+	}
+
+	return s.finish(ctx, job, ImportStatusCompleted, "")
+}
+
+// importBatch imports rows[from:] of the job and moves its checkpoint past them.
+func (s *ImportService) importBatch(ctx context.Context, tx db.Tx, job db.ImportJob, from int, rows []wordimport.Row) (db.ImportJob, error) {
+	var created, existing, failed int32
+	if job.WordSetID.Valid {
+		// Appends number items from MAX(position); hold the set until the batch commits.
+		if _, err := tx.WordSets().LockWordSet(ctx, job.WordSetID); err != nil {
+			return db.ImportJob{}, err
+		}
+	}
+	for _, row := range rows {
+		params, rowErr := s.wordParams(job, row)
+		if rowErr != nil {
+			body, err := json.Marshal(rowErr)
+			if err != nil {
+				return db.ImportJob{}, err
+			}
+			err = tx.Imports().CreateImportJobError(ctx, db.CreateImportJobErrorParams{
+				JobID: job.ID,
+				Line:  int32(row.Line),
+				Error: body,
+			})
+			if err != nil {
+				return db.ImportJob{}, err
+			}
+			failed++
+			continue
+		}
+
+		word, err := tx.Words().CreateWordIfMissing(ctx, params)
+		switch {
+		case err == nil:
+			created++
+		case errors.Is(err, pgx.ErrNoRows):
+			word, err = tx.Words().GetWordByLemma(ctx, db.GetWordByLemmaParams{
+				Lemma:      params.Lemma,
+				SourceLang: params.SourceLang,
+				TargetLang: params.TargetLang,
+			})
+			if err != nil {
+				return db.ImportJob{}, err
+			}
+			existing++
+		default:
+			return db.ImportJob{}, err
+		}
+
+		if job.WordSetID.Valid {
+			_, err := tx.WordSets().AddWordSetItemIfMissing(ctx, db.AddWordSetItemIfMissingParams{
+				WordSetID: job.WordSetID,
+				WordID:    word.ID,
+			})
+			if err != nil {
+				return db.ImportJob{}, err
+			}
+		}
+	}
+
+	next, err := tx.Imports().CheckpointImportJob(ctx, db.CheckpointImportJobParams{
+		ProcessedRows:  int32(from + len(rows)),
+		CreatedWords:   created,
+		ExistingWords:  existing,
+		FailedRows:     failed,
+		LeaseExpiresAt: s.leaseUntil(),
+		ID:             job.ID,
+		FromRow:        int32(from),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return db.ImportJob{}, errLeaseLost
+	}
+	return next, err
+}
+
+// wordParams applies the rules of CreateWordRequest to a row. Blank part of speech and
+// difficulty fall back to the defaults of the manual form. A rejected row comes back
+// as IMPORT_ROW_INVALID with the source line and lemma as args and the failed fields.
+func (s *ImportService) wordParams(job db.ImportJob, row wordimport.Row) (db.CreateWordIfMissingParams, *fault.Fault) {
+	request := dto.CreateWordRequest{
+		Lemma:        row.Lemma,
+		Translation:  row.Translation,
+		PartOfSpeech: strings.ToLower(row.PartOfSpeech),
+		SourceLang:   job.SourceLang,
+		TargetLang:   job.TargetLang,
+		Examples:     row.Examples,
+		Difficulty:   defaultDifficulty,
+	}
+	if request.PartOfSpeech == "" {
+		request.PartOfSpeech = defaultPartOfSpeech
+	}
+
+	args := []*fault.Arg{{K: "line", V: strconv.Itoa(row.Line)}}
+	if row.Lemma != "" {
+		args = append(args, &fault.Arg{K: "lemma", V: row.Lemma})
+	}
+	rowErr := errorsPkg.ImportRowInvalid.Err(args...)
+
+	if row.Difficulty != "" {
+		difficulty, err := strconv.ParseInt(row.Difficulty, 10, 16)
+		if err != nil {
+			rowErr.WithFields(fault.FieldViolation{Field: "difficulty", Tag: "numeric"})
+		} else {
+			request.Difficulty = int16(difficulty)
+		}
+	}
+
+	if err := s.validate.Struct(request); err != nil {
+		var fieldErrs validator.ValidationErrors
+		if !errors.As(err, &fieldErrs) {
+			return db.CreateWordIfMissingParams{}, rowErr
+		}
+		for _, fe := range fieldErrs {
+			rowErr.WithFields(fault.FieldViolation{Field: fe.Field(), Tag: fe.Tag(), Param: fe.Param()})
+		}
+	}
+	if len(rowErr.Fields) > 0 {
+		return db.CreateWordIfMissingParams{}, rowErr
+	}
+
+	return db.CreateWordIfMissingParams{
+		Lemma:        request.Lemma,
+		Translation:  request.Translation,
+		PartOfSpeech: request.PartOfSpeech,
+		SourceLang:   request.SourceLang,
+		TargetLang:   request.TargetLang,
+		Examples:     nonNilExamples(request.Examples),
+		Difficulty:   request.Difficulty,
+	}, nil
+}
+
+// finish records the outcome of a run. The job is returned as it was when that fails,
+// and the lease makes sure somebody looks at it again.
+func (s *ImportService) finish(ctx context.Context, job db.ImportJob, status string, code fault.Code) (db.ImportJob, error) {
+	finished, err := s.importRepo.FinishImportJob(ctx, db.FinishImportJobParams{
+		Status:    status,
+		ErrorCode: optionalString(string(code)),
+		ID:        job.ID,
+	})
+	if err != nil {
+		helper.LogError(ctx, s.logger, "ImportService.finish", "FinishImportJob", "failed to finish import job", err,
+			slog.String("import_id", job.ID.String()),
+			slog.String("status", status),
+		)
+		return job, errorsPkg.InfrastructureUnexpected.Err()
+	}
+
+	helper.LogInfo(ctx, s.logger, "ImportService.finish", "import job finished",
+		slog.String("import_id", finished.ID.String()),
+		slog.String("status", finished.Status),
+		slog.Int("created_words", int(finished.CreatedWords)),
+		slog.Int("existing_words", int(finished.ExistingWords)),
+		slog.Int("failed_rows", int(finished.FailedRows)),
+	)
+
+	return finished, nil
+}
+
+func (s *ImportService) leaseUntil() pgtype.Timestamptz {
+	return pgtype.Timestamptz{Time: time.Now().Add(s.lease), Valid: true}
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"test-http/internal/db"
+	"test-http/internal/db/mocks"
+	"test-http/internal/dto"
+	errorsPkg "test-http/pkg/errors_pkg"
+	"test-http/pkg/fault"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const testImportCSV = "lemma,translation,part_of_speech,difficulty\n" +
+	"house,дом,noun,2\n" +
+	"House,дом,,\n" +
+	"run,,verb,hard\n"
+
+// newImportTestValidator reports fields under their JSON names, like handlers.NewValidator.
+func newImportTestValidator() *validator.Validate {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		return name
+	})
+	return validate
+}
+
+type importMocks struct {
+	imports  *mocks.MockImportRepo
+	words    *mocks.MockWordRepo
+	wordSets *mocks.MockWordSetRepo
+	svc      *ImportService
+}
+
+func newImportMocks(ctrl *gomock.Controller, batchSize int) importMocks {
+	m := importMocks{
+		imports:  mocks.NewMockImportRepo(ctrl),
+		words:    mocks.NewMockWordRepo(ctrl),
+		wordSets: mocks.NewMockWordSetRepo(ctrl),
+	}
+	tx := mocks.NewMockTx(ctrl)
+	tx.EXPECT().Imports().Return(m.imports).AnyTimes()
+	tx.EXPECT().Words().Return(m.words).AnyTimes()
+	tx.EXPECT().WordSets().Return(m.wordSets).AnyTimes()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	m.svc = NewImportService(m.imports, inTx(ctrl, tx), newImportTestValidator(), time.Minute, batchSize, logger)
+	return m
+}
+
+func testImportJob(processed int32) db.ImportJob {
+	return db.ImportJob{
+		ID:            pgtype.UUID{Bytes: [16]byte{1}, Valid: true},
+		Format:        "csv",
+		SourceLang:    "en",
+		TargetLang:    "ru",
+		WordSetID:     pgtype.UUID{Bytes: [16]byte{2}, Valid: true},
+		Status:        ImportStatusRunning,
+		TotalRows:     3,
+		ProcessedRows: processed,
+	}
+}
+
+func TestImportService_Create_RejectsUnparsableFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := newImportMocks(ctrl, 10)
+
+	_, err := m.svc.Create(context.Background(), dto.CreateImportRequest{
+		Filename:   "words.csv",
+		Format:     "csv",
+		SourceLang: "en",
+		TargetLang: "ru",
+		Data:       []byte("word,meaning\nhouse,дом\n"),
+	})
+	if err == nil || err.Error() != string(errorsPkg.ImportFileInvalid) {
+		t.Fatalf("expected %s, got %v", errorsPkg.ImportFileInvalid, err)
+	}
+}
+
+func TestImportService_RunNext_ImportsInBatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := newImportMocks(ctrl, 2)
+	job := testImportJob(0)
+	house := db.Word{ID: pgtype.UUID{Bytes: [16]byte{3}, Valid: true}, Lemma: "house"}
+
+	m.imports.EXPECT().ClaimImportJob(gomock.Any(), gomock.Any()).Return(job, nil)
+	m.imports.EXPECT().GetImportJobPayload(gomock.Any(), job.ID).Return([]byte(testImportCSV), nil)
+	m.wordSets.EXPECT().LockWordSet(gomock.Any(), job.WordSetID).Return(job.WordSetID, nil).Times(2)
+
+	// Batch one: a new word, then the same lemma in another case, which dedupes.
+	m.words.EXPECT().CreateWordIfMissing(gomock.Any(), db.CreateWordIfMissingParams{
+		Lemma: "house", Translation: "дом", PartOfSpeech: "noun", SourceLang: "en", TargetLang: "ru",
+		Examples: []string{}, Difficulty: 2,
+	}).Return(house, nil)
+	m.words.EXPECT().CreateWordIfMissing(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, arg db.CreateWordIfMissingParams) (db.Word, error) {
+			if arg.PartOfSpeech != defaultPartOfSpeech || arg.Difficulty != defaultDifficulty {
+				t.Errorf("blank columns not defaulted: %+v", arg)
+			}
+			return db.Word{}, pgx.ErrNoRows
+		})
+	m.words.EXPECT().GetWordByLemma(gomock.Any(), db.GetWordByLemmaParams{Lemma: "House", SourceLang: "en", TargetLang: "ru"}).
+		Return(house, nil)
+	m.wordSets.EXPECT().AddWordSetItemIfMissing(gomock.Any(), db.AddWordSetItemIfMissingParams{WordSetID: job.WordSetID, WordID: house.ID}).
+		Return(int64(1), nil)
+	m.wordSets.EXPECT().AddWordSetItemIfMissing(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+	m.imports.EXPECT().CheckpointImportJob(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, arg db.CheckpointImportJobParams) (db.ImportJob, error) {
+			if arg.FromRow != 0 || arg.ProcessedRows != 2 || arg.CreatedWords != 1 || arg.ExistingWords != 1 || arg.FailedRows != 0 {
+				t.Errorf("first checkpoint = %+v", arg)
+			}
+			return testImportJob(2), nil
+		})
+
+	// Batch two: a row without a translation and with a non-numeric difficulty.
+	m.imports.EXPECT().CreateImportJobError(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, arg db.CreateImportJobErrorParams) error {
+			var f fault.Fault
+			if err := json.Unmarshal(arg.Error, &f); err != nil {
+				t.Fatalf("row error is not a fault: %v", err)
+			}
+			if arg.Line != 4 || f.Code != string(errorsPkg.ImportRowInvalid) || f.Args["line"] != "4" || f.Args["lemma"] != "run" {
+				t.Errorf("row error = line %d, %+v", arg.Line, f)
+			}
+			if len(f.Fields) != 2 || f.Fields[0].Field != "difficulty" || f.Fields[1].Field != "translation" || f.Fields[1].Tag != "required" {
+				t.Errorf("row error fields = %+v", f.Fields)
+			}
+			return nil
+		})
+	m.imports.EXPECT().CheckpointImportJob(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, arg db.CheckpointImportJobParams) (db.ImportJob, error) {
+			if arg.FromRow != 2 || arg.ProcessedRows != 3 || arg.FailedRows != 1 {
+				t.Errorf("second checkpoint = %+v", arg)
+			}
+			return testImportJob(3), nil
+		})
+	m.imports.EXPECT().FinishImportJob(gomock.Any(), db.FinishImportJobParams{Status: ImportStatusCompleted, ID: job.ID}).
+		Return(db.ImportJob{ID: job.ID, Status: ImportStatusCompleted}, nil)
+
+	got, ok, err := m.svc.RunNext(context.Background(), pgtype.UUID{})
+	if err != nil || !ok || got.Status != ImportStatusCompleted {
+		t.Fatalf("RunNext = %+v, %v, %v; want a completed job", got, ok, err)
+	}
+}
+
+func TestImportService_RunNext_ResumesAfterCheckpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := newImportMocks(ctrl, 2)
+	job := testImportJob(3)
+	job.TotalRows = 4
+
+	m.imports.EXPECT().ClaimImportJob(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, arg db.ClaimImportJobParams) (db.ImportJob, error) {
+			if arg.ID != job.ID || time.Until(arg.LeaseExpiresAt.Time) <= 0 {
+				t.Errorf("claim = %+v, want job %s with a lease", arg, job.ID)
+			}
+			return job, nil
+		})
+	m.imports.EXPECT().GetImportJobPayload(gomock.Any(), job.ID).Return([]byte(testImportCSV+"tree,дерево\n"), nil)
+	m.wordSets.EXPECT().LockWordSet(gomock.Any(), job.WordSetID).Return(job.WordSetID, nil)
+	m.words.EXPECT().CreateWordIfMissing(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, arg db.CreateWordIfMissingParams) (db.Word, error) {
+			if arg.Lemma != "tree" {
+				t.Errorf("re-imported row %q before the checkpoint", arg.Lemma)
+			}
+			return db.Word{Lemma: arg.Lemma}, nil
+		})
+	m.wordSets.EXPECT().AddWordSetItemIfMissing(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+	// Another runner took the job over meanwhile: the batch is rolled back and left to it.
+	m.imports.EXPECT().CheckpointImportJob(gomock.Any(), gomock.Any()).Return(db.ImportJob{}, pgx.ErrNoRows)
+
+	got, ok, err := m.svc.RunNext(context.Background(), job.ID)
+	if err != nil || !ok || got.Status != ImportStatusRunning {
+		t.Fatalf("RunNext = %+v, %v, %v; want the job left running", got, ok, err)
+	}
+}
+
+func TestImportService_Retry_NotFailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := newImportMocks(ctrl, 10)
+	id := pgtype.UUID{Bytes: [16]byte{1}, Valid: true}
+
+	m.imports.EXPECT().RetryImportJob(gomock.Any(), id).Return(db.ImportJob{}, pgx.ErrNoRows)
+	m.imports.EXPECT().GetImportJob(gomock.Any(), id).Return(db.ImportJob{ID: id, Status: ImportStatusCompleted}, nil)
+
+	_, err := m.svc.Retry(context.Background(), dto.RetryImportRequest{ID: id})
+	if err == nil || err.Error() != string(errorsPkg.ImportJobNotRetryable) {
+		t.Fatalf("expected %s, got %v", errorsPkg.ImportJobNotRetryable, err)
+	}
+}
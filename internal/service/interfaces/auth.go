@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+	"test-http/internal/dto"
+)
+
+type AuthService interface {
+	Register(ctx context.Context, request dto.RegisterRequest) (dto.RegisterResponse, error)
+	Login(ctx context.Context, request dto.LoginRequest) (dto.AuthTokens, error)
+	Refresh(ctx context.Context, request dto.RefreshRequest) (dto.AuthTokens, error)
+	Logout(ctx context.Context, request dto.LogoutRequest) error
+}
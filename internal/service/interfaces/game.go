@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+	"test-http/internal/dto"
+)
+
+type GameService interface {
+	Start(ctx context.Context, request dto.StartGameRequest) (dto.StartGameResponse, error)
+	Next(ctx context.Context, request dto.GetGameQuestionRequest) (dto.GameQuestion, error)
+	Answer(ctx context.Context, request dto.AnswerGameRequest) (dto.AnswerGameResponse, error)
+	Finish(ctx context.Context, request dto.FinishGameRequest) (dto.GameSummary, error)
+}
@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"context"
+	"test-http/internal/db"
+	"test-http/internal/dto"
+)
+
+type ReviewService interface {
+	Due(ctx context.Context, request dto.ListDueReviewsRequest) ([]db.ListDueReviewsRow, error)
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+	"test-http/internal/db"
+	"test-http/internal/dto"
+)
+
+type WordService interface {
+	Create(ctx context.Context, request dto.CreateWordRequest) (db.Word, error)
+	GetByID(ctx context.Context, request dto.GetWordRequest) (db.Word, error)
+	Resolve(ctx context.Context, request dto.ResolveWordsRequest) ([]db.Word, error)
+	Search(ctx context.Context, request dto.SearchWordsRequest) ([]db.Word, error)
+	Update(ctx context.Context, request dto.UpdateWordRequest) (db.Word, error)
+	Delete(ctx context.Context, request dto.DeleteWordRequest) error
+}
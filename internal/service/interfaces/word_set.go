@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+	"test-http/internal/db"
+	"test-http/internal/dto"
+)
+
+type WordSetService interface {
+	Create(ctx context.Context, request dto.CreateWordSetRequest) (db.WordSet, error)
+	GetByID(ctx context.Context, request dto.GetWordSetRequest) (db.WordSet, error)
+	List(ctx context.Context, request dto.ListWordSetsRequest) ([]db.WordSet, error)
+	Update(ctx context.Context, request dto.UpdateWordSetRequest) (db.WordSet, error)
+	Delete(ctx context.Context, request dto.DeleteWordSetRequest) error
+	AddWord(ctx context.Context, request dto.AddWordSetItemRequest) (db.WordSetItem, error)
+	RemoveWord(ctx context.Context, request dto.RemoveWordSetItemRequest) error
+	Reorder(ctx context.Context, request dto.ReorderWordSetItemsRequest) error
+	ListItems(ctx context.Context, request dto.ListWordSetItemsRequest) (dto.WordSetItemsPage, error)
+}
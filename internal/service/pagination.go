@@ -0,0 +1,81 @@
+package service
+
+import (
+	"slices"
+	"time"
+
+	"test-http/internal/dto"
+	"test-http/pkg/cursor"
+	errorsPkg "test-http/pkg/errors_pkg"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Cursor scopes keep a token issued by one listing from being replayed against another.
+const (
+	cursorScopeUsers          = "users"
+	cursorScopeUserSessions   = "user_sessions"
+	cursorScopeUserProgress   = "user_progress"
+	cursorScopeUserStatistics = "user_statistics"
+)
+
+// keysetPosition is a decoded cursor in the form the keyset queries take.
+type keysetPosition struct {
+	At   pgtype.Timestamptz
+	ID   pgtype.UUID
+	Prev bool
+}
+
+// decodeCursor returns nil for an empty token, meaning the listing falls back to Offset.
+func decodeCursor(codec *cursor.Codec, scope, token string) (*keysetPosition, error) {
+	if token == "" {
+		return nil, nil
+	}
+	c, err := codec.Decode(scope, token)
+	if err != nil {
+		return nil, errorsPkg.CursorInvalid.Err()
+	}
+	id, err := uuid.Parse(c.ID)
+	if err != nil {
+		return nil, errorsPkg.CursorInvalid.Err()
+	}
+	return &keysetPosition{
+		At:   pgtype.Timestamptz{Time: c.At, Valid: true},
+		ID:   pgtype.UUID{Bytes: id, Valid: true},
+		Prev: c.Prev,
+	}, nil
+}
+
+// keysetPage turns up to limit+1 rows into a page; the extra row only signals that
+// another page exists. Rows fetched backwards arrive oldest first and are reversed.
+// key returns the row's sort key and id, which become the cursors at either end.
+func keysetPage[T any](codec *cursor.Codec, scope string, rows []T, limit int32, from *keysetPosition, offset int32, key func(T) (time.Time, pgtype.UUID)) dto.Page[T] {
+	backward := from != nil && from.Prev
+	more := int32(len(rows)) > limit
+	if more {
+		rows = rows[:limit]
+	}
+	if backward {
+		slices.Reverse(rows)
+	}
+
+	page := dto.Page[T]{Items: rows}
+	if len(rows) == 0 {
+		return page
+	}
+
+	hasNext, hasPrev := more, from != nil || offset > 0
+	if backward {
+		hasNext, hasPrev = true, more
+	}
+	if hasNext {
+		at, id := key(rows[len(rows)-1])
+		page.NextCursor = codec.Encode(cursor.Cursor{Scope: scope, At: at, ID: id.String()})
+	}
+	if hasPrev {
+		at, id := key(rows[0])
+		page.PrevCursor = codec.Encode(cursor.Cursor{Scope: scope, At: at, ID: id.String(), Prev: true})
+	}
+	return page
+}
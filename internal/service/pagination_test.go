@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"test-http/internal/db"
+	"test-http/internal/db/mocks"
+	"test-http/internal/dto"
+	"test-http/pkg/cursor"
+	errorsPkg "test-http/pkg/errors_pkg"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var testCursors = cursor.NewCodec([]byte("0123456789abcdef0123456789abcdef"))
+
+func pageUser(b byte, at time.Time) db.User {
+	return db.User{
+		ID:        pgtype.UUID{Bytes: [16]byte{b}, Valid: true},
+		CreatedAt: pgtype.Timestamptz{Time: at, Valid: true},
+	}
+}
+
+func TestUserService_List_WalksCursors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewUserService(mockRepo, testRestorePeriod, testCursors, logger)
+
+	now := time.Date(2025, 10, 5, 12, 0, 0, 0, time.UTC)
+	u1, u2, u3 := pageUser(1, now), pageUser(2, now.Add(-time.Minute)), pageUser(3, now.Add(-2*time.Minute))
+
+	// First page: the look-ahead row is dropped and only a next cursor is issued.
+	active := pgtype.Bool{Bool: true, Valid: true}
+	mockRepo.EXPECT().CountUsers(gomock.Any(), db.CountUsersParams{IsActive: active}).Return(int64(3), nil).Times(3)
+	mockRepo.EXPECT().ListUsers(gomock.Any(), db.ListUsersParams{IsActive: active, Sort: "-created_at", Limit: 3}).Return([]db.User{u1, u2, u3}, nil)
+	first, err := svc.List(context.Background(), dto.ListUsersRequest{Limit: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(first.Items) != 2 || first.NextCursor == "" || first.PrevCursor != "" {
+		t.Fatalf("unexpected first page: %+v", first)
+	}
+
+	// Next page resumes strictly after the last row of the first page.
+	mockRepo.EXPECT().ListUsersAfter(gomock.Any(), db.ListUsersAfterParams{IsActive: active, CreatedAt: u2.CreatedAt, ID: u2.ID, Limit: 3}).
+		Return([]db.User{u3}, nil)
+	second, err := svc.List(context.Background(), dto.ListUsersRequest{Limit: 2, Cursor: first.NextCursor})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(second.Items) != 1 || second.NextCursor != "" || second.PrevCursor == "" {
+		t.Fatalf("unexpected second page: %+v", second)
+	}
+
+	// Going back fetches oldest first and restores newest-first order.
+	mockRepo.EXPECT().ListUsersBefore(gomock.Any(), db.ListUsersBeforeParams{IsActive: active, CreatedAt: u3.CreatedAt, ID: u3.ID, Limit: 3}).
+		Return([]db.User{u2, u1}, nil)
+	back, err := svc.List(context.Background(), dto.ListUsersRequest{Limit: 2, Cursor: second.PrevCursor})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(back.Items) != 2 || back.Items[0].ID != u1.ID || back.PrevCursor != "" || back.NextCursor == "" {
+		t.Fatalf("unexpected previous page: %+v", back)
+	}
+}
+
+func TestUserService_List_RejectsForeignCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewUserService(mockRepo, testRestorePeriod, testCursors, logger)
+
+	sessionCursor := testCursors.Encode(cursor.Cursor{Scope: cursorScopeUserSessions, At: time.Now(), ID: pageUser(1, time.Now()).ID.String()})
+
+	for _, token := range []string{"not-a-cursor", sessionCursor} {
+		_, err := svc.List(context.Background(), dto.ListUsersRequest{Cursor: token})
+		if err == nil || err.Error() != string(errorsPkg.CursorInvalid) {
+			t.Fatalf("cursor %q: expected %s, got %v", token, errorsPkg.CursorInvalid, err)
+		}
+	}
+}
+
+func TestUserService_List_FiltersAndSorts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewUserService(mockRepo, testRestorePeriod, testCursors, logger)
+
+	active := true
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	filter := db.CountUsersParams{
+		UsernamePrefix: pgtype.Text{String: `ann\_`, Valid: true},
+		EmailDomain:    pgtype.Text{String: "example.com", Valid: true},
+		IsActive:       pgtype.Bool{Bool: true, Valid: true},
+		CreatedFrom:    pgtype.Timestamptz{Time: from, Valid: true},
+	}
+	mockRepo.EXPECT().ListUsers(gomock.Any(), db.ListUsersParams{
+		UsernamePrefix: filter.UsernamePrefix,
+		EmailDomain:    filter.EmailDomain,
+		IsActive:       filter.IsActive,
+		CreatedFrom:    filter.CreatedFrom,
+		Sort:           "username",
+		Limit:          2,
+		Offset:         4,
+	}).Return([]db.User{pageUser(1, from), pageUser(2, from)}, nil)
+	mockRepo.EXPECT().CountUsers(gomock.Any(), filter).Return(int64(6), nil)
+
+	got, err := svc.List(context.Background(), dto.ListUsersRequest{
+		UsernamePrefix: "ann_",
+		EmailDomain:    "example.com",
+		IsActive:       &active,
+		CreatedFrom:    &from,
+		Sort:           "username",
+		Limit:          1,
+		Offset:         4,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Orders other than newest first page by offset, so no cursors are issued.
+	if len(got.Items) != 1 || got.Total != 6 || got.NextCursor != "" || got.PrevCursor != "" {
+		t.Fatalf("unexpected page: %+v", got)
+	}
+
+	cursorToken := testCursors.Encode(cursor.Cursor{Scope: cursorScopeUsers, At: from, ID: pageUser(1, from).ID.String()})
+	_, err = svc.List(context.Background(), dto.ListUsersRequest{Sort: "email", Cursor: cursorToken})
+	if err == nil || err.Error() != string(errorsPkg.CursorInvalid) {
+		t.Fatalf("expected %s for a cursor with a non-default sort, got %v", errorsPkg.CursorInvalid, err)
+	}
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"test-http/pkg/helper"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// runPeriodically calls job once immediately and then every interval until ctx is cancelled.
+func runPeriodically(ctx context.Context, interval time.Duration, job func(context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		job(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sweepOlderThan runs sweep once for the rows that reached age and returns how many it
+// touched. fn and op name the caller and the repository method in the logs.
+func sweepOlderThan(ctx context.Context, logger *slog.Logger, fn, op string, age time.Duration,
+	sweep func(context.Context, pgtype.Timestamptz) (int64, error)) (int64, error) {
+	cutoff := time.Now().Add(-age)
+
+	n, err := sweep(ctx, pgtype.Timestamptz{Time: cutoff, Valid: true})
+	if err != nil {
+		helper.LogError(ctx, logger, fn, op, "sweep failed", err,
+			slog.Time("cutoff", cutoff),
+		)
+		return 0, err
+	}
+
+	if n > 0 {
+		helper.LogInfo(ctx, logger, fn, "sweep completed",
+			slog.Int64("count", n),
+			slog.Duration("age", age),
+		)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// expectCutoff stubs a sweep statement: it checks that the cut-off lies age in the past
+// and answers with n and err.
+func expectCutoff(t *testing.T, age time.Duration, n int64, err error) func(context.Context, pgtype.Timestamptz) (int64, error) {
+	t.Helper()
+	return func(_ context.Context, cutoff pgtype.Timestamptz) (int64, error) {
+		if got := time.Since(cutoff.Time); got < age || got > age+time.Minute {
+			t.Errorf("cut-off %s is not %s ago", cutoff.Time, age)
+		}
+		return n, err
+	}
+}
+
+func TestSweepOlderThan(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	repoErr := errors.New("connection reset")
+
+	n, err := sweepOlderThan(context.Background(), logger, "Test", "Sweep", time.Hour, expectCutoff(t, time.Hour, 4, nil))
+	if err != nil || n != 4 {
+		t.Fatalf("got %d, %v; want 4, nil", n, err)
+	}
+
+	n, err = sweepOlderThan(context.Background(), logger, "Test", "Sweep", time.Hour, expectCutoff(t, time.Hour, 4, repoErr))
+	if !errors.Is(err, repoErr) || n != 0 {
+		t.Fatalf("got %d, %v; want 0, %v", n, err, repoErr)
+	}
+}
+
+func TestRunPeriodically_StopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	runs := 0
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		runPeriodically(ctx, time.Millisecond, func(context.Context) {
+			if runs++; runs == 3 {
+				cancel()
+			}
+		})
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("runPeriodically did not return after cancel")
+	}
+	if runs != 3 {
+		t.Fatalf("job ran %d times, want 3", runs)
+	}
+}
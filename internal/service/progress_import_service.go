@@ -0,0 +1,290 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"test-http/internal/db"
+	"test-http/internal/dto"
+	errorsPkg "test-http/pkg/errors_pkg"
+	"test-http/pkg/fault"
+	"test-http/pkg/helper"
+	"test-http/pkg/wordimport"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ProgressImportService replays review logs from other vocabulary apps. Every attempt
+// of a known word goes through RecordUserProgressAttempt at its original time, so the
+// counters, last_attempt and SM-2 schedule come out as if the reviews had happened
+// here; runs of attempts become completed sessions, split like the session reaper
+// would split them. The whole log is applied in one transaction.
+type ProgressImportService struct {
+	txRunner    db.TxRunner
+	idleTimeout time.Duration
+	activityGap time.Duration
+	logger      *slog.Logger
+}
+
+func NewProgressImportService(txRunner db.TxRunner, idleTimeout, activityGap time.Duration, log *slog.Logger) *ProgressImportService {
+	return &ProgressImportService{
+		txRunner:    txRunner,
+		idleTimeout: idleTimeout,
+		activityGap: activityGap,
+		logger:      log,
+	}
+}
+
+// reviewAttempt is a readable row of a review log.
+type reviewAttempt struct {
+	line    int
+	word    string
+	at      time.Time
+	correct bool
+	quality int16
+	wordID  pgtype.UUID
+}
+
+// replaySession is a run of attempts with no pause longer than the idle timeout.
+type replaySession struct {
+	attempts      []reviewAttempt
+	activeSeconds int32
+}
+
+// Import matches the log against the catalog and, unless it is a dry run, records
+// the attempts. Rows that can't be read are reported and left out; so are words
+// missing from the catalog.
+func (s *ProgressImportService) Import(ctx context.Context, request dto.ImportProgressRequest) (dto.ProgressImportReport, error) {
+	helper.LogDebug(ctx, s.logger, "ProgressImportService.Import", "importing progress",
+		slog.String("user_id", request.UserID.String()),
+		slog.Bool("dry_run", request.DryRun),
+		slog.Int("size", len(request.Data)),
+	)
+
+	rows, err := wordimport.ParseReviews(request.Data)
+	if err != nil {
+		return dto.ProgressImportReport{}, errorsPkg.ImportFileInvalid.Err(&fault.Arg{K: "reason", V: err.Error()})
+	}
+	attempts, rowErrors, err := readReviewAttempts(rows, time.Now())
+	if err != nil {
+		helper.LogError(ctx, s.logger, "ProgressImportService.Import", "json.Marshal", "failed to encode row error", err)
+		return dto.ProgressImportReport{}, errorsPkg.InfrastructureUnexpected.Err()
+	}
+
+	var report dto.ProgressImportReport
+	err = s.txRunner.InTx(ctx, func(tx db.Tx) error {
+		report = dto.ProgressImportReport{
+			DryRun:    request.DryRun,
+			Attempts:  len(attempts),
+			Unmatched: []dto.UnmatchedWord{},
+			Errors:    rowErrors,
+		}
+
+		if _, err := tx.Users().GetUser(ctx, request.UserID); err != nil {
+			return err
+		}
+
+		replay, err := s.plan(ctx, tx, request, attempts, &report)
+		if err != nil {
+			return err
+		}
+		sessions := s.sessions(replay)
+		report.Sessions = len(sessions)
+		if request.DryRun {
+			return nil
+		}
+
+		for _, a := range replay {
+			_, err := tx.Progress().RecordUserProgressAttempt(ctx, db.RecordUserProgressAttemptParams{
+				UserID:      request.UserID,
+				WordID:      a.wordID,
+				Correct:     a.correct,
+				AttemptedAt: pgtype.Timestamptz{Time: a.at, Valid: true},
+				Quality:     a.quality,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		for _, session := range sessions {
+			first, last := session.attempts[0], session.attempts[len(session.attempts)-1]
+			created, err := tx.Sessions().CreateCompletedUserSession(ctx, db.CreateCompletedUserSessionParams{
+				UserID:        request.UserID,
+				StartedAt:     pgtype.Timestamptz{Time: first.at, Valid: true},
+				EndedAt:       pgtype.Timestamptz{Time: last.at, Valid: true},
+				ActiveSeconds: session.activeSeconds,
+			})
+			if err != nil {
+				return err
+			}
+			occurredAt := make([]pgtype.Timestamptz, len(session.attempts))
+			for i, a := range session.attempts {
+				occurredAt[i] = pgtype.Timestamptz{Time: a.at, Valid: true}
+			}
+			_, err = tx.Sessions().CreateAnswerSessionEvents(ctx, db.CreateAnswerSessionEventsParams{
+				SessionID:  created.ID,
+				OccurredAt: occurredAt,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if f := repoFault(err, errorsPkg.UserNotFound); f != nil {
+			return dto.ProgressImportReport{}, f
+		}
+		helper.LogError(ctx, s.logger, "ProgressImportService.Import", "InTx", "failed to import progress", err,
+			slog.String("user_id", request.UserID.String()),
+		)
+		return dto.ProgressImportReport{}, errorsPkg.InfrastructureUnexpected.Err()
+	}
+
+	helper.LogInfo(ctx, s.logger, "ProgressImportService.Import", "progress imported",
+		slog.String("user_id", request.UserID.String()),
+		slog.Bool("dry_run", report.DryRun),
+		slog.Int("replayed", report.Replayed),
+		slog.Int("skipped", report.Skipped),
+		slog.Int("unmatched", len(report.Unmatched)),
+		slog.Int("errors", len(report.Errors)),
+	)
+
+	return report, nil
+}
+
+// plan resolves the words of the log and picks the attempts to replay: those of known
+// words made after the word's last recorded attempt. Unmatched words go to the report.
+func (s *ProgressImportService) plan(ctx context.Context, tx db.Tx, request dto.ImportProgressRequest, attempts []reviewAttempt, report *dto.ProgressImportReport) ([]reviewAttempt, error) {
+	var lemmas []string
+	seen := map[string]bool{}
+	for _, a := range attempts {
+		if key := strings.ToLower(a.word); !seen[key] {
+			seen[key] = true
+			lemmas = append(lemmas, key)
+		}
+	}
+	if len(lemmas) == 0 {
+		return nil, nil
+	}
+
+	words, err := tx.Words().ListWordsByLemmas(ctx, db.ListWordsByLemmasParams{
+		Lemmas:     lemmas,
+		SourceLang: request.SourceLang,
+		TargetLang: request.TargetLang,
+	})
+	if err != nil {
+		return nil, err
+	}
+	byLemma := make(map[string]pgtype.UUID, len(words))
+	wordIDs := make([]pgtype.UUID, len(words))
+	for i, w := range words {
+		byLemma[strings.ToLower(w.Lemma)] = w.ID
+		wordIDs[i] = w.ID
+	}
+
+	lastAttempt := map[[16]byte]time.Time{}
+	if len(wordIDs) > 0 {
+		progress, err := tx.Progress().ListUserProgressByWords(ctx, db.ListUserProgressByWordsParams{
+			UserID:  request.UserID,
+			WordIds: wordIDs,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range progress {
+			if p.LastAttempt.Valid {
+				lastAttempt[p.WordID.Bytes] = p.LastAttempt.Time
+			}
+		}
+	}
+
+	var replay []reviewAttempt
+	unmatched := map[string]int{}
+	replayedWords := map[[16]byte]bool{}
+	for _, a := range attempts {
+		id, ok := byLemma[strings.ToLower(a.word)]
+		if !ok {
+			idx, seen := unmatched[strings.ToLower(a.word)]
+			if !seen {
+				idx = len(report.Unmatched)
+				unmatched[strings.ToLower(a.word)] = idx
+				report.Unmatched = append(report.Unmatched, dto.UnmatchedWord{Word: a.word})
+			}
+			report.Unmatched[idx].Attempts++
+			continue
+		}
+		if last, ok := lastAttempt[id.Bytes]; ok && !a.at.After(last) {
+			report.Skipped++
+			continue
+		}
+		a.wordID = id
+		replay = append(replay, a)
+		replayedWords[id.Bytes] = true
+	}
+	report.Replayed = len(replay)
+	report.Words = len(replayedWords)
+	return replay, nil
+}
+
+// sessions splits time-ordered attempts wherever the learner paused for longer than
+// the idle timeout. Only gaps up to the activity gap count as active time, as they do
+// for live sessions.
+func (s *ProgressImportService) sessions(attempts []reviewAttempt) []replaySession {
+	var sessions []replaySession
+	for i, a := range attempts {
+		if i == 0 || a.at.Sub(attempts[i-1].at) > s.idleTimeout {
+			sessions = append(sessions, replaySession{})
+		} else if gap := a.at.Sub(attempts[i-1].at); gap <= s.activityGap {
+			sessions[len(sessions)-1].activeSeconds += int32(gap.Round(time.Second) / time.Second)
+		}
+		sessions[len(sessions)-1].attempts = append(sessions[len(sessions)-1].attempts, a)
+	}
+	return sessions
+}
+
+// readReviewAttempts keeps the rows that can be replayed, oldest first, and turns the
+// rest into IMPORT_ROW_INVALID faults with the line and word as args. Attempts dated
+// after now are rejected: they would hold last_attempt in the future.
+func readReviewAttempts(rows []wordimport.ReviewRow, now time.Time) ([]reviewAttempt, []dto.ImportRowError, error) {
+	var attempts []reviewAttempt
+	rowErrors := []dto.ImportRowError{}
+	for _, row := range rows {
+		args := []*fault.Arg{{K: "line", V: strconv.Itoa(row.Line)}}
+		if row.Word != "" {
+			args = append(args, &fault.Arg{K: "word", V: row.Word})
+		}
+		rowErr := errorsPkg.ImportRowInvalid.Err(args...)
+
+		if row.Word == "" {
+			rowErr.WithFields(fault.FieldViolation{Field: "word", Tag: "required"})
+		}
+		at, err := row.Time()
+		if err != nil {
+			rowErr.WithFields(fault.FieldViolation{Field: "timestamp", Tag: "datetime"})
+		} else if at.After(now) {
+			rowErr.WithFields(fault.FieldViolation{Field: "timestamp", Tag: "lte"})
+		}
+		correct, quality, err := row.Grade()
+		if err != nil {
+			rowErr.WithFields(fault.FieldViolation{Field: "result", Tag: "oneof"})
+		}
+
+		if len(rowErr.Fields) > 0 {
+			body, err := json.Marshal(rowErr)
+			if err != nil {
+				return nil, nil, err
+			}
+			rowErrors = append(rowErrors, dto.ImportRowError{Line: int32(row.Line), Error: body})
+			continue
+		}
+		attempts = append(attempts, reviewAttempt{line: row.Line, word: row.Word, at: at, correct: correct, quality: quality})
+	}
+	sort.SliceStable(attempts, func(i, j int) bool { return attempts[i].at.Before(attempts[j].at) })
+	return attempts, rowErrors, nil
+}
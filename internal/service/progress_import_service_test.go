@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"test-http/internal/db"
+	"test-http/internal/db/mocks"
+	"test-http/internal/dto"
+	errorsPkg "test-http/pkg/errors_pkg"
+	"test-http/pkg/fault"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const testReviewLog = "word,timestamp,result\n" +
+	"house,2024-03-01T10:00:00Z,good\n" +
+	"tree,2024-03-01T10:00:30Z,again\n" +
+	"House,2024-03-01T10:01:00Z,easy\n" +
+	"mystery,2024-03-01T10:01:10Z,good\n" +
+	"tree,2024-03-01T12:00:00Z,good\n" +
+	"mystery,2024-03-01T12:00:05Z,fail\n" +
+	"tree,soon,good\n"
+
+type progressImportMocks struct {
+	users    *mocks.MockUserRepo
+	words    *mocks.MockWordRepo
+	progress *mocks.MockUserProgressRepo
+	sessions *mocks.MockUserSessionRepo
+	svc      *ProgressImportService
+}
+
+func newProgressImportMocks(ctrl *gomock.Controller) progressImportMocks {
+	m := progressImportMocks{
+		users:    mocks.NewMockUserRepo(ctrl),
+		words:    mocks.NewMockWordRepo(ctrl),
+		progress: mocks.NewMockUserProgressRepo(ctrl),
+		sessions: mocks.NewMockUserSessionRepo(ctrl),
+	}
+	tx := mocks.NewMockTx(ctrl)
+	tx.EXPECT().Users().Return(m.users).AnyTimes()
+	tx.EXPECT().Words().Return(m.words).AnyTimes()
+	tx.EXPECT().Progress().Return(m.progress).AnyTimes()
+	tx.EXPECT().Sessions().Return(m.sessions).AnyTimes()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	m.svc = NewProgressImportService(inTx(ctrl, tx), 30*time.Minute, 2*time.Minute, logger)
+	return m
+}
+
+var (
+	testImportUserID = pgtype.UUID{Bytes: [16]byte{9}, Valid: true}
+	testHouseID      = pgtype.UUID{Bytes: [16]byte{1}, Valid: true}
+	testTreeID       = pgtype.UUID{Bytes: [16]byte{2}, Valid: true}
+)
+
+func (m progressImportMocks) expectCatalog(treeLastAttempt time.Time) {
+	m.users.EXPECT().GetUser(gomock.Any(), testImportUserID).Return(db.User{ID: testImportUserID}, nil)
+	m.words.EXPECT().ListWordsByLemmas(gomock.Any(), db.ListWordsByLemmasParams{
+		Lemmas: []string{"house", "tree", "mystery"}, SourceLang: "en", TargetLang: "ru",
+	}).Return([]db.Word{{ID: testHouseID, Lemma: "House"}, {ID: testTreeID, Lemma: "tree"}}, nil)
+	m.progress.EXPECT().ListUserProgressByWords(gomock.Any(), db.ListUserProgressByWordsParams{
+		UserID: testImportUserID, WordIds: []pgtype.UUID{testHouseID, testTreeID},
+	}).Return([]db.UserProgress{{WordID: testTreeID, LastAttempt: pgtype.Timestamptz{Time: treeLastAttempt, Valid: true}}}, nil)
+}
+
+func TestProgressImportService_Import_DryRunWritesNothing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := newProgressImportMocks(ctrl)
+	m.expectCatalog(time.Date(2024, 3, 1, 10, 0, 30, 0, time.UTC))
+
+	report, err := m.svc.Import(context.Background(), dto.ImportProgressRequest{
+		UserID: testImportUserID, SourceLang: "en", TargetLang: "ru", DryRun: true, Data: []byte(testReviewLog),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !report.DryRun || report.Attempts != 6 || report.Replayed != 3 || report.Skipped != 1 || report.Words != 2 || report.Sessions != 2 {
+		t.Fatalf("report = %+v", report)
+	}
+	if len(report.Unmatched) != 1 || report.Unmatched[0] != (dto.UnmatchedWord{Word: "mystery", Attempts: 2}) {
+		t.Fatalf("unmatched = %+v", report.Unmatched)
+	}
+	if len(report.Errors) != 1 || report.Errors[0].Line != 8 {
+		t.Fatalf("errors = %+v", report.Errors)
+	}
+	var f fault.Fault
+	if err := json.Unmarshal(report.Errors[0].Error, &f); err != nil || f.Code != string(errorsPkg.ImportRowInvalid) ||
+		len(f.Fields) != 1 || f.Fields[0].Field != "timestamp" {
+		t.Fatalf("row error = %+v, %v", f, err)
+	}
+}
+
+func TestProgressImportService_Import_ReplaysAttemptsAndSessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := newProgressImportMocks(ctrl)
+	// tree was already answered at 10:00:30, so only its later attempt is new.
+	m.expectCatalog(time.Date(2024, 3, 1, 10, 0, 30, 0, time.UTC))
+
+	at := func(h, min, sec int) pgtype.Timestamptz {
+		return pgtype.Timestamptz{Time: time.Date(2024, 3, 1, h, min, sec, 0, time.UTC), Valid: true}
+	}
+	gomock.InOrder(
+		m.progress.EXPECT().RecordUserProgressAttempt(gomock.Any(), db.RecordUserProgressAttemptParams{
+			UserID: testImportUserID, WordID: testHouseID, Correct: true, AttemptedAt: at(10, 0, 0), Quality: 4,
+		}).Return(db.UserProgress{}, nil),
+		m.progress.EXPECT().RecordUserProgressAttempt(gomock.Any(), db.RecordUserProgressAttemptParams{
+			UserID: testImportUserID, WordID: testHouseID, Correct: true, AttemptedAt: at(10, 1, 0), Quality: 5,
+		}).Return(db.UserProgress{}, nil),
+		m.progress.EXPECT().RecordUserProgressAttempt(gomock.Any(), db.RecordUserProgressAttemptParams{
+			UserID: testImportUserID, WordID: testTreeID, Correct: true, AttemptedAt: at(12, 0, 0), Quality: 4,
+		}).Return(db.UserProgress{}, nil),
+	)
+
+	// The two-hour pause splits the log; the minute between the house answers counts as active time.
+	first := pgtype.UUID{Bytes: [16]byte{0xa}, Valid: true}
+	second := pgtype.UUID{Bytes: [16]byte{0xb}, Valid: true}
+	m.sessions.EXPECT().CreateCompletedUserSession(gomock.Any(), db.CreateCompletedUserSessionParams{
+		UserID: testImportUserID, StartedAt: at(10, 0, 0), EndedAt: at(10, 1, 0), ActiveSeconds: 60,
+	}).Return(db.UserSession{ID: first}, nil)
+	m.sessions.EXPECT().CreateAnswerSessionEvents(gomock.Any(), db.CreateAnswerSessionEventsParams{
+		SessionID: first, OccurredAt: []pgtype.Timestamptz{at(10, 0, 0), at(10, 1, 0)},
+	}).Return(int64(2), nil)
+	m.sessions.EXPECT().CreateCompletedUserSession(gomock.Any(), db.CreateCompletedUserSessionParams{
+		UserID: testImportUserID, StartedAt: at(12, 0, 0), EndedAt: at(12, 0, 0),
+	}).Return(db.UserSession{ID: second}, nil)
+	m.sessions.EXPECT().CreateAnswerSessionEvents(gomock.Any(), db.CreateAnswerSessionEventsParams{
+		SessionID: second, OccurredAt: []pgtype.Timestamptz{at(12, 0, 0)},
+	}).Return(int64(1), nil)
+
+	report, err := m.svc.Import(context.Background(), dto.ImportProgressRequest{
+		UserID: testImportUserID, SourceLang: "en", TargetLang: "ru", Data: []byte(testReviewLog),
+	})
+	if err != nil || report.DryRun || report.Replayed != 3 || report.Sessions != 2 {
+		t.Fatalf("Import = %+v, %v", report, err)
+	}
+}
+
+func TestProgressImportService_Import_UnknownUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := newProgressImportMocks(ctrl)
+	m.users.EXPECT().GetUser(gomock.Any(), testImportUserID).Return(db.User{}, pgx.ErrNoRows)
+
+	_, err := m.svc.Import(context.Background(), dto.ImportProgressRequest{
+		UserID: testImportUserID, SourceLang: "en", TargetLang: "ru", DryRun: true, Data: []byte(testReviewLog),
+	})
+	if err == nil || err.Error() != string(errorsPkg.UserNotFound) {
+		t.Fatalf("expected %s, got %v", errorsPkg.UserNotFound, err)
+	}
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	errorsPkg "test-http/pkg/errors_pkg"
+	"test-http/pkg/fault"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// SQLSTATE codes of the integrity violations the API reports to the caller.
+const (
+	pgNotNullViolation    = "23502"
+	pgForeignKeyViolation = "23503"
+	pgUniqueViolation     = "23505"
+	pgCheckViolation      = "23514"
+)
+
+// repoFault classifies a repository error caused by the request rather than
+// by the infrastructure: a missing row becomes notFound, integrity violations
+// become CONFLICT, REFERENCE_MISSING or VALIDATION_ERROR with "constraint" and
+// "field" args. It returns nil for anything else, which callers log and report
+// as InfrastructureUnexpected.
+func repoFault(err error, notFound fault.Code) *fault.Fault {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return notFound.Err()
+	}
+	return integrityFault(err)
+}
+
+// integrityFault is the integrity-violation half of repoFault.
+func integrityFault(err error) *fault.Fault {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return nil
+	}
+
+	var code fault.Code
+	switch pgErr.Code {
+	case pgUniqueViolation:
+		code = errorsPkg.Conflict
+	case pgForeignKeyViolation:
+		code = errorsPkg.ReferenceMissing
+	case pgCheckViolation, pgNotNullViolation:
+		code = errorsPkg.ValidationError
+	default:
+		return nil
+	}
+
+	var args []*fault.Arg
+	if pgErr.ConstraintName != "" {
+		args = append(args, &fault.Arg{K: "constraint", V: pgErr.ConstraintName})
+	}
+	if field := violatedField(pgErr); field != "" {
+		args = append(args, &fault.Arg{K: "field", V: field})
+	}
+	return code.Err(args...)
+}
+
+// violatedField names the column(s) behind a violation. Postgres reports the
+// column directly only for NOT NULL; unique and foreign-key violations carry it
+// in the detail ("Key (user_id, word_id)=(...) ..."), and CHECK constraints are
+// named "<table>_<column>_check" unless declared otherwise.
+func violatedField(pgErr *pgconn.PgError) string {
+	if pgErr.ColumnName != "" {
+		return pgErr.ColumnName
+	}
+
+	if rest, ok := strings.CutPrefix(pgErr.Detail, "Key ("); ok {
+		if cols, _, ok := strings.Cut(rest, ")="); ok {
+			return strings.ReplaceAll(cols, " ", "")
+		}
+	}
+
+	if pgErr.Code == pgCheckViolation && pgErr.TableName != "" {
+		name, ok := strings.CutPrefix(pgErr.ConstraintName, pgErr.TableName+"_")
+		if !ok {
+			return ""
+		}
+		if column, ok := strings.CutSuffix(name, "_check"); ok {
+			return column
+		}
+	}
+	return ""
+}
+
+// txError maps an error returned by TxRunner.InTx. Transaction bodies return
+// faults for request errors and the raw, already logged repository error
+// otherwise, so that the runner can recognise and replay serialization failures.
+// Integrity violations in the raw error are classified as repoFault does.
+func txError(err error) error {
+	var f *fault.Fault
+	if errors.As(err, &f) {
+		return f
+	}
+	if f := integrityFault(err); f != nil {
+		return f
+	}
+	return errorsPkg.InfrastructureUnexpected.Err()
+}
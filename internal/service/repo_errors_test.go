@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"test-http/internal/db"
+	"test-http/internal/db/mocks"
+	errorsPkg "test-http/pkg/errors_pkg"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// inTx returns a TxRunner that runs every transaction body once against tx.
+func inTx(ctrl *gomock.Controller, tx db.Tx) *mocks.MockTxRunner {
+	runner := mocks.NewMockTxRunner(ctrl)
+	runner.EXPECT().InTx(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, fn func(db.Tx) error) error { return fn(tx) },
+	).AnyTimes()
+	return runner
+}
+
+func TestRepoFault(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code string
+		args map[string]string
+	}{
+		{
+			name: "no rows",
+			err:  fmt.Errorf("get user: %w", pgx.ErrNoRows),
+			code: string(errorsPkg.UserNotFound),
+			args: map[string]string{},
+		},
+		{
+			name: "unique violation",
+			err: &pgconn.PgError{
+				Code:           pgUniqueViolation,
+				TableName:      "user_progress",
+				ConstraintName: "user_progress_user_id_word_id_key",
+				Detail:         "Key (user_id, word_id)=(a, b) already exists.",
+			},
+			code: string(errorsPkg.Conflict),
+			args: map[string]string{"constraint": "user_progress_user_id_word_id_key", "field": "user_id,word_id"},
+		},
+		{
+			name: "foreign key violation",
+			err: &pgconn.PgError{
+				Code:           pgForeignKeyViolation,
+				TableName:      "word_set_items",
+				ConstraintName: "word_set_items_word_id_fkey",
+				Detail:         `Key (word_id)=(c) is not present in table "words".`,
+			},
+			code: string(errorsPkg.ReferenceMissing),
+			args: map[string]string{"constraint": "word_set_items_word_id_fkey", "field": "word_id"},
+		},
+		{
+			name: "check violation",
+			err: &pgconn.PgError{
+				Code:           pgCheckViolation,
+				TableName:      "word_sets",
+				ConstraintName: "word_sets_visibility_check",
+			},
+			code: string(errorsPkg.ValidationError),
+			args: map[string]string{"constraint": "word_sets_visibility_check", "field": "visibility"},
+		},
+		{
+			name: "not null violation",
+			err:  &pgconn.PgError{Code: pgNotNullViolation, TableName: "words", ColumnName: "lemma"},
+			code: string(errorsPkg.ValidationError),
+			args: map[string]string{"field": "lemma"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := repoFault(tt.err, errorsPkg.UserNotFound)
+			if f == nil {
+				t.Fatalf("expected %s, got nil", tt.code)
+			}
+			if f.Code != tt.code {
+				t.Fatalf("expected %s, got %s", tt.code, f.Code)
+			}
+			if fmt.Sprint(f.Args) != fmt.Sprint(tt.args) {
+				t.Fatalf("expected args %v, got %v", tt.args, f.Args)
+			}
+		})
+	}
+}
+
+func TestRepoFault_Infrastructure(t *testing.T) {
+	for _, err := range []error{
+		errors.New("connection reset"),
+		&pgconn.PgError{Code: "40001"},
+	} {
+		if f := repoFault(err, errorsPkg.UserNotFound); f != nil {
+			t.Fatalf("expected %v to stay unclassified, got %s", err, f.Code)
+		}
+	}
+}
+
+func TestTxError(t *testing.T) {
+	if err := txError(errorsPkg.GameRoundClosed.Err()); err.Error() != string(errorsPkg.GameRoundClosed) {
+		t.Fatalf("expected fault to pass through, got %v", err)
+	}
+	if err := txError(&pgconn.PgError{Code: "40001"}); err.Error() != string(errorsPkg.InfrastructureUnexpected) {
+		t.Fatalf("expected %s, got %v", errorsPkg.InfrastructureUnexpected, err)
+	}
+	unique := fmt.Errorf("create session: %w", &pgconn.PgError{Code: pgUniqueViolation, ConstraintName: "user_sessions_one_active_per_user"})
+	if err := txError(unique); err.Error() != string(errorsPkg.Conflict) {
+		t.Fatalf("expected %s, got %v", errorsPkg.Conflict, err)
+	}
+	if err := txError(&pgconn.PgError{Code: pgForeignKeyViolation}); err.Error() != string(errorsPkg.ReferenceMissing) {
+		t.Fatalf("expected %s, got %v", errorsPkg.ReferenceMissing, err)
+	}
+}
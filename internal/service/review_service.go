@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"test-http/internal/db"
+	"test-http/internal/dto"
+	errorsPkg "test-http/pkg/errors_pkg"
+	"test-http/pkg/helper"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const defaultDueReviewsLimit = 20
+
+// SM-2 grades recorded for binary answers. The scheduler itself runs inside
+// RecordUserProgressAttempt so counters and due dates change atomically.
+const (
+	ReviewQualityCorrect   int16 = 4
+	ReviewQualityIncorrect int16 = 1
+)
+
+// reviewQuality maps a right/wrong answer onto an SM-2 grade.
+func reviewQuality(correct bool) int16 {
+	if correct {
+		return ReviewQualityCorrect
+	}
+	return ReviewQualityIncorrect
+}
+
+type ReviewService struct {
+	progressRepo db.UserProgressRepo
+	logger       *slog.Logger
+}
+
+func NewReviewService(progressRepo db.UserProgressRepo, log *slog.Logger) *ReviewService {
+	return &ReviewService{
+		progressRepo: progressRepo,
+		logger:       log,
+	}
+}
+
+// Due returns the user's words whose review is due, most overdue first.
+func (s *ReviewService) Due(ctx context.Context, request dto.ListDueReviewsRequest) ([]db.ListDueReviewsRow, error) {
+	at := request.At
+	if at.IsZero() {
+		at = time.Now()
+	}
+	limit := request.Limit
+	if limit == 0 {
+		limit = defaultDueReviewsLimit
+	}
+
+	helper.LogDebug(ctx, s.logger, "ReviewService.Due", "listing due reviews",
+		slog.String("user_id", request.UserID.String()),
+		slog.Time("at", at),
+		slog.Int("limit", int(limit)),
+	)
+
+	rows, err := s.progressRepo.ListDueReviews(ctx, db.ListDueReviewsParams{
+		UserID:    request.UserID,
+		DueBefore: pgtype.Timestamptz{Time: at, Valid: true},
+		Limit:     limit,
+	})
+	if err != nil {
+		helper.LogError(ctx, s.logger, "ReviewService.Due", "ListDueReviews", "failed to list due reviews", err,
+			slog.String("user_id", request.UserID.String()),
+		)
+		return nil, errorsPkg.InfrastructureUnexpected.Err()
+	}
+
+	return rows, nil
+}
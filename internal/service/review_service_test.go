@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	db "test-http/internal/db"
+	mockdb "test-http/internal/db/mocks"
+	"test-http/internal/dto"
+	errorsPkg "test-http/pkg/errors_pkg"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestReviewService_Due_Defaults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockdb.NewMockUserProgressRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewReviewService(mockRepo, logger)
+
+	var uid pgtype.UUID
+	want := []db.ListDueReviewsRow{{UserProgress: db.UserProgress{UserID: uid}}}
+	before := time.Now()
+
+	mockRepo.EXPECT().ListDueReviews(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg db.ListDueReviewsParams) ([]db.ListDueReviewsRow, error) {
+			if arg.Limit != defaultDueReviewsLimit {
+				t.Fatalf("limit = %d, want %d", arg.Limit, defaultDueReviewsLimit)
+			}
+			if !arg.DueBefore.Valid || arg.DueBefore.Time.Before(before) {
+				t.Fatalf("due_before = %+v, want now", arg.DueBefore)
+			}
+			return want, nil
+		})
+
+	got, err := svc.Due(context.Background(), dto.ListDueReviewsRequest{UserID: uid})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d want %d", len(got), len(want))
+	}
+}
+
+func TestReviewService_Due_ExplicitTime(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockdb.NewMockUserProgressRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewReviewService(mockRepo, logger)
+
+	var uid pgtype.UUID
+	at := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	mockRepo.EXPECT().ListDueReviews(gomock.Any(), db.ListDueReviewsParams{
+		UserID:    uid,
+		DueBefore: pgtype.Timestamptz{Time: at, Valid: true},
+		Limit:     5,
+	}).Return([]db.ListDueReviewsRow{}, nil)
+
+	if _, err := svc.Due(context.Background(), dto.ListDueReviewsRequest{UserID: uid, At: at, Limit: 5}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestReviewService_Due_RepoError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockdb.NewMockUserProgressRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewReviewService(mockRepo, logger)
+
+	mockRepo.EXPECT().ListDueReviews(gomock.Any(), gomock.Any()).Return(nil, errors.New("fail"))
+
+	_, err := svc.Due(context.Background(), dto.ListDueReviewsRequest{})
+	if err == nil || err.Error() != string(errorsPkg.InfrastructureUnexpected) {
+		t.Fatalf("expected %s, got %v", errorsPkg.InfrastructureUnexpected, err)
+	}
+}
+
+func TestReviewQuality(t *testing.T) {
+	if q := reviewQuality(true); q < 3 {
+		t.Fatalf("correct answer graded %d, want a passing grade", q)
+	}
+	if q := reviewQuality(false); q >= 3 {
+		t.Fatalf("wrong answer graded %d, want a failing grade", q)
+	}
+}
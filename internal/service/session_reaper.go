@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"test-http/internal/db"
+)
+
+// SessionReaper abandons active sessions that saw no activity for longer than the idle timeout.
+type SessionReaper struct {
+	sessionRepo db.UserSessionRepo
+	idleTimeout time.Duration
+	logger      *slog.Logger
+}
+
+func NewSessionReaper(sessionRepo db.UserSessionRepo, idleTimeout time.Duration, log *slog.Logger) *SessionReaper {
+	return &SessionReaper{
+		sessionRepo: sessionRepo,
+		idleTimeout: idleTimeout,
+		logger:      log,
+	}
+}
+
+// Run reaps once immediately and then every interval until ctx is cancelled.
+func (r *SessionReaper) Run(ctx context.Context, interval time.Duration) {
+	runPeriodically(ctx, interval, func(ctx context.Context) { _, _ = r.Reap(ctx) })
+}
+
+// Reap abandons every idle session once and returns how many were abandoned.
+func (r *SessionReaper) Reap(ctx context.Context) (int64, error) {
+	return sweepOlderThan(ctx, r.logger, "SessionReaper.Reap", "AbandonIdleSessions", r.idleTimeout,
+		r.sessionRepo.AbandonIdleSessions)
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"test-http/internal/db/mocks"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestSessionReaper_Reap(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserSessionRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	reaper := NewSessionReaper(mockRepo, 30*time.Minute, logger)
+
+	mockRepo.EXPECT().AbandonIdleSessions(gomock.Any(), gomock.Any()).DoAndReturn(expectCutoff(t, 30*time.Minute, 2, nil))
+
+	n, err := reaper.Reap(context.Background())
+	if err != nil || n != 2 {
+		t.Fatalf("got %d, %v; want 2, nil", n, err)
+	}
+}
+
+func TestSessionReaper_Reap_RepoError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserSessionRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	reaper := NewSessionReaper(mockRepo, 30*time.Minute, logger)
+
+	repoErr := errors.New("connection reset")
+	mockRepo.EXPECT().AbandonIdleSessions(gomock.Any(), gomock.Any()).DoAndReturn(expectCutoff(t, 30*time.Minute, 0, repoErr))
+
+	if _, err := reaper.Reap(context.Background()); !errors.Is(err, repoErr) {
+		t.Fatalf("got %v, want %v", err, repoErr)
+	}
+}
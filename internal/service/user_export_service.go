@@ -0,0 +1,291 @@
+package service
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"time"
+
+	"test-http/internal/db"
+	"test-http/internal/dto"
+	errorsPkg "test-http/pkg/errors_pkg"
+	"test-http/pkg/helper"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	ExportFormatJSON = "json"
+	ExportFormatZIP  = "zip"
+
+	// exportBatchSize is how many rows of one table are read per query while streaming.
+	exportBatchSize int32 = 500
+)
+
+// UserExportService assembles everything held about a user for data-subject requests.
+type UserExportService struct {
+	userRepo        db.UserRepo
+	sessionRepo     db.UserSessionRepo
+	progressRepo    db.UserProgressRepo
+	statisticsRepo  db.UserStatisticsRepo
+	userWordSetRepo db.UserWordSetRepo
+	batchSize       int32
+	logger          *slog.Logger
+}
+
+func NewUserExportService(
+	userRepo db.UserRepo,
+	sessionRepo db.UserSessionRepo,
+	progressRepo db.UserProgressRepo,
+	statisticsRepo db.UserStatisticsRepo,
+	userWordSetRepo db.UserWordSetRepo,
+	log *slog.Logger,
+) *UserExportService {
+	return &UserExportService{
+		userRepo:        userRepo,
+		sessionRepo:     sessionRepo,
+		progressRepo:    progressRepo,
+		statisticsRepo:  statisticsRepo,
+		userWordSetRepo: userWordSetRepo,
+		batchSize:       exportBatchSize,
+		logger:          log,
+	}
+}
+
+// UserExport is an export opened for one user. Only the users row is loaded up front;
+// the other tables are read batch by batch while the export is written, so a large
+// history never sits in memory. Tables are read one after another, not from one snapshot.
+type UserExport struct {
+	svc        *UserExportService
+	User       db.User
+	ExportedAt time.Time
+}
+
+// exportSection is one table of the export: a top-level key of the JSON document
+// and a <name>.json file in the ZIP archive.
+type exportSection struct {
+	name  string
+	write func(ctx context.Context, w io.Writer) error
+}
+
+// Open loads the user so that a missing user is reported before anything is written.
+// Deactivated and soft-deleted users are exported too: their data is held until the purge.
+func (s *UserExportService) Open(ctx context.Context, request dto.ExportUserRequest) (*UserExport, error) {
+	helper.LogDebug(ctx, s.logger, "UserExportService.Open", "opening user export",
+		slog.String("user_id", request.ID.String()),
+		slog.String("format", request.Format),
+	)
+
+	user, err := s.userRepo.GetUserIncludingDeleted(ctx, request.ID)
+	if err != nil {
+		if f := repoFault(err, errorsPkg.UserNotFound); f != nil {
+			return nil, f
+		}
+		helper.LogError(ctx, s.logger, "UserExportService.Open", "GetUserIncludingDeleted", "failed to get user", err,
+			slog.String("user_id", request.ID.String()),
+		)
+		return nil, errorsPkg.InfrastructureUnexpected.Err()
+	}
+
+	return &UserExport{svc: s, User: user, ExportedAt: time.Now().UTC()}, nil
+}
+
+// WriteJSON streams the export as a single JSON object keyed by section.
+func (e *UserExport) WriteJSON(ctx context.Context, w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	exportedAt, _ := json.Marshal(e.ExportedAt)
+	if _, err := io.WriteString(bw, `{"exported_at":`+string(exportedAt)); err != nil {
+		return err
+	}
+	for _, section := range e.sections() {
+		if _, err := io.WriteString(bw, `,"`+section.name+`":`); err != nil {
+			return err
+		}
+		if err := section.write(ctx, bw); err != nil {
+			return err
+		}
+	}
+	if _, err := io.WriteString(bw, "}\n"); err != nil {
+		return err
+	}
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+
+	e.logWritten(ctx, ExportFormatJSON)
+	return nil
+}
+
+// WriteZIP streams the export as a ZIP archive with one JSON file per section.
+func (e *UserExport) WriteZIP(ctx context.Context, w io.Writer) error {
+	zw := zip.NewWriter(w)
+	for _, section := range e.sections() {
+		f, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     section.name + ".json",
+			Method:   zip.Deflate,
+			Modified: e.ExportedAt,
+		})
+		if err != nil {
+			return err
+		}
+		if err := section.write(ctx, f); err != nil {
+			return err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+
+	e.logWritten(ctx, ExportFormatZIP)
+	return nil
+}
+
+func (e *UserExport) logWritten(ctx context.Context, format string) {
+	helper.LogInfo(ctx, e.svc.logger, "UserExportService.Export", "user export written",
+		slog.String("user_id", e.User.ID.String()),
+		slog.String("format", format),
+	)
+}
+
+func (e *UserExport) sections() []exportSection {
+	return []exportSection{
+		{name: "user", write: func(_ context.Context, w io.Writer) error { return writeJSONValue(w, e.User) }},
+		{name: "statistics", write: e.writeStatistics},
+		{name: "sessions", write: e.writeSessions},
+		{name: "progress", write: e.writeProgress},
+		{name: "word_sets", write: e.writeWordSets},
+	}
+}
+
+// writeStatistics writes null for a user who has no statistics row yet.
+func (e *UserExport) writeStatistics(ctx context.Context, w io.Writer) error {
+	stats, err := e.svc.statisticsRepo.GetUserStatistics(ctx, e.User.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return writeJSONValue(w, nil)
+	}
+	if err != nil {
+		return e.readFailed(ctx, "GetUserStatistics", err)
+	}
+	return writeJSONValue(w, stats)
+}
+
+func (e *UserExport) writeSessions(ctx context.Context, w io.Writer) error {
+	return writeJSONArray(w, e.svc.batchSize, func(last *db.UserSession) ([]db.UserSession, error) {
+		var (
+			rows []db.UserSession
+			err  error
+		)
+		if last == nil {
+			rows, err = e.svc.sessionRepo.ListUserSessions(ctx, db.ListUserSessionsParams{
+				UserID: e.User.ID,
+				Limit:  e.svc.batchSize,
+			})
+		} else {
+			rows, err = e.svc.sessionRepo.ListUserSessionsAfter(ctx, db.ListUserSessionsAfterParams{
+				UserID:    e.User.ID,
+				StartedAt: last.StartedAt,
+				ID:        last.ID,
+				Limit:     e.svc.batchSize,
+			})
+		}
+		if err != nil {
+			return nil, e.readFailed(ctx, "ListUserSessions", err)
+		}
+		return rows, nil
+	})
+}
+
+func (e *UserExport) writeProgress(ctx context.Context, w io.Writer) error {
+	return writeJSONArray(w, e.svc.batchSize, func(last *db.UserProgress) ([]db.UserProgress, error) {
+		var (
+			rows []db.UserProgress
+			err  error
+		)
+		if last == nil {
+			rows, err = e.svc.progressRepo.ListUserProgress(ctx, db.ListUserProgressParams{
+				UserID: e.User.ID,
+				Limit:  e.svc.batchSize,
+			})
+		} else {
+			rows, err = e.svc.progressRepo.ListUserProgressAfter(ctx, db.ListUserProgressAfterParams{
+				UserID:      e.User.ID,
+				LastAttempt: last.LastAttempt,
+				ID:          last.ID,
+				Limit:       e.svc.batchSize,
+			})
+		}
+		if err != nil {
+			return nil, e.readFailed(ctx, "ListUserProgress", err)
+		}
+		return rows, nil
+	})
+}
+
+// writeWordSets pages by offset: subscriptions have no keyset query and stay few per user.
+func (e *UserExport) writeWordSets(ctx context.Context, w io.Writer) error {
+	var offset int32
+	return writeJSONArray(w, e.svc.batchSize, func(_ *db.UserWordSet) ([]db.UserWordSet, error) {
+		rows, err := e.svc.userWordSetRepo.ListUserWordSets(ctx, db.ListUserWordSetsParams{
+			UserID: e.User.ID,
+			Limit:  e.svc.batchSize,
+			Offset: offset,
+		})
+		if err != nil {
+			return nil, e.readFailed(ctx, "ListUserWordSets", err)
+		}
+		offset += int32(len(rows))
+		return rows, nil
+	})
+}
+
+func (e *UserExport) readFailed(ctx context.Context, repoMethod string, err error) error {
+	helper.LogError(ctx, e.svc.logger, "UserExportService.Export", repoMethod, "failed to read user data", err,
+		slog.String("user_id", e.User.ID.String()),
+	)
+	return errorsPkg.InfrastructureUnexpected.Err()
+}
+
+// writeJSONArray writes the rows returned by next as one JSON array. next is called with
+// the last row written so far, nil at first, until it returns a short batch.
+func writeJSONArray[T any](w io.Writer, batchSize int32, next func(last *T) ([]T, error)) error {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+	var last *T
+	for {
+		rows, err := next(last)
+		if err != nil {
+			return err
+		}
+		for i := range rows {
+			if last != nil || i > 0 {
+				if _, err := io.WriteString(w, ","); err != nil {
+					return err
+				}
+			}
+			if err := writeJSONValue(w, rows[i]); err != nil {
+				return err
+			}
+		}
+		if int32(len(rows)) < batchSize {
+			break
+		}
+		last = &rows[len(rows)-1]
+	}
+	_, err := io.WriteString(w, "]")
+	return err
+}
+
+func writeJSONValue(w io.Writer, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
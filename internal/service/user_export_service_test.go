@@ -0,0 +1,188 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"test-http/internal/db"
+	"test-http/internal/db/mocks"
+	"test-http/internal/dto"
+	errorsPkg "test-http/pkg/errors_pkg"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type exportMocks struct {
+	users        *mocks.MockUserRepo
+	sessions     *mocks.MockUserSessionRepo
+	progress     *mocks.MockUserProgressRepo
+	statistics   *mocks.MockUserStatisticsRepo
+	userWordSets *mocks.MockUserWordSetRepo
+}
+
+func newTestExportService(ctrl *gomock.Controller) (*UserExportService, exportMocks) {
+	m := exportMocks{
+		users:        mocks.NewMockUserRepo(ctrl),
+		sessions:     mocks.NewMockUserSessionRepo(ctrl),
+		progress:     mocks.NewMockUserProgressRepo(ctrl),
+		statistics:   mocks.NewMockUserStatisticsRepo(ctrl),
+		userWordSets: mocks.NewMockUserWordSetRepo(ctrl),
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewUserExportService(m.users, m.sessions, m.progress, m.statistics, m.userWordSets, logger)
+	svc.batchSize = 2
+	return svc, m
+}
+
+// expectExportReads stubs a user with three sessions (two batches), no progress,
+// one word set subscription and no statistics row.
+func expectExportReads(m exportMocks, user db.User) {
+	now := time.Date(2025, 10, 5, 12, 0, 0, 0, time.UTC)
+	s1 := db.UserSession{ID: pgtype.UUID{Bytes: [16]byte{1}, Valid: true}, UserID: user.ID, StartedAt: pgtype.Timestamptz{Time: now, Valid: true}}
+	s2 := db.UserSession{ID: pgtype.UUID{Bytes: [16]byte{2}, Valid: true}, UserID: user.ID, StartedAt: pgtype.Timestamptz{Time: now.Add(-time.Hour), Valid: true}}
+	s3 := db.UserSession{ID: pgtype.UUID{Bytes: [16]byte{3}, Valid: true}, UserID: user.ID, StartedAt: pgtype.Timestamptz{Time: now.Add(-2 * time.Hour), Valid: true}}
+
+	m.statistics.EXPECT().GetUserStatistics(gomock.Any(), user.ID).Return(db.UserStatistic{}, pgx.ErrNoRows)
+	m.sessions.EXPECT().ListUserSessions(gomock.Any(), db.ListUserSessionsParams{UserID: user.ID, Limit: 2}).
+		Return([]db.UserSession{s1, s2}, nil)
+	m.sessions.EXPECT().ListUserSessionsAfter(gomock.Any(), db.ListUserSessionsAfterParams{UserID: user.ID, StartedAt: s2.StartedAt, ID: s2.ID, Limit: 2}).
+		Return([]db.UserSession{s3}, nil)
+	m.progress.EXPECT().ListUserProgress(gomock.Any(), db.ListUserProgressParams{UserID: user.ID, Limit: 2}).
+		Return([]db.UserProgress{}, nil)
+	m.userWordSets.EXPECT().ListUserWordSets(gomock.Any(), db.ListUserWordSetsParams{UserID: user.ID, Limit: 2}).
+		Return([]db.UserWordSet{{UserID: user.ID}}, nil)
+}
+
+func TestUserExportService_WriteJSON(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc, m := newTestExportService(ctrl)
+	user := db.User{ID: pgtype.UUID{Bytes: [16]byte{9}, Valid: true}, Username: "ann"}
+	m.users.EXPECT().GetUserIncludingDeleted(gomock.Any(), user.ID).Return(user, nil)
+	expectExportReads(m, user)
+
+	export, err := svc.Open(context.Background(), dto.ExportUserRequest{ID: user.ID})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := export.WriteJSON(context.Background(), &buf); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+
+	var got struct {
+		ExportedAt time.Time         `json:"exported_at"`
+		User       db.User           `json:"user"`
+		Statistics *db.UserStatistic `json:"statistics"`
+		Sessions   []db.UserSession  `json:"sessions"`
+		Progress   []db.UserProgress `json:"progress"`
+		WordSets   []db.UserWordSet  `json:"word_sets"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("export is not valid JSON: %v\n%s", err, buf.String())
+	}
+	if got.User.Username != "ann" || got.Statistics != nil || len(got.Sessions) != 3 ||
+		got.Progress == nil || len(got.Progress) != 0 || len(got.WordSets) != 1 || got.ExportedAt.IsZero() {
+		t.Fatalf("unexpected export: %s", buf.String())
+	}
+}
+
+// A soft-deleted account stays exportable until the purger removes it.
+func TestUserExportService_WriteJSON_SoftDeletedUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc, m := newTestExportService(ctrl)
+	deletedAt := time.Date(2025, 10, 1, 9, 0, 0, 0, time.UTC)
+	user := db.User{
+		ID:        pgtype.UUID{Bytes: [16]byte{9}, Valid: true},
+		Username:  "ann",
+		DeletedAt: pgtype.Timestamptz{Time: deletedAt, Valid: true},
+	}
+	m.users.EXPECT().GetUserIncludingDeleted(gomock.Any(), user.ID).Return(user, nil)
+	expectExportReads(m, user)
+
+	export, err := svc.Open(context.Background(), dto.ExportUserRequest{ID: user.ID})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := export.WriteJSON(context.Background(), &buf); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+
+	var got struct {
+		User     db.User          `json:"user"`
+		Sessions []db.UserSession `json:"sessions"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("export is not valid JSON: %v\n%s", err, buf.String())
+	}
+	if !got.User.DeletedAt.Valid || !got.User.DeletedAt.Time.Equal(deletedAt) || len(got.Sessions) != 3 {
+		t.Fatalf("unexpected export: %s", buf.String())
+	}
+}
+
+func TestUserExportService_WriteZIP(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc, m := newTestExportService(ctrl)
+	user := db.User{ID: pgtype.UUID{Bytes: [16]byte{9}, Valid: true}, Username: "ann"}
+	m.users.EXPECT().GetUserIncludingDeleted(gomock.Any(), user.ID).Return(user, nil)
+	expectExportReads(m, user)
+
+	export, err := svc.Open(context.Background(), dto.ExportUserRequest{ID: user.ID, Format: ExportFormatZIP})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := export.WriteZIP(context.Background(), &buf); err != nil {
+		t.Fatalf("WriteZIP: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("export is not a valid archive: %v", err)
+	}
+	want := []string{"user.json", "statistics.json", "sessions.json", "progress.json", "word_sets.json"}
+	if len(zr.File) != len(want) {
+		t.Fatalf("archive has %d files, want %d", len(zr.File), len(want))
+	}
+	for i, f := range zr.File {
+		if f.Name != want[i] {
+			t.Fatalf("file %d = %s, want %s", i, f.Name, want[i])
+		}
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", f.Name, err)
+		}
+		body, _ := io.ReadAll(rc)
+		rc.Close()
+		if !json.Valid(body) {
+			t.Fatalf("%s is not valid JSON: %s", f.Name, body)
+		}
+	}
+}
+
+func TestUserExportService_Open_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	svc, m := newTestExportService(ctrl)
+	m.users.EXPECT().GetUserIncludingDeleted(gomock.Any(), gomock.Any()).Return(db.User{}, pgx.ErrNoRows)
+
+	_, err := svc.Open(context.Background(), dto.ExportUserRequest{})
+	if err == nil || err.Error() != string(errorsPkg.UserNotFound) {
+		t.Fatalf("expected %s, got %v", errorsPkg.UserNotFound, err)
+	}
+}
@@ -2,23 +2,33 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"log/slog"
 
 	"test-http/internal/db"
 	"test-http/internal/dto"
+	"test-http/pkg/cursor"
 	errorsPkg "test-http/pkg/errors_pkg"
 	"test-http/pkg/helper"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const defaultProgressPageLimit = 20
+
 type UserProgressService struct {
 	userProgressRepo db.UserProgressRepo
+	txRunner         db.TxRunner
+	cursors          *cursor.Codec
 	logger           *slog.Logger
 }
 
-func NewUserProgressService(userProgressRepo db.UserProgressRepo, log *slog.Logger) *UserProgressService {
+func NewUserProgressService(userProgressRepo db.UserProgressRepo, txRunner db.TxRunner, cursors *cursor.Codec, log *slog.Logger) *UserProgressService {
 	return &UserProgressService{
 		userProgressRepo: userProgressRepo,
+		txRunner:         txRunner,
+		cursors:          cursors,
 		logger:           log,
 	}
 }
@@ -38,6 +48,9 @@ func (u *UserProgressService) Create(ctx context.Context, request dto.CreateUser
 		IncorrectCount: request.IncorrectCount,
 	})
 	if err != nil {
+		if f := repoFault(err, errorsPkg.UserProgressNotFound); f != nil {
+			return db.UserProgress{}, f
+		}
 		helper.LogError(ctx, u.logger, "UserProgressService.Create", "CreateUserProgress", "failed to create user progress", err,
 			slog.String("user_id", request.UserID.String()),
 			slog.String("word_id", request.WordID.String()),
@@ -59,11 +72,18 @@ func (u *UserProgressService) GetByID(ctx context.Context, request dto.GetUserPr
 
 	progress, err := u.userProgressRepo.GetUserProgress(ctx, request.ID)
 	if err != nil {
+		if f := repoFault(err, errorsPkg.UserProgressNotFound); f != nil {
+			return db.UserProgress{}, f
+		}
 		helper.LogError(ctx, u.logger, "UserProgressService.GetByID", "GetUserProgress", "failed to get user progress by id", err,
 			slog.String("progress_id", request.ID.String()),
 		)
 		return db.UserProgress{}, errorsPkg.InfrastructureUnexpected.Err()
 	}
+	// Another user's progress is reported as missing rather than forbidden.
+	if progress.UserID != request.UserID {
+		return db.UserProgress{}, errorsPkg.UserProgressNotFound.Err()
+	}
 
 	return progress, nil
 }
@@ -79,6 +99,9 @@ func (u *UserProgressService) GetByUserAndWord(ctx context.Context, request dto.
 		WordID: request.WordID,
 	})
 	if err != nil {
+		if f := repoFault(err, errorsPkg.UserProgressNotFound); f != nil {
+			return db.UserProgress{}, f
+		}
 		helper.LogError(ctx, u.logger, "UserProgressService.GetByUserAndWord", "GetUserProgressByUserAndWord", "failed to get user progress by user and word", err,
 			slog.String("user_id", request.UserID.String()),
 			slog.String("word_id", request.WordID.String()),
@@ -89,26 +112,63 @@ func (u *UserProgressService) GetByUserAndWord(ctx context.Context, request dto.
 	return progress, nil
 }
 
-func (u *UserProgressService) List(ctx context.Context, request dto.ListUserProgressRequest) ([]db.UserProgress, error) {
+// List pages through the user's progress, most recently attempted first. A cursor takes precedence over Offset.
+func (u *UserProgressService) List(ctx context.Context, request dto.ListUserProgressRequest) (dto.Page[db.UserProgress], error) {
 	helper.LogDebug(ctx, u.logger, "UserProgressService.List", "listing user progress",
 		slog.String("user_id", request.UserID.String()),
 		slog.Int("limit", int(request.Limit)),
 		slog.Int("offset", int(request.Offset)),
+		slog.Bool("cursor", request.Cursor != ""),
 	)
 
-	progressList, err := u.userProgressRepo.ListUserProgress(ctx, request.UserID)
+	from, err := decodeCursor(u.cursors, cursorScopeUserProgress, request.Cursor)
+	if err != nil {
+		return dto.Page[db.UserProgress]{}, err
+	}
+	limit := request.Limit
+	if limit == 0 {
+		limit = defaultProgressPageLimit
+	}
+
+	var progressList []db.UserProgress
+	switch {
+	case from == nil:
+		progressList, err = u.userProgressRepo.ListUserProgress(ctx, db.ListUserProgressParams{
+			UserID: request.UserID,
+			Limit:  limit + 1,
+			Offset: request.Offset,
+		})
+	case from.Prev:
+		progressList, err = u.userProgressRepo.ListUserProgressBefore(ctx, db.ListUserProgressBeforeParams{
+			UserID:      request.UserID,
+			LastAttempt: from.At,
+			ID:          from.ID,
+			Limit:       limit + 1,
+		})
+	default:
+		progressList, err = u.userProgressRepo.ListUserProgressAfter(ctx, db.ListUserProgressAfterParams{
+			UserID:      request.UserID,
+			LastAttempt: from.At,
+			ID:          from.ID,
+			Limit:       limit + 1,
+		})
+	}
 	if err != nil {
 		helper.LogError(ctx, u.logger, "UserProgressService.List", "ListUserProgress", "failed to list user progress", err,
 			slog.String("user_id", request.UserID.String()),
 		)
-		return nil, errorsPkg.InfrastructureUnexpected.Err()
+		return dto.Page[db.UserProgress]{}, errorsPkg.InfrastructureUnexpected.Err()
 	}
 
+	page := keysetPage(u.cursors, cursorScopeUserProgress, progressList, limit, from, request.Offset, func(p db.UserProgress) (time.Time, pgtype.UUID) {
+		return p.LastAttempt.Time, p.ID
+	})
+
 	helper.LogDebug(ctx, u.logger, "UserProgressService.List", "user progress listed successfully",
-		slog.Int("count", len(progressList)),
+		slog.Int("count", len(page.Items)),
 	)
 
-	return progressList, nil
+	return page, nil
 }
 
 func (u *UserProgressService) Update(ctx context.Context, request dto.UpdateUserProgressRequest) (db.UserProgress, error) {
@@ -120,10 +180,14 @@ func (u *UserProgressService) Update(ctx context.Context, request dto.UpdateUser
 
 	progress, err := u.userProgressRepo.UpdateUserProgress(ctx, db.UpdateUserProgressParams{
 		ID:             request.ID,
+		UserID:         request.UserID,
 		CorrectCount:   request.CorrectCount,
 		IncorrectCount: request.IncorrectCount,
 	})
 	if err != nil {
+		if f := repoFault(err, errorsPkg.UserProgressNotFound); f != nil {
+			return db.UserProgress{}, f
+		}
 		helper.LogError(ctx, u.logger, "UserProgressService.Update", "UpdateUserProgress", "failed to update user progress", err,
 			slog.String("progress_id", request.ID.String()),
 		)
@@ -137,18 +201,78 @@ func (u *UserProgressService) Update(ctx context.Context, request dto.UpdateUser
 	return progress, nil
 }
 
+// RecordAttempts upserts progress for every attempt in one transaction. Counters are
+// incremented in SQL, so concurrent answers from several devices never overwrite each other.
+func (u *UserProgressService) RecordAttempts(ctx context.Context, request dto.RecordProgressAttemptsRequest) ([]db.UserProgress, error) {
+	helper.LogDebug(ctx, u.logger, "UserProgressService.RecordAttempts", "recording progress attempts",
+		slog.String("user_id", request.UserID.String()),
+		slog.Int("count", len(request.Attempts)),
+	)
+
+	params := make([]db.RecordUserProgressAttemptParams, 0, len(request.Attempts))
+	for _, attempt := range request.Attempts {
+		quality := reviewQuality(attempt.Correct)
+		if attempt.Quality != nil {
+			quality = *attempt.Quality
+		}
+		params = append(params, db.RecordUserProgressAttemptParams{
+			UserID:  request.UserID,
+			WordID:  attempt.WordID,
+			Correct: attempt.Correct,
+			Quality: quality,
+		})
+	}
+
+	var progress []db.UserProgress
+	err := u.txRunner.InTx(ctx, func(tx db.Tx) error {
+		progress = make([]db.UserProgress, 0, len(params))
+		for _, arg := range params {
+			p, err := tx.Progress().RecordUserProgressAttempt(ctx, arg)
+			if err != nil {
+				return err
+			}
+			progress = append(progress, p)
+		}
+		return nil
+	})
+	if err != nil {
+		if f := repoFault(err, errorsPkg.UserProgressNotFound); f != nil {
+			return nil, f
+		}
+		helper.LogError(ctx, u.logger, "UserProgressService.RecordAttempts", "RecordUserProgressAttempt", "failed to record progress attempts", err,
+			slog.String("user_id", request.UserID.String()),
+		)
+		return nil, errorsPkg.InfrastructureUnexpected.Err()
+	}
+
+	helper.LogDebug(ctx, u.logger, "UserProgressService.RecordAttempts", "progress attempts recorded successfully",
+		slog.Int("count", len(progress)),
+	)
+
+	return progress, nil
+}
+
 func (u *UserProgressService) Delete(ctx context.Context, request dto.DeleteUserProgressRequest) error {
 	helper.LogDebug(ctx, u.logger, "UserProgressService.Delete", "deleting user progress",
 		slog.String("progress_id", request.ID.String()),
 	)
 
-	err := u.userProgressRepo.DeleteUserProgress(ctx, request.ID)
+	deleted, err := u.userProgressRepo.DeleteUserProgress(ctx, db.DeleteUserProgressParams{
+		ID:     request.ID,
+		UserID: request.UserID,
+	})
 	if err != nil {
+		if f := repoFault(err, errorsPkg.UserProgressNotFound); f != nil {
+			return f
+		}
 		helper.LogError(ctx, u.logger, "UserProgressService.Delete", "DeleteUserProgress", "failed to delete user progress", err,
 			slog.String("progress_id", request.ID.String()),
 		)
 		return errorsPkg.InfrastructureUnexpected.Err()
 	}
+	if deleted == 0 {
+		return errorsPkg.UserProgressNotFound.Err()
+	}
 
 	helper.LogDebug(ctx, u.logger, "UserProgressService.Delete", "user progress deleted successfully",
 		slog.String("progress_id", request.ID.String()),
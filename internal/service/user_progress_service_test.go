@@ -10,8 +10,10 @@ import (
 	db "test-http/internal/db"
 	"test-http/internal/dto"
 	mockdb "test-http/internal/db/mocks"
+	errorsPkg "test-http/pkg/errors_pkg"
 
 	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -21,7 +23,7 @@ func TestUserProgressService_Create_Success(t *testing.T) {
 
 	mockRepo := mockdb.NewMockUserProgressRepo(ctrl)
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-	svc := NewUserProgressService(mockRepo, logger)
+	svc := NewUserProgressService(mockRepo, mockdb.NewMockTxRunner(ctrl), testCursors, logger)
 
 	var uid, wid pgtype.UUID
 	params := dto.CreateUserProgressRequest{UserID: uid, WordID: wid, CorrectCount: 1, IncorrectCount: 0}
@@ -49,7 +51,7 @@ func TestUserProgressService_Create_RepoError(t *testing.T) {
 
 	mockRepo := mockdb.NewMockUserProgressRepo(ctrl)
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-	svc := NewUserProgressService(mockRepo, logger)
+	svc := NewUserProgressService(mockRepo, mockdb.NewMockTxRunner(ctrl), testCursors, logger)
 
 	var uid, wid pgtype.UUID
 	params := dto.CreateUserProgressRequest{UserID: uid, WordID: wid, CorrectCount: 1, IncorrectCount: 0}
@@ -68,7 +70,7 @@ func TestUserProgressService_GetByID_Success(t *testing.T) {
 
 	mockRepo := mockdb.NewMockUserProgressRepo(ctrl)
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-	svc := NewUserProgressService(mockRepo, logger)
+	svc := NewUserProgressService(mockRepo, mockdb.NewMockTxRunner(ctrl), testCursors, logger)
 
 	var id pgtype.UUID
 	want := db.UserProgress{ID: id}
@@ -89,7 +91,7 @@ func TestUserProgressService_GetByID_RepoError(t *testing.T) {
 
 	mockRepo := mockdb.NewMockUserProgressRepo(ctrl)
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-	svc := NewUserProgressService(mockRepo, logger)
+	svc := NewUserProgressService(mockRepo, mockdb.NewMockTxRunner(ctrl), testCursors, logger)
 
 	var id pgtype.UUID
 	mockRepo.EXPECT().GetUserProgress(gomock.Any(), id).Return(db.UserProgress{}, errors.New("not found"))
@@ -106,7 +108,7 @@ func TestUserProgressService_GetByUserAndWord_Success(t *testing.T) {
 
 	mockRepo := mockdb.NewMockUserProgressRepo(ctrl)
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-	svc := NewUserProgressService(mockRepo, logger)
+	svc := NewUserProgressService(mockRepo, mockdb.NewMockTxRunner(ctrl), testCursors, logger)
 
 	var uid, wid pgtype.UUID
 	params := db.GetUserProgressByUserAndWordParams{UserID: uid, WordID: wid}
@@ -128,7 +130,7 @@ func TestUserProgressService_GetByUserAndWord_RepoError(t *testing.T) {
 
 	mockRepo := mockdb.NewMockUserProgressRepo(ctrl)
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-	svc := NewUserProgressService(mockRepo, logger)
+	svc := NewUserProgressService(mockRepo, mockdb.NewMockTxRunner(ctrl), testCursors, logger)
 
 	var uid, wid pgtype.UUID
 	mockRepo.EXPECT().GetUserProgressByUserAndWord(gomock.Any(), gomock.Any()).Return(db.UserProgress{}, errors.New("fail"))
@@ -145,19 +147,19 @@ func TestUserProgressService_List_Success(t *testing.T) {
 
 	mockRepo := mockdb.NewMockUserProgressRepo(ctrl)
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-	svc := NewUserProgressService(mockRepo, logger)
+	svc := NewUserProgressService(mockRepo, mockdb.NewMockTxRunner(ctrl), testCursors, logger)
 
 	var uid pgtype.UUID
 	filters := dto.ListUserProgressRequest{UserID: uid, Limit: 10, Offset: 0}
 	want := []db.UserProgress{{UserID: uid}}
-	mockRepo.EXPECT().ListUserProgress(gomock.Any(), uid).Return(want, nil)
+	mockRepo.EXPECT().ListUserProgress(gomock.Any(), db.ListUserProgressParams{UserID: uid, Limit: 11, Offset: 0}).Return(want, nil)
 
 	got, err := svc.List(context.Background(), filters)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if len(got) != len(want) {
-		t.Fatalf("got %d want %d", len(got), len(want))
+	if len(got.Items) != len(want) || got.NextCursor != "" || got.PrevCursor != "" {
+		t.Fatalf("got %+v want %d items and no cursors", got, len(want))
 	}
 }
 
@@ -167,7 +169,7 @@ func TestUserProgressService_List_RepoError(t *testing.T) {
 
 	mockRepo := mockdb.NewMockUserProgressRepo(ctrl)
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-	svc := NewUserProgressService(mockRepo, logger)
+	svc := NewUserProgressService(mockRepo, mockdb.NewMockTxRunner(ctrl), testCursors, logger)
 
 	var uid pgtype.UUID
 	filters := dto.ListUserProgressRequest{UserID: uid}
@@ -185,7 +187,7 @@ func TestUserProgressService_Update_Success(t *testing.T) {
 
 	mockRepo := mockdb.NewMockUserProgressRepo(ctrl)
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-	svc := NewUserProgressService(mockRepo, logger)
+	svc := NewUserProgressService(mockRepo, mockdb.NewMockTxRunner(ctrl), testCursors, logger)
 
 	var id pgtype.UUID
 	params := dto.UpdateUserProgressRequest{ID: id, CorrectCount: 2, IncorrectCount: 1}
@@ -208,7 +210,7 @@ func TestUserProgressService_Update_RepoError(t *testing.T) {
 
 	mockRepo := mockdb.NewMockUserProgressRepo(ctrl)
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-	svc := NewUserProgressService(mockRepo, logger)
+	svc := NewUserProgressService(mockRepo, mockdb.NewMockTxRunner(ctrl), testCursors, logger)
 
 	var id pgtype.UUID
 	params := dto.UpdateUserProgressRequest{ID: id}
@@ -226,10 +228,10 @@ func TestUserProgressService_Delete_Success(t *testing.T) {
 
 	mockRepo := mockdb.NewMockUserProgressRepo(ctrl)
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-	svc := NewUserProgressService(mockRepo, logger)
+	svc := NewUserProgressService(mockRepo, mockdb.NewMockTxRunner(ctrl), testCursors, logger)
 
 	var id pgtype.UUID
-	mockRepo.EXPECT().DeleteUserProgress(gomock.Any(), id).Return(nil)
+	mockRepo.EXPECT().DeleteUserProgress(gomock.Any(), db.DeleteUserProgressParams{ID: id}).Return(int64(1), nil)
 
 	err := svc.Delete(context.Background(), dto.DeleteUserProgressRequest{ID: id})
 	if err != nil {
@@ -243,13 +245,73 @@ func TestUserProgressService_Delete_RepoError(t *testing.T) {
 
 	mockRepo := mockdb.NewMockUserProgressRepo(ctrl)
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-	svc := NewUserProgressService(mockRepo, logger)
+	svc := NewUserProgressService(mockRepo, mockdb.NewMockTxRunner(ctrl), testCursors, logger)
 
 	var id pgtype.UUID
-	mockRepo.EXPECT().DeleteUserProgress(gomock.Any(), id).Return(errors.New("fail"))
+	mockRepo.EXPECT().DeleteUserProgress(gomock.Any(), db.DeleteUserProgressParams{ID: id}).Return(int64(0), errors.New("fail"))
 
 	err := svc.Delete(context.Background(), dto.DeleteUserProgressRequest{ID: id})
 	if err == nil {
 		t.Fatalf("expected error from repo, got nil")
 	}
 }
+
+func TestUserProgressService_RecordAttempts_DerivesQuality(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockdb.NewMockUserProgressRepo(ctrl)
+	tx := mockdb.NewMockTx(ctrl)
+	tx.EXPECT().Progress().Return(mockRepo).AnyTimes()
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewUserProgressService(mockRepo, inTx(ctrl, tx), testCursors, logger)
+
+	userID := pgtype.UUID{Bytes: [16]byte{1}, Valid: true}
+	first := pgtype.UUID{Bytes: [16]byte{2}, Valid: true}
+	second := pgtype.UUID{Bytes: [16]byte{3}, Valid: true}
+	explicit := int16(5)
+
+	gomock.InOrder(
+		mockRepo.EXPECT().RecordUserProgressAttempt(gomock.Any(), db.RecordUserProgressAttemptParams{
+			UserID: userID, WordID: first, Correct: false, Quality: ReviewQualityIncorrect,
+		}).Return(db.UserProgress{WordID: first, IncorrectCount: 1}, nil),
+		mockRepo.EXPECT().RecordUserProgressAttempt(gomock.Any(), db.RecordUserProgressAttemptParams{
+			UserID: userID, WordID: second, Correct: true, Quality: 5,
+		}).Return(db.UserProgress{WordID: second, CorrectCount: 1}, nil),
+	)
+
+	got, err := svc.RecordAttempts(context.Background(), dto.RecordProgressAttemptsRequest{
+		UserID: userID,
+		Attempts: []dto.ProgressAttempt{
+			{WordID: first, Correct: false},
+			{WordID: second, Correct: true, Quality: &explicit},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0].WordID != first || got[1].WordID != second {
+		t.Fatalf("unexpected rows: %+v", got)
+	}
+}
+
+func TestUserProgressService_RecordAttempts_UnknownWord(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mockdb.NewMockUserProgressRepo(ctrl)
+	tx := mockdb.NewMockTx(ctrl)
+	tx.EXPECT().Progress().Return(mockRepo).AnyTimes()
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewUserProgressService(mockRepo, inTx(ctrl, tx), testCursors, logger)
+
+	mockRepo.EXPECT().RecordUserProgressAttempt(gomock.Any(), gomock.Any()).
+		Return(db.UserProgress{}, &pgconn.PgError{Code: "23503", ConstraintName: "fk_user_progress_word_id"})
+
+	_, err := svc.RecordAttempts(context.Background(), dto.RecordProgressAttemptsRequest{
+		Attempts: []dto.ProgressAttempt{{Correct: true}, {Correct: false}},
+	})
+	if err == nil || err.Error() != string(errorsPkg.ReferenceMissing) {
+		t.Fatalf("expected %s, got %v", errorsPkg.ReferenceMissing, err)
+	}
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"test-http/internal/db"
+)
+
+// UserPurger hard-deletes users whose restore period has expired since they were soft-deleted.
+type UserPurger struct {
+	userRepo      db.UserRepo
+	restorePeriod time.Duration
+	logger        *slog.Logger
+}
+
+func NewUserPurger(userRepo db.UserRepo, restorePeriod time.Duration, log *slog.Logger) *UserPurger {
+	return &UserPurger{
+		userRepo:      userRepo,
+		restorePeriod: restorePeriod,
+		logger:        log,
+	}
+}
+
+// Run purges once immediately and then every interval until ctx is cancelled.
+func (p *UserPurger) Run(ctx context.Context, interval time.Duration) {
+	runPeriodically(ctx, interval, func(ctx context.Context) { _, _ = p.Purge(ctx) })
+}
+
+// Purge removes every expired soft-deleted user once and returns how many were removed.
+func (p *UserPurger) Purge(ctx context.Context) (int64, error) {
+	return sweepOlderThan(ctx, p.logger, "UserPurger.Purge", "PurgeDeletedUsers", p.restorePeriod,
+		p.userRepo.PurgeDeletedUsers)
+}
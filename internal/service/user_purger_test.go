@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"test-http/internal/db/mocks"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestUserPurger_Purge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	purger := NewUserPurger(mockRepo, 24*time.Hour, logger)
+
+	mockRepo.EXPECT().PurgeDeletedUsers(gomock.Any(), gomock.Any()).DoAndReturn(expectCutoff(t, 24*time.Hour, 3, nil))
+
+	n, err := purger.Purge(context.Background())
+	if err != nil || n != 3 {
+		t.Fatalf("got %d, %v; want 3, nil", n, err)
+	}
+}
+
+func TestUserPurger_Purge_RepoError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	purger := NewUserPurger(mockRepo, 24*time.Hour, logger)
+
+	repoErr := errors.New("connection reset")
+	mockRepo.EXPECT().PurgeDeletedUsers(gomock.Any(), gomock.Any()).DoAndReturn(expectCutoff(t, 24*time.Hour, 0, repoErr))
+
+	n, err := purger.Purge(context.Background())
+	if !errors.Is(err, repoErr) || n != 0 {
+		t.Fatalf("got %d, %v; want 0, %v", n, err, repoErr)
+	}
+}
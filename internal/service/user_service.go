@@ -3,27 +3,47 @@ package service
 import (
 	"context"
 	"strings"
+	"time"
 
 	"log/slog"
 	"test-http/internal/db"
 	"test-http/internal/dto"
+	"test-http/pkg/cursor"
 	errorsPkg "test-http/pkg/errors_pkg"
+	"test-http/pkg/fault"
+	"test-http/pkg/helper"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	defaultUserPageLimit = 20
+	defaultUserSort      = "-created_at"
 )
 
+// likeEscaper escapes LIKE wildcards so a username prefix matches literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 type UserService struct {
-	userRepo db.UserRepo
-	logger   *slog.Logger
+	userRepo      db.UserRepo
+	restorePeriod time.Duration
+	cursors       *cursor.Codec
+	logger        *slog.Logger
 }
 
-func NewUserService(userRepo db.UserRepo, log *slog.Logger) *UserService {
+// NewUserService returns a UserService. Deleted users can be restored for restorePeriod,
+// after which UserPurger removes them for good.
+func NewUserService(userRepo db.UserRepo, restorePeriod time.Duration, cursors *cursor.Codec, log *slog.Logger) *UserService {
 	return &UserService{
-		userRepo: userRepo,
-		logger:   log,
+		userRepo:      userRepo,
+		restorePeriod: restorePeriod,
+		cursors:       cursors,
+		logger:        log,
 	}
 }
 
 func (u *UserService) Create(ctx context.Context, request dto.CreateUserRequest) (db.User, error) {
-	lib.LogDebug(ctx, u.logger, "UserService.Create", "creating user",
+	helper.LogDebug(ctx, u.logger, "UserService.Create", "creating user",
 		slog.String("username", request.Username),
 		slog.String("email", request.Email),
 	)
@@ -33,14 +53,17 @@ func (u *UserService) Create(ctx context.Context, request dto.CreateUserRequest)
 		Email:    request.Email,
 	})
 	if err != nil {
-		lib.LogError(ctx, u.logger, "UserService.Create", "CreateUser", "failed to create user", err,
+		if f := repoFault(err, errorsPkg.UserNotFound); f != nil {
+			return db.User{}, f
+		}
+		helper.LogError(ctx, u.logger, "UserService.Create", "CreateUser", "failed to create user", err,
 			slog.String("username", request.Username),
 			slog.String("email", request.Email),
 		)
 		return db.User{}, errorsPkg.InfrastructureUnexpected.Err()
 	}
 
-	lib.LogInfo(ctx, u.logger, "UserService.Create", "user created successfully",
+	helper.LogInfo(ctx, u.logger, "UserService.Create", "user created successfully",
 		slog.String("user_id", user.ID.String()),
 		slog.String("username", user.Username),
 	)
@@ -49,13 +72,16 @@ func (u *UserService) Create(ctx context.Context, request dto.CreateUserRequest)
 }
 
 func (u *UserService) GetByID(ctx context.Context, request dto.GetUserByIDRequest) (db.User, error) {
-	lib.LogDebug(ctx, u.logger, "UserService.GetByID", "getting user by id",
+	helper.LogDebug(ctx, u.logger, "UserService.GetByID", "getting user by id",
 		slog.String("user_id", request.ID.String()),
 	)
 
 	user, err := u.userRepo.GetUser(ctx, request.ID)
 	if err != nil {
-		lib.LogError(ctx, u.logger, "UserService.GetByID", "GetUser", "failed to get user by id", err,
+		if f := repoFault(err, errorsPkg.UserNotFound); f != nil {
+			return db.User{}, f
+		}
+		helper.LogError(ctx, u.logger, "UserService.GetByID", "GetUser", "failed to get user by id", err,
 			slog.String("user_id", request.ID.String()),
 		)
 		return db.User{}, errorsPkg.InfrastructureUnexpected.Err()
@@ -65,19 +91,22 @@ func (u *UserService) GetByID(ctx context.Context, request dto.GetUserByIDReques
 }
 
 func (u *UserService) GetByEmail(ctx context.Context, request dto.GetUserByEmailRequest) (db.User, error) {
-	lib.LogDebug(ctx, u.logger, "UserService.GetByEmail", "getting user by email",
+	helper.LogDebug(ctx, u.logger, "UserService.GetByEmail", "getting user by email",
 		slog.String("email", request.Email),
 	)
 
 	if !strings.Contains(request.Email, "@") {
-		lib.LogError(ctx, u.logger, "UserService.GetByEmail", "GetUserByEmail", "invalid email format", nil,
+		helper.LogError(ctx, u.logger, "UserService.GetByEmail", "GetUserByEmail", "invalid email format", nil,
 			slog.String("email", request.Email))
 		return db.User{}, errorsPkg.ValidationError.Err()
 	}
 
 	user, err := u.userRepo.GetUserByEmail(ctx, request.Email)
 	if err != nil {
-		lib.LogError(ctx, u.logger, "UserService.GetByEmail", "GetUserByEmail", "failed to get user by email", err,
+		if f := repoFault(err, errorsPkg.UserNotFound); f != nil {
+			return db.User{}, f
+		}
+		helper.LogError(ctx, u.logger, "UserService.GetByEmail", "GetUserByEmail", "failed to get user by email", err,
 			slog.String("email", request.Email),
 		)
 		return db.User{}, errorsPkg.InfrastructureUnexpected.Err()
@@ -86,35 +115,138 @@ func (u *UserService) GetByEmail(ctx context.Context, request dto.GetUserByEmail
 	return user, nil
 }
 
-func (u *UserService) List(ctx context.Context, request dto.ListUsersRequest) ([]db.User, error) {
-	lib.LogDebug(ctx, u.logger, "UserService.List", "listing users",
+// List searches users and pages through them. The default newest-first order pages
+// by cursor; other sort orders page by Offset and never issue cursors.
+func (u *UserService) List(ctx context.Context, request dto.ListUsersRequest) (dto.UsersPage, error) {
+	helper.LogDebug(ctx, u.logger, "UserService.List", "listing users",
+		slog.String("username_prefix", request.UsernamePrefix),
+		slog.String("email_domain", request.EmailDomain),
+		slog.String("sort", request.Sort),
 		slog.Int("limit", int(request.Limit)),
 		slog.Int("offset", int(request.Offset)),
+		slog.Bool("cursor", request.Cursor != ""),
 	)
 
-	users, err := u.userRepo.ListUsers(ctx, db.ListUsersParams{
-		Limit:  request.Limit,
-		Offset: request.Offset,
-	})
+	sort := request.Sort
+	if sort == "" {
+		sort = defaultUserSort
+	}
+	from, err := decodeCursor(u.cursors, cursorScopeUsers, request.Cursor)
 	if err != nil {
-		lib.LogError(ctx, u.logger, "UserService.List", "ListUsers", "failed to list users", err,
+		return dto.UsersPage{}, err
+	}
+	if from != nil && sort != defaultUserSort {
+		return dto.UsersPage{}, errorsPkg.CursorInvalid.Err(&fault.Arg{K: "sort", V: sort})
+	}
+	limit := request.Limit
+	if limit == 0 {
+		limit = defaultUserPageLimit
+	}
+	filter := userFilter(request)
+
+	// One row past the limit tells whether another page exists.
+	var users []db.User
+	switch {
+	case from == nil:
+		users, err = u.userRepo.ListUsers(ctx, db.ListUsersParams{
+			UsernamePrefix: filter.UsernamePrefix,
+			EmailDomain:    filter.EmailDomain,
+			IsActive:       filter.IsActive,
+			CreatedFrom:    filter.CreatedFrom,
+			CreatedTo:      filter.CreatedTo,
+			Deleted:        filter.Deleted,
+			Sort:           sort,
+			Limit:          limit + 1,
+			Offset:         request.Offset,
+		})
+	case from.Prev:
+		users, err = u.userRepo.ListUsersBefore(ctx, db.ListUsersBeforeParams{
+			UsernamePrefix: filter.UsernamePrefix,
+			EmailDomain:    filter.EmailDomain,
+			IsActive:       filter.IsActive,
+			CreatedFrom:    filter.CreatedFrom,
+			CreatedTo:      filter.CreatedTo,
+			Deleted:        filter.Deleted,
+			CreatedAt:      from.At,
+			ID:             from.ID,
+			Limit:          limit + 1,
+		})
+	default:
+		users, err = u.userRepo.ListUsersAfter(ctx, db.ListUsersAfterParams{
+			UsernamePrefix: filter.UsernamePrefix,
+			EmailDomain:    filter.EmailDomain,
+			IsActive:       filter.IsActive,
+			CreatedFrom:    filter.CreatedFrom,
+			CreatedTo:      filter.CreatedTo,
+			Deleted:        filter.Deleted,
+			CreatedAt:      from.At,
+			ID:             from.ID,
+			Limit:          limit + 1,
+		})
+	}
+	if err != nil {
+		helper.LogError(ctx, u.logger, "UserService.List", "ListUsers", "failed to list users", err,
 			slog.Int("limit", int(request.Limit)),
 			slog.Int("offset", int(request.Offset)),
 		)
-		return nil, errorsPkg.InfrastructureUnexpected.Err()
+		return dto.UsersPage{}, errorsPkg.InfrastructureUnexpected.Err()
+	}
+
+	total, err := u.userRepo.CountUsers(ctx, filter)
+	if err != nil {
+		helper.LogError(ctx, u.logger, "UserService.List", "CountUsers", "failed to count users", err)
+		return dto.UsersPage{}, errorsPkg.InfrastructureUnexpected.Err()
 	}
 
-	lib.LogInfo(ctx, u.logger, "UserService.List", "users listed successfully",
-		slog.Int("count", len(users)),
+	var page dto.Page[db.User]
+	if sort == defaultUserSort {
+		page = keysetPage(u.cursors, cursorScopeUsers, users, limit, from, request.Offset, func(user db.User) (time.Time, pgtype.UUID) {
+			return user.CreatedAt.Time, user.ID
+		})
+	} else {
+		if int32(len(users)) > limit {
+			users = users[:limit]
+		}
+		page = dto.Page[db.User]{Items: users}
+	}
+
+	helper.LogInfo(ctx, u.logger, "UserService.List", "users listed successfully",
+		slog.Int("count", len(page.Items)),
+		slog.Int64("total", total),
 		slog.Int("limit", int(request.Limit)),
 		slog.Int("offset", int(request.Offset)),
 	)
 
-	return users, nil
+	return dto.UsersPage{Page: page, Total: total}, nil
+}
+
+// userFilter maps the optional search fields to query arguments; unset fields stay NULL.
+// Live listings show active users unless is_active says otherwise.
+func userFilter(request dto.ListUsersRequest) db.CountUsersParams {
+	filter := db.CountUsersParams{Deleted: request.Deleted}
+	if !request.Deleted {
+		filter.IsActive = pgtype.Bool{Bool: true, Valid: true}
+	}
+	if request.UsernamePrefix != "" {
+		filter.UsernamePrefix = pgtype.Text{String: likeEscaper.Replace(request.UsernamePrefix), Valid: true}
+	}
+	if request.EmailDomain != "" {
+		filter.EmailDomain = pgtype.Text{String: request.EmailDomain, Valid: true}
+	}
+	if request.IsActive != nil {
+		filter.IsActive = pgtype.Bool{Bool: *request.IsActive, Valid: true}
+	}
+	if request.CreatedFrom != nil {
+		filter.CreatedFrom = pgtype.Timestamptz{Time: *request.CreatedFrom, Valid: true}
+	}
+	if request.CreatedTo != nil {
+		filter.CreatedTo = pgtype.Timestamptz{Time: *request.CreatedTo, Valid: true}
+	}
+	return filter
 }
 
 func (u *UserService) Update(ctx context.Context, request dto.UpdateUserRequest) (db.User, error) {
-	lib.LogDebug(ctx, u.logger, "UserService.Update", "updating user",
+	helper.LogDebug(ctx, u.logger, "UserService.Update", "updating user",
 		slog.String("user_id", request.ID.String()),
 		slog.String("username", request.Username),
 		slog.String("email", request.Email),
@@ -126,7 +258,10 @@ func (u *UserService) Update(ctx context.Context, request dto.UpdateUserRequest)
 		Email:    request.Email,
 	})
 	if err != nil {
-		lib.LogError(ctx, u.logger, "UserService.Update", "UpdateUser", "failed to update user", err,
+		if f := repoFault(err, errorsPkg.UserNotFound); f != nil {
+			return db.User{}, f
+		}
+		helper.LogError(ctx, u.logger, "UserService.Update", "UpdateUser", "failed to update user", err,
 			slog.String("user_id", request.ID.String()),
 			slog.String("username", request.Username),
 			slog.String("email", request.Email),
@@ -134,7 +269,7 @@ func (u *UserService) Update(ctx context.Context, request dto.UpdateUserRequest)
 		return db.User{}, errorsPkg.InfrastructureUnexpected.Err()
 	}
 
-	lib.LogInfo(ctx, u.logger, "UserService.Update", "user updated successfully",
+	helper.LogInfo(ctx, u.logger, "UserService.Update", "user updated successfully",
 		slog.String("user_id", user.ID.String()),
 		slog.String("username", user.Username),
 	)
@@ -142,22 +277,101 @@ func (u *UserService) Update(ctx context.Context, request dto.UpdateUserRequest)
 	return user, nil
 }
 
+// Delete soft-deletes the user; Restore undoes it within the restore period.
 func (u *UserService) Delete(ctx context.Context, request dto.DeleteUserRequest) error {
-	lib.LogDebug(ctx, u.logger, "UserService.Delete", "deleting user",
+	helper.LogDebug(ctx, u.logger, "UserService.Delete", "deleting user",
 		slog.String("user_id", request.ID.String()),
 	)
 
-	err := u.userRepo.DeleteUser(ctx, request.ID)
+	deleted, err := u.userRepo.DeleteUser(ctx, request.ID)
 	if err != nil {
-		lib.LogError(ctx, u.logger, "UserService.Delete", "DeleteUser", "failed to delete user", err,
+		if f := repoFault(err, errorsPkg.UserNotFound); f != nil {
+			return f
+		}
+		helper.LogError(ctx, u.logger, "UserService.Delete", "DeleteUser", "failed to delete user", err,
 			slog.String("user_id", request.ID.String()),
 		)
 		return errorsPkg.InfrastructureUnexpected.Err()
 	}
+	if deleted == 0 {
+		return errorsPkg.UserNotFound.Err()
+	}
 
-	lib.LogInfo(ctx, u.logger, "UserService.Delete", "user deleted successfully",
+	helper.LogInfo(ctx, u.logger, "UserService.Delete", "user deleted successfully",
 		slog.String("user_id", request.ID.String()),
+		slog.Duration("restore_period", u.restorePeriod),
 	)
 
 	return nil
 }
+
+// Deactivate hides the user from reads and blocks sign-in until Reactivate.
+func (u *UserService) Deactivate(ctx context.Context, request dto.DeactivateUserRequest) (db.User, error) {
+	return u.setActive(ctx, "UserService.Deactivate", request.ID, false)
+}
+
+func (u *UserService) Reactivate(ctx context.Context, request dto.ReactivateUserRequest) (db.User, error) {
+	return u.setActive(ctx, "UserService.Reactivate", request.ID, true)
+}
+
+func (u *UserService) setActive(ctx context.Context, op string, id pgtype.UUID, active bool) (db.User, error) {
+	helper.LogDebug(ctx, u.logger, op, "setting user active flag",
+		slog.String("user_id", id.String()),
+		slog.Bool("is_active", active),
+	)
+
+	user, err := u.userRepo.SetUserActive(ctx, db.SetUserActiveParams{
+		ID:       id,
+		IsActive: active,
+	})
+	if err != nil {
+		if f := repoFault(err, errorsPkg.UserNotFound); f != nil {
+			return db.User{}, f
+		}
+		helper.LogError(ctx, u.logger, op, "SetUserActive", "failed to set user active flag", err,
+			slog.String("user_id", id.String()),
+			slog.Bool("is_active", active),
+		)
+		return db.User{}, errorsPkg.InfrastructureUnexpected.Err()
+	}
+
+	helper.LogInfo(ctx, u.logger, op, "user active flag updated",
+		slog.String("user_id", user.ID.String()),
+		slog.Bool("is_active", user.IsActive),
+	)
+
+	return user, nil
+}
+
+// Restore undoes a soft delete. Users deleted longer than the restore period ago
+// are reported as not found, even before the purge job has removed them.
+func (u *UserService) Restore(ctx context.Context, request dto.RestoreUserRequest) (db.User, error) {
+	helper.LogDebug(ctx, u.logger, "UserService.Restore", "restoring user",
+		slog.String("user_id", request.ID.String()),
+	)
+
+	deletedAfter := time.Now().Add(-u.restorePeriod)
+	user, err := u.userRepo.RestoreUser(ctx, db.RestoreUserParams{
+		ID:           request.ID,
+		DeletedAfter: pgtype.Timestamptz{Time: deletedAfter, Valid: true},
+	})
+	if err != nil {
+		if f := repoFault(err, errorsPkg.UserNotFound); f != nil {
+			// Someone registered the email again while the account was deleted.
+			if f.Code == string(errorsPkg.Conflict) {
+				f = errorsPkg.EmailAlreadyRegistered.Err()
+			}
+			return db.User{}, f
+		}
+		helper.LogError(ctx, u.logger, "UserService.Restore", "RestoreUser", "failed to restore user", err,
+			slog.String("user_id", request.ID.String()),
+		)
+		return db.User{}, errorsPkg.InfrastructureUnexpected.Err()
+	}
+
+	helper.LogInfo(ctx, u.logger, "UserService.Restore", "user restored successfully",
+		slog.String("user_id", user.ID.String()),
+	)
+
+	return user, nil
+}
@@ -6,15 +6,22 @@ import (
 	"io"
 	"log/slog"
 	"testing"
+	"time"
 
 	"test-http/internal/db"
 	"test-http/internal/db/mocks"
 	"test-http/internal/dto"
+	errorsPkg "test-http/pkg/errors_pkg"
+	"test-http/pkg/fault"
 
 	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const testRestorePeriod = 30 * 24 * time.Hour
+
 func TestUserService_Create_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -22,7 +29,7 @@ func TestUserService_Create_Success(t *testing.T) {
 	mockRepo := mocks.NewMockUserRepo(ctrl)
 	// minimal no-op logger for tests
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-	svc := NewUserService(mockRepo, logger)
+	svc := NewUserService(mockRepo, testRestorePeriod, testCursors, logger)
 
 	params := db.CreateUserParams{Username: "alice", Email: "alice@example.com"}
 	want := db.User{Username: "alice", Email: "alice@example.com"}
@@ -44,7 +51,7 @@ func TestUserService_GetByEmail_InvalidEmail(t *testing.T) {
 
 	mockRepo := mocks.NewMockUserRepo(ctrl)
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-	svc := NewUserService(mockRepo, logger)
+	svc := NewUserService(mockRepo, testRestorePeriod, testCursors, logger)
 
 	_, err := svc.GetByEmail(context.Background(), dto.GetUserByEmailRequest{Email: "bad-email"})
 	if err == nil {
@@ -58,7 +65,7 @@ func TestUserService_GetByEmail_RepoError(t *testing.T) {
 
 	mockRepo := mocks.NewMockUserRepo(ctrl)
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-	svc := NewUserService(mockRepo, logger)
+	svc := NewUserService(mockRepo, testRestorePeriod, testCursors, logger)
 
 	mockRepo.EXPECT().GetUserByEmail(gomock.Any(), "nope@example.com").Return(db.User{}, errors.New("not found"))
 
@@ -74,17 +81,20 @@ func TestUserService_List_Success(t *testing.T) {
 
 	mockRepo := mocks.NewMockUserRepo(ctrl)
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-	svc := NewUserService(mockRepo, logger)
+	svc := NewUserService(mockRepo, testRestorePeriod, testCursors, logger)
 
 	users := []db.User{{Username: "a"}, {Username: "b"}}
-	mockRepo.EXPECT().ListUsers(gomock.Any(), db.ListUsersParams{Limit: 10, Offset: 0}).Return(users, nil)
+	// Only active users are listed unless the request says otherwise.
+	active := pgtype.Bool{Bool: true, Valid: true}
+	mockRepo.EXPECT().ListUsers(gomock.Any(), db.ListUsersParams{IsActive: active, Sort: "-created_at", Limit: 11, Offset: 0}).Return(users, nil)
+	mockRepo.EXPECT().CountUsers(gomock.Any(), db.CountUsersParams{IsActive: active}).Return(int64(2), nil)
 
 	got, err := svc.List(context.Background(), dto.ListUsersRequest{Limit: 10, Offset: 0})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if len(got) != len(users) {
-		t.Fatalf("got %d users want %d", len(got), len(users))
+	if len(got.Items) != len(users) || got.Total != 2 {
+		t.Fatalf("got %d users (total %d) want %d", len(got.Items), got.Total, len(users))
 	}
 }
 
@@ -94,7 +104,7 @@ func TestUserService_Update_Success(t *testing.T) {
 
 	mockRepo := mocks.NewMockUserRepo(ctrl)
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-	svc := NewUserService(mockRepo, logger)
+	svc := NewUserService(mockRepo, testRestorePeriod, testCursors, logger)
 
 	// Use zero UUID for simplicity
 	var id pgtype.UUID
@@ -120,7 +130,7 @@ func TestUserService_Delete_Success(t *testing.T) {
 
 	mockRepo := mocks.NewMockUserRepo(ctrl)
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-	svc := NewUserService(mockRepo, logger)
+	svc := NewUserService(mockRepo, testRestorePeriod, testCursors, logger)
 
 	uuidStr := "550e8400-e29b-41d4-a716-446655440000"
 	var id pgtype.UUID
@@ -128,10 +138,132 @@ func TestUserService_Delete_Success(t *testing.T) {
 		t.Fatalf("failed to convert uuid")
 	}
 
-	mockRepo.EXPECT().DeleteUser(gomock.Any(), gomock.Any()).Return(nil)
+	mockRepo.EXPECT().DeleteUser(gomock.Any(), gomock.Any()).Return(int64(1), nil)
 
 	err := svc.Delete(context.Background(), dto.DeleteUserRequest{ID: id})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestUserService_GetByID_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewUserService(mockRepo, testRestorePeriod, testCursors, logger)
+
+	mockRepo.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(db.User{}, pgx.ErrNoRows)
+
+	_, err := svc.GetByID(context.Background(), dto.GetUserByIDRequest{})
+	if err == nil || err.Error() != string(errorsPkg.UserNotFound) {
+		t.Fatalf("expected %s, got %v", errorsPkg.UserNotFound, err)
+	}
+}
+
+func TestUserService_Update_EmailTaken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewUserService(mockRepo, testRestorePeriod, testCursors, logger)
+
+	mockRepo.EXPECT().UpdateUser(gomock.Any(), gomock.Any()).Return(db.User{}, &pgconn.PgError{
+		Code:           pgUniqueViolation,
+		TableName:      "users",
+		ConstraintName: "users_email_key",
+		Detail:         "Key (email)=(bob@example.com) already exists.",
+	})
+
+	_, err := svc.Update(context.Background(), dto.UpdateUserRequest{Email: "bob@example.com"})
+	if err == nil || err.Error() != string(errorsPkg.Conflict) {
+		t.Fatalf("expected %s, got %v", errorsPkg.Conflict, err)
+	}
+	if f := fault.HandleErr(err); f.Args["field"] != "email" || f.Args["constraint"] != "users_email_key" {
+		t.Fatalf("unexpected args: %v", f.Args)
+	}
+}
+
+func TestUserService_Delete_AlreadyDeleted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewUserService(mockRepo, testRestorePeriod, testCursors, logger)
+
+	mockRepo.EXPECT().DeleteUser(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+
+	err := svc.Delete(context.Background(), dto.DeleteUserRequest{})
+	if err == nil || err.Error() != string(errorsPkg.UserNotFound) {
+		t.Fatalf("expected %s, got %v", errorsPkg.UserNotFound, err)
+	}
+}
+
+func TestUserService_Deactivate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewUserService(mockRepo, testRestorePeriod, testCursors, logger)
+
+	id := pgtype.UUID{Bytes: [16]byte{1}, Valid: true}
+	mockRepo.EXPECT().SetUserActive(gomock.Any(), db.SetUserActiveParams{ID: id, IsActive: false}).
+		Return(db.User{ID: id, IsActive: false}, nil)
+
+	user, err := svc.Deactivate(context.Background(), dto.DeactivateUserRequest{ID: id})
+	if err != nil || user.IsActive {
+		t.Fatalf("got %+v, %v; want an inactive user", user, err)
+	}
+
+	mockRepo.EXPECT().SetUserActive(gomock.Any(), db.SetUserActiveParams{ID: id, IsActive: true}).
+		Return(db.User{}, pgx.ErrNoRows)
+
+	_, err = svc.Reactivate(context.Background(), dto.ReactivateUserRequest{ID: id})
+	if err == nil || err.Error() != string(errorsPkg.UserNotFound) {
+		t.Fatalf("expected %s for a deleted user, got %v", errorsPkg.UserNotFound, err)
+	}
+}
+
+func TestUserService_Restore_WithinPeriod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewUserService(mockRepo, testRestorePeriod, testCursors, logger)
+
+	id := pgtype.UUID{Bytes: [16]byte{1}, Valid: true}
+	mockRepo.EXPECT().RestoreUser(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, arg db.RestoreUserParams) (db.User, error) {
+			if age := time.Since(arg.DeletedAfter.Time); age < testRestorePeriod || age > testRestorePeriod+time.Minute {
+				t.Errorf("restore cut-off %s is not one restore period ago", arg.DeletedAfter.Time)
+			}
+			return db.User{}, pgx.ErrNoRows
+		})
+
+	_, err := svc.Restore(context.Background(), dto.RestoreUserRequest{ID: id})
+	if err == nil || err.Error() != string(errorsPkg.UserNotFound) {
+		t.Fatalf("expected %s past the restore period, got %v", errorsPkg.UserNotFound, err)
+	}
+}
+
+func TestUserService_Restore_EmailTaken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewUserService(mockRepo, testRestorePeriod, testCursors, logger)
+
+	mockRepo.EXPECT().RestoreUser(gomock.Any(), gomock.Any()).
+		Return(db.User{}, &pgconn.PgError{Code: pgUniqueViolation, ConstraintName: "users_email_key"})
+
+	_, err := svc.Restore(context.Background(), dto.RestoreUserRequest{ID: pgtype.UUID{Bytes: [16]byte{1}, Valid: true}})
+	if err == nil || err.Error() != string(errorsPkg.EmailAlreadyRegistered) {
+		t.Fatalf("expected %s, got %v", errorsPkg.EmailAlreadyRegistered, err)
+	}
+}
@@ -2,138 +2,252 @@ package service
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"time"
 
 	"log/slog"
 
 	"test-http/internal/db"
 	"test-http/internal/dto"
-	"test-http/internal/lib"
+	"test-http/pkg/cursor"
 	errorsPkg "test-http/pkg/errors_pkg"
+	"test-http/pkg/fault"
+	"test-http/pkg/helper"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const defaultSessionPageLimit = 20
+
+// Session event kinds, mirrored by the user_session_events kind check.
+const (
+	SessionEventAnswer    = "answer"
+	SessionEventPause     = "pause"
+	SessionEventResume    = "resume"
+	SessionEventHeartbeat = "heartbeat"
+)
+
+// sessionTransitions lists the statuses a session may move to. Ended sessions are final.
+var sessionTransitions = map[string][]string{
+	SessionStatusActive: {SessionStatusCompleted, SessionStatusAbandoned},
+}
+
+func canTransitionSession(from, to string) bool {
+	return slices.Contains(sessionTransitions[from], to)
+}
+
 type UserSessionService struct {
 	userSessionRepo db.UserSessionRepo
+	txRunner        db.TxRunner
+	activityGap     time.Duration
+	cursors         *cursor.Codec
 	logger          *slog.Logger
 }
 
-func NewUserSessionService(userSessionRepo db.UserSessionRepo, log *slog.Logger) *UserSessionService {
+// NewUserSessionService builds the service; activityGap is the longest pause between
+// two events that still counts as time on task.
+func NewUserSessionService(userSessionRepo db.UserSessionRepo, txRunner db.TxRunner, activityGap time.Duration, cursors *cursor.Codec, log *slog.Logger) *UserSessionService {
 	return &UserSessionService{
 		userSessionRepo: userSessionRepo,
+		txRunner:        txRunner,
+		activityGap:     activityGap,
+		cursors:         cursors,
 		logger:          log,
 	}
 }
 
 func (u *UserSessionService) Create(ctx context.Context, request dto.CreateUserSessionRequest) (db.UserSession, error) {
-	lib.LogDebug(ctx, u.logger, "UserSessionService.Create", "creating user session",
+	helper.LogDebug(ctx, u.logger, "UserSessionService.Create", "creating user session",
 		slog.String("user_id", request.UserID.String()),
-		slog.String("status", request.Status),
 	)
 
 	session, err := u.userSessionRepo.CreateUserSession(ctx, db.CreateUserSessionParams{
 		UserID: request.UserID,
-		Status: request.Status,
+		Status: SessionStatusActive,
 	})
 	if err != nil {
-		lib.LogError(ctx, u.logger, "UserSessionService.Create", "CreateUserSession", "failed to create user session", err,
+		if f := createSessionFault(err); f != nil {
+			return db.UserSession{}, f
+		}
+		helper.LogError(ctx, u.logger, "UserSessionService.Create", "CreateUserSession", "failed to create user session", err,
 			slog.String("user_id", request.UserID.String()),
-			slog.String("status", request.Status),
 		)
 		return db.UserSession{}, errorsPkg.InfrastructureUnexpected.Err()
 	}
 
-	lib.LogInfo(ctx, u.logger, "UserSessionService.Create", "user session created successfully",
+	helper.LogInfo(ctx, u.logger, "UserSessionService.Create", "user session created successfully",
 		slog.String("session_id", session.ID.String()),
 		slog.String("user_id", request.UserID.String()),
-		slog.String("status", request.Status),
 	)
 
 	return session, nil
 }
 
+// createSessionFault classifies an error of CreateUserSession like repoFault, except that
+// user_sessions_one_active_per_user allows a single active session per user.
+func createSessionFault(err error) *fault.Fault {
+	f := repoFault(err, errorsPkg.UserSessionNotFound)
+	if f != nil && f.Code == string(errorsPkg.Conflict) {
+		return errorsPkg.UserSessionAlreadyActive.Err()
+	}
+	return f
+}
+
 func (u *UserSessionService) GetByID(ctx context.Context, request dto.GetUserSessionRequest) (db.UserSession, error) {
-	lib.LogDebug(ctx, u.logger, "UserSessionService.GetByID", "getting user session by id",
+	helper.LogDebug(ctx, u.logger, "UserSessionService.GetByID", "getting user session by id",
 		slog.String("session_id", request.ID.String()),
 	)
 
 	session, err := u.userSessionRepo.GetUserSession(ctx, request.ID)
 	if err != nil {
-		lib.LogError(ctx, u.logger, "UserSessionRepository.GetByID", "GetUserSession", "failed to get user session by id", err,
+		if f := repoFault(err, errorsPkg.UserSessionNotFound); f != nil {
+			return db.UserSession{}, f
+		}
+		helper.LogError(ctx, u.logger, "UserSessionRepository.GetByID", "GetUserSession", "failed to get user session by id", err,
 			slog.String("session_id", request.ID.String()),
 		)
 		return db.UserSession{}, errorsPkg.InfrastructureUnexpected.Err()
 	}
+	// Another user's session is reported as missing rather than forbidden.
+	if session.UserID != request.UserID {
+		return db.UserSession{}, errorsPkg.UserSessionNotFound.Err()
+	}
 
 	return session, nil
 }
 
-func (u *UserSessionService) List(ctx context.Context, request dto.ListUserSessionsRequest) ([]db.UserSession, error) {
-	lib.LogDebug(ctx, u.logger, "UserSessionRepository.List", "listing user sessions",
+// List pages through the user's sessions, newest first. A cursor takes precedence over Offset.
+func (u *UserSessionService) List(ctx context.Context, request dto.ListUserSessionsRequest) (dto.Page[db.UserSession], error) {
+	helper.LogDebug(ctx, u.logger, "UserSessionRepository.List", "listing user sessions",
 		slog.String("user_id", request.UserID.String()),
+		slog.String("status", request.Status),
 		slog.Int("limit", int(request.Limit)),
 		slog.Int("offset", int(request.Offset)),
+		slog.Bool("cursor", request.Cursor != ""),
 	)
 
-	sessions, err := u.userSessionRepo.ListUserSessions(ctx, db.ListUserSessionsParams{
-		UserID: request.UserID,
-		Limit:  request.Limit,
-		Offset: request.Offset,
-	})
+	from, err := decodeCursor(u.cursors, cursorScopeUserSessions, request.Cursor)
 	if err != nil {
-		lib.LogError(ctx, u.logger, "UserSessionService.List", "ListUserSessions", "failed to list user sessions", err,
+		return dto.Page[db.UserSession]{}, err
+	}
+	limit := request.Limit
+	if limit == 0 {
+		limit = defaultSessionPageLimit
+	}
+	status := pgtype.Text{String: request.Status, Valid: request.Status != ""}
+
+	var sessions []db.UserSession
+	switch {
+	case from == nil:
+		sessions, err = u.userSessionRepo.ListUserSessions(ctx, db.ListUserSessionsParams{
+			UserID: request.UserID,
+			Status: status,
+			Limit:  limit + 1,
+			Offset: request.Offset,
+		})
+	case from.Prev:
+		sessions, err = u.userSessionRepo.ListUserSessionsBefore(ctx, db.ListUserSessionsBeforeParams{
+			UserID:    request.UserID,
+			Status:    status,
+			StartedAt: from.At,
+			ID:        from.ID,
+			Limit:     limit + 1,
+		})
+	default:
+		sessions, err = u.userSessionRepo.ListUserSessionsAfter(ctx, db.ListUserSessionsAfterParams{
+			UserID:    request.UserID,
+			Status:    status,
+			StartedAt: from.At,
+			ID:        from.ID,
+			Limit:     limit + 1,
+		})
+	}
+	if err != nil {
+		helper.LogError(ctx, u.logger, "UserSessionService.List", "ListUserSessions", "failed to list user sessions", err,
 			slog.String("user_id", request.UserID.String()),
 		)
-		return nil, errorsPkg.InfrastructureUnexpected.Err()
+		return dto.Page[db.UserSession]{}, errorsPkg.InfrastructureUnexpected.Err()
 	}
 
-	lib.LogInfo(ctx, u.logger, "UserSessionService.List", "user sessions listed successfully",
-		slog.Int("count", len(sessions)),
+	page := keysetPage(u.cursors, cursorScopeUserSessions, sessions, limit, from, request.Offset, func(s db.UserSession) (time.Time, pgtype.UUID) {
+		return s.StartedAt.Time, s.ID
+	})
+
+	helper.LogInfo(ctx, u.logger, "UserSessionService.List", "user sessions listed successfully",
+		slog.Int("count", len(page.Items)),
 		slog.String("user_id", request.UserID.String()),
 	)
 
-	return sessions, nil
+	return page, nil
 }
 
-func (u *UserSessionService) ListActive(ctx context.Context) ([]db.UserSession, error) {
-	lib.LogDebug(ctx, u.logger, "UserSessionService.ListActive", "listing active sessions")
+func (u *UserSessionService) ListActive(ctx context.Context, request dto.ListActiveUserSessionsRequest) ([]db.UserSession, error) {
+	helper.LogDebug(ctx, u.logger, "UserSessionService.ListActive", "listing active sessions",
+		slog.String("user_id", request.UserID.String()),
+	)
+
+	limit := request.Limit
+	if limit == 0 {
+		limit = defaultSessionPageLimit
+	}
 
 	sessions, err := u.userSessionRepo.ListActiveSessions(ctx, db.ListActiveSessionsParams{
-		Limit:  100,
-		Offset: 0,
+		UserID: request.UserID,
+		Limit:  limit,
+		Offset: request.Offset,
 	})
 	if err != nil {
-		lib.LogError(ctx, u.logger, "UserSessionService.ListActive", "ListActiveSessions", "failed to list active sessions", err)
+		helper.LogError(ctx, u.logger, "UserSessionService.ListActive", "ListActiveSessions", "failed to list active sessions", err,
+			slog.String("user_id", request.UserID.String()),
+		)
 		return nil, errorsPkg.InfrastructureUnexpected.Err()
 	}
 
-	lib.LogInfo(ctx, u.logger, "UserSessionService.ListActive", "active sessions listed successfully",
+	helper.LogInfo(ctx, u.logger, "UserSessionService.ListActive", "active sessions listed successfully",
 		slog.Int("count", len(sessions)),
+		slog.String("user_id", request.UserID.String()),
 	)
 
 	return sessions, nil
 }
 
 func (u *UserSessionService) Update(ctx context.Context, request dto.UpdateUserSessionRequest) (db.UserSession, error) {
-	lib.LogDebug(ctx, u.logger, "UserSessionService.Update", "updating user session",
+	helper.LogDebug(ctx, u.logger, "UserSessionService.Update", "updating user session",
 		slog.String("session_id", request.ID.String()),
 		slog.String("status", request.Status),
 	)
 
+	current, err := u.GetByID(ctx, dto.GetUserSessionRequest{ID: request.ID, UserID: request.UserID})
+	if err != nil {
+		return db.UserSession{}, err
+	}
+	if !canTransitionSession(current.Status, request.Status) {
+		return db.UserSession{}, errorsPkg.UserSessionTransitionInvalid.Err(
+			&fault.Arg{K: "from", V: current.Status},
+			&fault.Arg{K: "to", V: request.Status},
+		)
+	}
+
 	session, err := u.userSessionRepo.UpdateUserSession(ctx, db.UpdateUserSessionParams{
-		ID:      request.ID,
-		Status:  request.Status,
-		EndedAt: request.EndedAt,
+		ID:     request.ID,
+		UserID: request.UserID,
+		Status: request.Status,
 	})
 	if err != nil {
-		lib.LogError(ctx, u.logger, "UserSessionService.Update", "UpdateUserSession", "failed to update user session", err,
+		// No row means the session was ended concurrently, after we read it as active.
+		if f := repoFault(err, errorsPkg.UserSessionTransitionInvalid); f != nil {
+			return db.UserSession{}, f
+		}
+		helper.LogError(ctx, u.logger, "UserSessionService.Update", "UpdateUserSession", "failed to update user session", err,
 			slog.String("session_id", request.ID.String()),
 			slog.String("status", request.Status),
 		)
 		return db.UserSession{}, errorsPkg.InfrastructureUnexpected.Err()
 	}
 
-	lib.LogInfo(ctx, u.logger, "UserSessionService.Update", "user session updated successfully",
+	helper.LogInfo(ctx, u.logger, "UserSessionService.Update", "user session updated successfully",
 		slog.String("session_id", session.ID.String()),
 		slog.String("status", session.Status),
 	)
@@ -141,21 +255,98 @@ func (u *UserSessionService) Update(ctx context.Context, request dto.UpdateUserS
 	return session, nil
 }
 
-func (u *UserSessionService) Delete(ctx context.Context, id pgtype.UUID) error {
-	lib.LogDebug(ctx, u.logger, "UserSessionRepository.Delete", "deleting user session",
-		slog.String("session_id", id.String()),
+// RecordEvent logs a heartbeat, pause or resume against an active session and credits
+// the time since its previous event to active_seconds.
+func (u *UserSessionService) RecordEvent(ctx context.Context, request dto.RecordSessionEventRequest) (db.UserSession, error) {
+	helper.LogDebug(ctx, u.logger, "UserSessionService.RecordEvent", "recording session event",
+		slog.String("session_id", request.ID.String()),
+		slog.String("kind", request.Kind),
 	)
 
-	err := u.userSessionRepo.DeleteUserSession(ctx, id)
+	current, err := u.GetByID(ctx, dto.GetUserSessionRequest{ID: request.ID, UserID: request.UserID})
 	if err != nil {
-		lib.LogError(ctx, u.logger, "UserSessionService.Delete", "DeleteUserSession", "failed to delete user session", err,
-			slog.String("session_id", id.String()),
+		return db.UserSession{}, err
+	}
+	if current.Status != SessionStatusActive {
+		return db.UserSession{}, errorsPkg.UserSessionNotActive.Err()
+	}
+
+	var session db.UserSession
+	err = u.txRunner.InTx(ctx, func(tx db.Tx) error {
+		var err error
+		session, err = recordSessionActivity(ctx, tx.Sessions(), current, request.Kind, u.activityGap)
+		if err != nil {
+			// No row means the session was ended concurrently, after we read it as active.
+			if errors.Is(err, pgx.ErrNoRows) {
+				return errorsPkg.UserSessionNotActive.Err()
+			}
+			helper.LogError(ctx, u.logger, "UserSessionService.RecordEvent", "RecordSessionActivity", "failed to record session event", err,
+				slog.String("session_id", request.ID.String()),
+				slog.String("kind", request.Kind),
+			)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return db.UserSession{}, txError(err)
+	}
+
+	helper.LogInfo(ctx, u.logger, "UserSessionService.RecordEvent", "session event recorded successfully",
+		slog.String("session_id", session.ID.String()),
+		slog.String("kind", request.Kind),
+		slog.Int("active_seconds", int(session.ActiveSeconds)),
+	)
+
+	return session, nil
+}
+
+// recordSessionActivity advances the session's activity clock and appends the event.
+// Errors are returned raw so callers can tell pgx.ErrNoRows (session no longer active) apart.
+func recordSessionActivity(ctx context.Context, sessions db.UserSessionRepo, session db.UserSession, kind string, gap time.Duration) (db.UserSession, error) {
+	updated, err := sessions.RecordSessionActivity(ctx, db.RecordSessionActivityParams{
+		MaxGapSeconds: int32(gap / time.Second),
+		Kind:          kind,
+		ID:            session.ID,
+		UserID:        session.UserID,
+	})
+	if err != nil {
+		return db.UserSession{}, err
+	}
+	if _, err := sessions.CreateUserSessionEvent(ctx, db.CreateUserSessionEventParams{
+		SessionID: session.ID,
+		Kind:      kind,
+	}); err != nil {
+		return db.UserSession{}, err
+	}
+	return updated, nil
+}
+
+func (u *UserSessionService) Delete(ctx context.Context, request dto.DeleteUserSessionRequest) error {
+	helper.LogDebug(ctx, u.logger, "UserSessionRepository.Delete", "deleting user session",
+		slog.String("session_id", request.ID.String()),
+		slog.String("user_id", request.UserID.String()),
+	)
+
+	deleted, err := u.userSessionRepo.DeleteUserSession(ctx, db.DeleteUserSessionParams{
+		ID:     request.ID,
+		UserID: request.UserID,
+	})
+	if err != nil {
+		if f := repoFault(err, errorsPkg.UserSessionNotFound); f != nil {
+			return f
+		}
+		helper.LogError(ctx, u.logger, "UserSessionService.Delete", "DeleteUserSession", "failed to delete user session", err,
+			slog.String("session_id", request.ID.String()),
 		)
 		return errorsPkg.InfrastructureUnexpected.Err()
 	}
+	if deleted == 0 {
+		return errorsPkg.UserSessionNotFound.Err()
+	}
 
-	lib.LogInfo(ctx, u.logger, "UserSessionService.Delete", "user session deleted successfully",
-		slog.String("session_id", id.String()),
+	helper.LogInfo(ctx, u.logger, "UserSessionService.Delete", "user session deleted successfully",
+		slog.String("session_id", request.ID.String()),
 	)
 
 	return nil
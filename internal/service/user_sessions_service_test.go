@@ -6,12 +6,16 @@ import (
 	"io"
 	"log/slog"
 	"testing"
+	"time"
 
 	"test-http/internal/db"
 	"test-http/internal/db/mocks"
 	"test-http/internal/dto"
+	errorsPkg "test-http/pkg/errors_pkg"
 
 	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -21,10 +25,10 @@ func TestUserSessionService_Create_Success(t *testing.T) {
 
 	mockRepo := mocks.NewMockUserSessionRepo(ctrl)
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-	svc := NewUserSessionService(mockRepo, logger)
+	svc := NewUserSessionService(mockRepo, nil, time.Minute, testCursors, logger)
 
 	var uid pgtype.UUID
-	params := dto.CreateUserSessionRequest{UserID: uid, Status: "active"}
+	params := dto.CreateUserSessionRequest{UserID: uid}
 	want := db.UserSession{UserID: uid, Status: "active"}
 
 	mockRepo.EXPECT().CreateUserSession(gomock.Any(), db.CreateUserSessionParams{UserID: uid, Status: "active"}).Return(want, nil)
@@ -44,10 +48,10 @@ func TestUserSessionService_Create_RepoError(t *testing.T) {
 
 	mockRepo := mocks.NewMockUserSessionRepo(ctrl)
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-	svc := NewUserSessionService(mockRepo, logger)
+	svc := NewUserSessionService(mockRepo, nil, time.Minute, testCursors, logger)
 
 	var uid pgtype.UUID
-	params := dto.CreateUserSessionRequest{UserID: uid, Status: "active"}
+	params := dto.CreateUserSessionRequest{UserID: uid}
 
 	mockRepo.EXPECT().CreateUserSession(gomock.Any(), gomock.Any()).Return(db.UserSession{}, errors.New("db error"))
 
@@ -63,7 +67,7 @@ func TestUserSessionService_GetByID_Success(t *testing.T) {
 
 	mockRepo := mocks.NewMockUserSessionRepo(ctrl)
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-	svc := NewUserSessionService(mockRepo, logger)
+	svc := NewUserSessionService(mockRepo, nil, time.Minute, testCursors, logger)
 
 	var id pgtype.UUID
 	want := db.UserSession{ID: id, Status: "active"}
@@ -84,7 +88,7 @@ func TestUserSessionService_GetByID_RepoError(t *testing.T) {
 
 	mockRepo := mocks.NewMockUserSessionRepo(ctrl)
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-	svc := NewUserSessionService(mockRepo, logger)
+	svc := NewUserSessionService(mockRepo, nil, time.Minute, testCursors, logger)
 
 	var id pgtype.UUID
 	mockRepo.EXPECT().GetUserSession(gomock.Any(), id).Return(db.UserSession{}, errors.New("not found"))
@@ -101,18 +105,18 @@ func TestUserSessionService_List_Success(t *testing.T) {
 
 	mockRepo := mocks.NewMockUserSessionRepo(ctrl)
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-	svc := NewUserSessionService(mockRepo, logger)
+	svc := NewUserSessionService(mockRepo, nil, time.Minute, testCursors, logger)
 
 	var uid pgtype.UUID
 	users := []db.UserSession{{UserID: uid}, {UserID: uid}}
-	mockRepo.EXPECT().ListUserSessions(gomock.Any(), db.ListUserSessionsParams{UserID: uid, Limit: 10, Offset: 0}).Return(users, nil)
+	mockRepo.EXPECT().ListUserSessions(gomock.Any(), db.ListUserSessionsParams{UserID: uid, Limit: 11, Offset: 0}).Return(users, nil)
 
 	got, err := svc.List(context.Background(), dto.ListUserSessionsRequest{UserID: uid, Limit: 10, Offset: 0})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if len(got) != len(users) {
-		t.Fatalf("got %d want %d", len(got), len(users))
+	if len(got.Items) != len(users) {
+		t.Fatalf("got %d want %d", len(got.Items), len(users))
 	}
 }
 
@@ -122,10 +126,10 @@ func TestUserSessionService_List_RepoError(t *testing.T) {
 
 	mockRepo := mocks.NewMockUserSessionRepo(ctrl)
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-	svc := NewUserSessionService(mockRepo, logger)
+	svc := NewUserSessionService(mockRepo, nil, time.Minute, testCursors, logger)
 
 	var uid pgtype.UUID
-	mockRepo.EXPECT().ListUserSessions(gomock.Any(), db.ListUserSessionsParams{UserID: uid, Limit: 1, Offset: 0}).Return(nil, errors.New("db error"))
+	mockRepo.EXPECT().ListUserSessions(gomock.Any(), db.ListUserSessionsParams{UserID: uid, Limit: 2, Offset: 0}).Return(nil, errors.New("db error"))
 
 	_, err := svc.List(context.Background(), dto.ListUserSessionsRequest{UserID: uid, Limit: 1, Offset: 0})
 	if err == nil {
@@ -139,12 +143,12 @@ func TestUserSessionService_ListActive_Success(t *testing.T) {
 
 	mockRepo := mocks.NewMockUserSessionRepo(ctrl)
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-	svc := NewUserSessionService(mockRepo, logger)
+	svc := NewUserSessionService(mockRepo, nil, time.Minute, testCursors, logger)
 
 	sessions := []db.UserSession{{Status: "active"}}
 	mockRepo.EXPECT().ListActiveSessions(gomock.Any(), gomock.Any()).Return(sessions, nil)
 
-	got, err := svc.ListActive(context.Background())
+	got, err := svc.ListActive(context.Background(), dto.ListActiveUserSessionsRequest{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -159,11 +163,11 @@ func TestUserSessionService_ListActive_RepoError(t *testing.T) {
 
 	mockRepo := mocks.NewMockUserSessionRepo(ctrl)
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-	svc := NewUserSessionService(mockRepo, logger)
+	svc := NewUserSessionService(mockRepo, nil, time.Minute, testCursors, logger)
 
 	mockRepo.EXPECT().ListActiveSessions(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
 
-	_, err := svc.ListActive(context.Background())
+	_, err := svc.ListActive(context.Background(), dto.ListActiveUserSessionsRequest{})
 	if err == nil {
 		t.Fatalf("expected error, got nil")
 	}
@@ -175,16 +179,16 @@ func TestUserSessionService_Update_Success(t *testing.T) {
 
 	mockRepo := mocks.NewMockUserSessionRepo(ctrl)
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-	svc := NewUserSessionService(mockRepo, logger)
+	svc := NewUserSessionService(mockRepo, nil, time.Minute, testCursors, logger)
 
-	var id pgtype.UUID
-	var endedAt pgtype.Timestamptz
-	up := db.UpdateUserSessionParams{ID: id, Status: "active", EndedAt: endedAt}
-	want := db.UserSession{ID: id, Status: "active"}
+	var id, uid pgtype.UUID
+	up := db.UpdateUserSessionParams{ID: id, UserID: uid, Status: "completed"}
+	want := db.UserSession{ID: id, Status: "completed"}
 
+	mockRepo.EXPECT().GetUserSession(gomock.Any(), id).Return(db.UserSession{ID: id, UserID: uid, Status: "active"}, nil)
 	mockRepo.EXPECT().UpdateUserSession(gomock.Any(), up).Return(want, nil)
 
-	got, err := svc.Update(context.Background(), dto.UpdateUserSessionRequest{ID: id, Status: up.Status, EndedAt: endedAt})
+	got, err := svc.Update(context.Background(), dto.UpdateUserSessionRequest{ID: id, UserID: uid, Status: up.Status})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -199,32 +203,84 @@ func TestUserSessionService_Update_RepoError(t *testing.T) {
 
 	mockRepo := mocks.NewMockUserSessionRepo(ctrl)
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-	svc := NewUserSessionService(mockRepo, logger)
+	svc := NewUserSessionService(mockRepo, nil, time.Minute, testCursors, logger)
 
-	var id pgtype.UUID
-	var endedAt pgtype.Timestamptz
-	up := db.UpdateUserSessionParams{ID: id, Status: "active", EndedAt: endedAt}
+	var id, uid pgtype.UUID
+	up := db.UpdateUserSessionParams{ID: id, UserID: uid, Status: "abandoned"}
 
+	mockRepo.EXPECT().GetUserSession(gomock.Any(), id).Return(db.UserSession{ID: id, UserID: uid, Status: "active"}, nil)
 	mockRepo.EXPECT().UpdateUserSession(gomock.Any(), up).Return(db.UserSession{}, errors.New("db error"))
 
-	_, err := svc.Update(context.Background(), dto.UpdateUserSessionRequest{ID: id, Status: up.Status, EndedAt: endedAt})
+	_, err := svc.Update(context.Background(), dto.UpdateUserSessionRequest{ID: id, UserID: uid, Status: up.Status})
 	if err == nil {
 		t.Fatalf("expected error, got nil")
 	}
 }
 
+func TestUserSessionService_Update_EndedSessionIsFinal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserSessionRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewUserSessionService(mockRepo, nil, time.Minute, testCursors, logger)
+
+	var id, uid pgtype.UUID
+	mockRepo.EXPECT().GetUserSession(gomock.Any(), id).Return(db.UserSession{ID: id, UserID: uid, Status: "completed"}, nil)
+
+	_, err := svc.Update(context.Background(), dto.UpdateUserSessionRequest{ID: id, UserID: uid, Status: "abandoned"})
+	if err == nil || err.Error() != string(errorsPkg.UserSessionTransitionInvalid) {
+		t.Fatalf("expected %s, got %v", errorsPkg.UserSessionTransitionInvalid, err)
+	}
+}
+
+func TestUserSessionService_Update_EndedConcurrently(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserSessionRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewUserSessionService(mockRepo, nil, time.Minute, testCursors, logger)
+
+	var id, uid pgtype.UUID
+	mockRepo.EXPECT().GetUserSession(gomock.Any(), id).Return(db.UserSession{ID: id, UserID: uid, Status: "active"}, nil)
+	mockRepo.EXPECT().UpdateUserSession(gomock.Any(), gomock.Any()).Return(db.UserSession{}, pgx.ErrNoRows)
+
+	_, err := svc.Update(context.Background(), dto.UpdateUserSessionRequest{ID: id, UserID: uid, Status: "completed"})
+	if err == nil || err.Error() != string(errorsPkg.UserSessionTransitionInvalid) {
+		t.Fatalf("expected %s, got %v", errorsPkg.UserSessionTransitionInvalid, err)
+	}
+}
+
+func TestUserSessionService_Create_SecondActiveSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserSessionRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewUserSessionService(mockRepo, nil, time.Minute, testCursors, logger)
+
+	mockRepo.EXPECT().CreateUserSession(gomock.Any(), gomock.Any()).
+		Return(db.UserSession{}, &pgconn.PgError{Code: "23505", ConstraintName: "user_sessions_one_active_per_user"})
+
+	_, err := svc.Create(context.Background(), dto.CreateUserSessionRequest{})
+	if err == nil || err.Error() != string(errorsPkg.UserSessionAlreadyActive) {
+		t.Fatalf("expected %s, got %v", errorsPkg.UserSessionAlreadyActive, err)
+	}
+}
+
 func TestUserSessionService_Delete_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockUserSessionRepo(ctrl)
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-	svc := NewUserSessionService(mockRepo, logger)
+	svc := NewUserSessionService(mockRepo, nil, time.Minute, testCursors, logger)
 
 	var id pgtype.UUID
-	mockRepo.EXPECT().DeleteUserSession(gomock.Any(), id).Return(nil)
+	mockRepo.EXPECT().DeleteUserSession(gomock.Any(), db.DeleteUserSessionParams{ID: id}).Return(int64(1), nil)
 
-	err := svc.Delete(context.Background(), id)
+	err := svc.Delete(context.Background(), dto.DeleteUserSessionRequest{ID: id})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -236,14 +292,150 @@ func TestUserSessionService_Delete_RepoError(t *testing.T) {
 
 	mockRepo := mocks.NewMockUserSessionRepo(ctrl)
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-	svc := NewUserSessionService(mockRepo, logger)
+	svc := NewUserSessionService(mockRepo, nil, time.Minute, testCursors, logger)
 
 	var id pgtype.UUID
 
-	mockRepo.EXPECT().DeleteUserSession(gomock.Any(), id).Return(errors.New("db error"))
+	mockRepo.EXPECT().DeleteUserSession(gomock.Any(), db.DeleteUserSessionParams{ID: id}).Return(int64(0), errors.New("db error"))
 
-	err := svc.Delete(context.Background(), id)
+	err := svc.Delete(context.Background(), dto.DeleteUserSessionRequest{ID: id})
 	if err == nil {
 		t.Fatalf("expected error, got nil")
 	}
 }
+
+func TestUserSessionService_List_FiltersByStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserSessionRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewUserSessionService(mockRepo, nil, time.Minute, testCursors, logger)
+
+	userID := pgtype.UUID{Bytes: [16]byte{1}, Valid: true}
+	mockRepo.EXPECT().ListUserSessions(gomock.Any(), db.ListUserSessionsParams{
+		UserID: userID,
+		Status: pgtype.Text{String: "completed", Valid: true},
+		Limit:  defaultSessionPageLimit + 1,
+	}).Return([]db.UserSession{{UserID: userID, Status: "completed"}}, nil)
+
+	got, err := svc.List(context.Background(), dto.ListUserSessionsRequest{UserID: userID, Status: "completed"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got.Items) != 1 {
+		t.Fatalf("got %d sessions, want 1", len(got.Items))
+	}
+}
+
+func TestUserSessionService_GetByID_OtherUsersSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserSessionRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewUserSessionService(mockRepo, nil, time.Minute, testCursors, logger)
+
+	id := pgtype.UUID{Bytes: [16]byte{1}, Valid: true}
+	mockRepo.EXPECT().GetUserSession(gomock.Any(), id).
+		Return(db.UserSession{ID: id, UserID: pgtype.UUID{Bytes: [16]byte{9}, Valid: true}}, nil)
+
+	_, err := svc.GetByID(context.Background(), dto.GetUserSessionRequest{ID: id, UserID: pgtype.UUID{Bytes: [16]byte{2}, Valid: true}})
+	if err == nil || err.Error() != string(errorsPkg.UserSessionNotFound) {
+		t.Fatalf("expected %s, got %v", errorsPkg.UserSessionNotFound, err)
+	}
+}
+
+func TestUserSessionService_Delete_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserSessionRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewUserSessionService(mockRepo, nil, time.Minute, testCursors, logger)
+
+	mockRepo.EXPECT().DeleteUserSession(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+
+	err := svc.Delete(context.Background(), dto.DeleteUserSessionRequest{})
+	if err == nil || err.Error() != string(errorsPkg.UserSessionNotFound) {
+		t.Fatalf("expected %s, got %v", errorsPkg.UserSessionNotFound, err)
+	}
+}
+
+func TestUserSessionService_RecordEvent_CreditsActivity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserSessionRepo(ctrl)
+	tx := mocks.NewMockTx(ctrl)
+	tx.EXPECT().Sessions().Return(mockRepo).AnyTimes()
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewUserSessionService(mockRepo, inTx(ctrl, tx), 2*time.Minute, testCursors, logger)
+
+	sessionID := pgtype.UUID{Bytes: [16]byte{1}, Valid: true}
+	userID := pgtype.UUID{Bytes: [16]byte{2}, Valid: true}
+
+	gomock.InOrder(
+		mockRepo.EXPECT().GetUserSession(gomock.Any(), sessionID).
+			Return(db.UserSession{ID: sessionID, UserID: userID, Status: SessionStatusActive}, nil),
+		mockRepo.EXPECT().RecordSessionActivity(gomock.Any(), db.RecordSessionActivityParams{
+			MaxGapSeconds: 120,
+			Kind:          SessionEventPause,
+			ID:            sessionID,
+			UserID:        userID,
+		}).Return(db.UserSession{ID: sessionID, UserID: userID, Status: SessionStatusActive, ActiveSeconds: 42, Paused: true}, nil),
+		mockRepo.EXPECT().CreateUserSessionEvent(gomock.Any(), db.CreateUserSessionEventParams{SessionID: sessionID, Kind: SessionEventPause}).
+			Return(db.UserSessionEvent{SessionID: sessionID, Kind: SessionEventPause}, nil),
+	)
+
+	got, err := svc.RecordEvent(context.Background(), dto.RecordSessionEventRequest{ID: sessionID, UserID: userID, Kind: SessionEventPause})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.ActiveSeconds != 42 || !got.Paused {
+		t.Fatalf("unexpected session: %+v", got)
+	}
+}
+
+func TestUserSessionService_RecordEvent_EndedSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserSessionRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewUserSessionService(mockRepo, nil, time.Minute, testCursors, logger)
+
+	sessionID := pgtype.UUID{Bytes: [16]byte{1}, Valid: true}
+	userID := pgtype.UUID{Bytes: [16]byte{2}, Valid: true}
+
+	mockRepo.EXPECT().GetUserSession(gomock.Any(), sessionID).
+		Return(db.UserSession{ID: sessionID, UserID: userID, Status: SessionStatusCompleted}, nil)
+
+	_, err := svc.RecordEvent(context.Background(), dto.RecordSessionEventRequest{ID: sessionID, UserID: userID, Kind: SessionEventHeartbeat})
+	if err == nil || err.Error() != string(errorsPkg.UserSessionNotActive) {
+		t.Fatalf("expected %s, got %v", errorsPkg.UserSessionNotActive, err)
+	}
+}
+
+func TestUserSessionService_RecordEvent_EndedConcurrently(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserSessionRepo(ctrl)
+	tx := mocks.NewMockTx(ctrl)
+	tx.EXPECT().Sessions().Return(mockRepo).AnyTimes()
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewUserSessionService(mockRepo, inTx(ctrl, tx), time.Minute, testCursors, logger)
+
+	sessionID := pgtype.UUID{Bytes: [16]byte{1}, Valid: true}
+	userID := pgtype.UUID{Bytes: [16]byte{2}, Valid: true}
+
+	mockRepo.EXPECT().GetUserSession(gomock.Any(), sessionID).
+		Return(db.UserSession{ID: sessionID, UserID: userID, Status: SessionStatusActive}, nil)
+	mockRepo.EXPECT().RecordSessionActivity(gomock.Any(), gomock.Any()).Return(db.UserSession{}, pgx.ErrNoRows)
+
+	_, err := svc.RecordEvent(context.Background(), dto.RecordSessionEventRequest{ID: sessionID, UserID: userID, Kind: SessionEventResume})
+	if err == nil || err.Error() != string(errorsPkg.UserSessionNotActive) {
+		t.Fatalf("expected %s, got %v", errorsPkg.UserSessionNotActive, err)
+	}
+}
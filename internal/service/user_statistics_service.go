@@ -2,72 +2,45 @@ package service
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"log/slog"
 
 	"test-http/internal/db"
 	"test-http/internal/dto"
-	"test-http/internal/lib"
+	"test-http/pkg/cursor"
 	errorsPkg "test-http/pkg/errors_pkg"
 	"test-http/pkg/helper"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const defaultStatisticsPageLimit = 20
+
 type UserStatisticsService struct {
 	userStatistRepo db.UserStatisticsRepo
+	cursors         *cursor.Codec
 	logger          *slog.Logger
 }
 
-func NewUserStatisticsService(userStatistRepo db.UserStatisticsRepo, log *slog.Logger) *UserStatisticsService {
+func NewUserStatisticsService(userStatistRepo db.UserStatisticsRepo, cursors *cursor.Codec, log *slog.Logger) *UserStatisticsService {
 	return &UserStatisticsService{
 		userStatistRepo: userStatistRepo,
+		cursors:         cursors,
 		logger:          log,
 	}
 }
 
-func (u *UserStatisticsService) Create(ctx context.Context, request dto.CreateStatisticsRequest) (db.UserStatistic, error) {
-	lib.LogDebug(ctx, u.logger, "UserStatisticsService.Create", "creating user statistics",
-		slog.String("user_id", request.UserID.String()),
-		slog.Int("total_words_learned", int(request.TotalWordsLearned)),
-		slog.Int("total_time", int(request.TotalTime)),
-	)
-
-	accuracy, err := helper.ToNumeric(request.Accuracy)
-	if err != nil {
-		lib.LogError(ctx, u.logger, "UserStatisticsService.Create", "ToNumeric", "failed to convert accuracy", err,
-			slog.String("user_id", request.UserID.String()),
-		)
-		return db.UserStatistic{}, errorsPkg.InfrastructureUnexpected.Err()
-	}
-
-	stats, err := u.userStatistRepo.CreateUserStatistics(ctx, db.CreateUserStatisticsParams{
-		UserID:            request.UserID,
-		TotalWordsLearned: request.TotalWordsLearned,
-		Accuracy:          accuracy,
-		TotalTime:         request.TotalTime,
-	})
-	if err != nil {
-		lib.LogError(ctx, u.logger, "UserStatisticsService.Create", "CreateUserStatistics", "failed to create user statistics", err,
-			slog.String("user_id", request.UserID.String()),
-		)
-		return db.UserStatistic{}, errorsPkg.InfrastructureUnexpected.Err()
-	}
-
-	lib.LogInfo(ctx, u.logger, "UserStatisticsService.Create", "user statistics created successfully",
-		slog.String("user_id", stats.UserID.String()),
-		slog.Int("total_words_learned", int(stats.TotalWordsLearned)),
-	)
-
-	return stats, nil
-}
-
 func (u *UserStatisticsService) GetByID(ctx context.Context, request dto.GetStatisticsRequest) (db.UserStatistic, error) {
-	lib.LogDebug(ctx, u.logger, "UserStatisticsService.GetByID", "getting user statistics by user id",
+	helper.LogDebug(ctx, u.logger, "UserStatisticsService.GetByID", "getting user statistics by user id",
 		slog.String("user_id", request.UserID.String()),
 	)
 
 	stats, err := u.userStatistRepo.GetUserStatistics(ctx, request.UserID)
 	if err != nil {
-		lib.LogError(ctx, u.logger, "UserStatisticsService.GetByID", "GetUserStatistics", "failed to get user statistics by user id", err,
+		helper.LogError(ctx, u.logger, "UserStatisticsService.GetByID", "GetUserStatistics", "failed to get user statistics by user id", err,
 			slog.String("user_id", request.UserID.String()),
 		)
 		return db.UserStatistic{}, errorsPkg.InfrastructureUnexpected.Err()
@@ -76,79 +49,70 @@ func (u *UserStatisticsService) GetByID(ctx context.Context, request dto.GetStat
 	return stats, nil
 }
 
-func (u *UserStatisticsService) List(ctx context.Context, request dto.ListStatisticsRequest) ([]db.UserStatistic, error) {
-	lib.LogDebug(ctx, u.logger, "UserStatisticsService.List", "listing user statistics",
+// List pages through statistics, most recently updated first. A cursor takes precedence over Offset.
+func (u *UserStatisticsService) List(ctx context.Context, request dto.ListStatisticsRequest) (dto.Page[db.UserStatistic], error) {
+	helper.LogDebug(ctx, u.logger, "UserStatisticsService.List", "listing user statistics",
 		slog.Int("limit", int(request.Limit)),
 		slog.Int("offset", int(request.Offset)),
+		slog.Bool("cursor", request.Cursor != ""),
 	)
 
-	stats, err := u.userStatistRepo.ListUserStatistics(ctx, db.ListUserStatisticsParams{
-		Limit:  request.Limit,
-		Offset: request.Offset,
-	})
+	from, err := decodeCursor(u.cursors, cursorScopeUserStatistics, request.Cursor)
 	if err != nil {
-		lib.LogError(ctx, u.logger, "UserStatisticsService.List", "ListUserStatistics", "failed to list user statistics", err)
-		return nil, errorsPkg.InfrastructureUnexpected.Err()
+		return dto.Page[db.UserStatistic]{}, err
+	}
+	limit := request.Limit
+	if limit == 0 {
+		limit = defaultStatisticsPageLimit
 	}
-	lib.LogInfo(ctx, u.logger, "UserStatisticsService.List", "list operation completed",
-		slog.Int("count", len(stats)),
-	)
-
-	return stats, nil
-}
-
-func (u *UserStatisticsService) Update(ctx context.Context, request dto.UpdateStatisticsRequest) (db.UserStatistic, error) {
-	lib.LogDebug(ctx, u.logger, "UserStatisticsService.Update", "updating user statistics",
-		slog.String("user_id", request.UserID.String()),
-		slog.Int("total_words_learned", int(request.TotalWordsLearned)),
-		slog.Int("total_time", int(request.TotalTime)),
-	)
 
-	accuracy, err := helper.ToNumeric(request.Accuracy)
+	var stats []db.UserStatistic
+	switch {
+	case from == nil:
+		stats, err = u.userStatistRepo.ListUserStatistics(ctx, db.ListUserStatisticsParams{Limit: limit + 1, Offset: request.Offset})
+	case from.Prev:
+		stats, err = u.userStatistRepo.ListUserStatisticsBefore(ctx, db.ListUserStatisticsBeforeParams{UpdatedAt: from.At, UserID: from.ID, Limit: limit + 1})
+	default:
+		stats, err = u.userStatistRepo.ListUserStatisticsAfter(ctx, db.ListUserStatisticsAfterParams{UpdatedAt: from.At, UserID: from.ID, Limit: limit + 1})
+	}
 	if err != nil {
-		lib.LogError(ctx, u.logger, "UserStatisticsService.Update", "ToNumeric", "failed to convert accuracy", err,
-			slog.String("user_id", request.UserID.String()),
-		)
-		return db.UserStatistic{}, errorsPkg.InfrastructureUnexpected.Err()
+		helper.LogError(ctx, u.logger, "UserStatisticsService.List", "ListUserStatistics", "failed to list user statistics", err)
+		return dto.Page[db.UserStatistic]{}, errorsPkg.InfrastructureUnexpected.Err()
 	}
 
-	stats, err := u.userStatistRepo.UpdateUserStatistics(ctx, db.UpdateUserStatisticsParams{
-		UserID:            request.UserID,
-		TotalWordsLearned: request.TotalWordsLearned,
-		Accuracy:          accuracy,
-		TotalTime:         request.TotalTime,
+	page := keysetPage(u.cursors, cursorScopeUserStatistics, stats, limit, from, request.Offset, func(s db.UserStatistic) (time.Time, pgtype.UUID) {
+		return s.UpdatedAt.Time, s.UserID
 	})
-	if err != nil {
-		lib.LogError(ctx, u.logger, "UserStatisticsService.Update", "UpdateUserStatistics", "failed to update user statistics", err,
-			slog.String("user_id", request.UserID.String()),
-		)
-		return db.UserStatistic{}, errorsPkg.InfrastructureUnexpected.Err()
-	}
 
-	lib.LogInfo(ctx, u.logger, "UserStatisticsService.Update", "user statistics updated successfully",
-		slog.String("user_id", stats.UserID.String()),
-		slog.Int("total_words_learned", int(stats.TotalWordsLearned)),
+	helper.LogInfo(ctx, u.logger, "UserStatisticsService.List", "list operation completed",
+		slog.Int("count", len(page.Items)),
 	)
 
-	return stats, nil
+	return page, nil
 }
 
-func (u *UserStatisticsService) Delete(ctx context.Context, request dto.DeleteStatisticsRequest) error {
-	lib.LogDebug(ctx, u.logger, "UserStatisticsService.Delete", "deleting user statistics",
+// Recompute rebuilds the user's statistics from their progress and sessions.
+// Triggers keep the row current; this is the explicit refresh behind PUT.
+func (u *UserStatisticsService) Recompute(ctx context.Context, request dto.RecomputeStatisticsRequest) (db.UserStatistic, error) {
+	helper.LogDebug(ctx, u.logger, "UserStatisticsService.Recompute", "recomputing user statistics",
 		slog.String("user_id", request.UserID.String()),
 	)
 
-	err := u.userStatistRepo.DeleteUserStatistics(ctx, request.UserID)
+	stats, err := u.userStatistRepo.RecomputeUserStatistics(ctx, request.UserID)
 	if err != nil {
-		lib.LogError(ctx, u.logger, "UserStatisticsService.Delete", "DeleteUserStatistics", "failed to delete user statistics", err,
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.UserStatistic{}, errorsPkg.UserNotFound.Err()
+		}
+		helper.LogError(ctx, u.logger, "UserStatisticsService.Recompute", "RecomputeUserStatistics", "failed to recompute user statistics", err,
 			slog.String("user_id", request.UserID.String()),
 		)
-		return errorsPkg.InfrastructureUnexpected.Err()
+		return db.UserStatistic{}, errorsPkg.InfrastructureUnexpected.Err()
 	}
 
-	lib.LogInfo(ctx, u.logger, "UserStatisticsService.Delete", "user statistics deleted successfully",
-		slog.String("user_id", request.UserID.String()),
+	helper.LogInfo(ctx, u.logger, "UserStatisticsService.Recompute", "user statistics recomputed successfully",
+		slog.String("user_id", stats.UserID.String()),
+		slog.Int("total_words_learned", int(stats.TotalWordsLearned)),
 	)
 
-	return nil
+	return stats, nil
 }
@@ -11,9 +11,11 @@ import (
 	db "test-http/internal/db"
 	"test-http/internal/dto"
 	mocks "test-http/internal/db/mocks"
+	errorsPkg "test-http/pkg/errors_pkg"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -31,7 +33,7 @@ func newStatisticsTestHelper(t *testing.T) *statisticsTestHelper {
 	ctrl := gomock.NewController(t)
 	mockRepo := mocks.NewMockUserStatisticsRepo(ctrl)
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-	service := NewUserStatisticsService(mockRepo, logger)
+	service := NewUserStatisticsService(mockRepo, testCursors, logger)
 
 	return &statisticsTestHelper{
 		ctrl:     ctrl,
@@ -79,85 +81,6 @@ func newNumeric(t *testing.T, value float64) pgtype.Numeric {
 	return num
 }
 
-func TestUserStatisticsService_Create(t *testing.T) {
-	t.Run("successfully creates user statistics", func(t *testing.T) {
-		h := newStatisticsTestHelper(t)
-		defer h.cleanup()
-
-		userID := randomStatisticsUUID(t)
-		accuracyFloat := 85.5
-		accuracyNumeric := newNumeric(t, accuracyFloat)
-		
-		request := dto.CreateStatisticsRequest{
-			UserID:            userID,
-			TotalWordsLearned: 100,
-			Accuracy:          accuracyFloat,
-			TotalTime:         3600,
-		}
-
-		expectedParams := db.CreateUserStatisticsParams{
-			UserID:            userID,
-			TotalWordsLearned: 100,
-			Accuracy:          accuracyNumeric,
-			TotalTime:         3600,
-		}
-
-		expectedStats := db.UserStatistic{
-			UserID:            userID,
-			TotalWordsLearned: 100,
-			Accuracy:          accuracyNumeric,
-			TotalTime:         3600,
-		}
-
-		h.mockRepo.EXPECT().
-			CreateUserStatistics(h.ctx, expectedParams).
-			Return(expectedStats, nil)
-
-		result, err := h.service.Create(h.ctx, request)
-
-		if err != nil {
-			t.Errorf("expected no error, got: %v", err)
-		}
-
-		if result.UserID.Bytes != expectedStats.UserID.Bytes {
-			t.Errorf("expected UserID %v, got %v", expectedStats.UserID, result.UserID)
-		}
-
-		if result.TotalWordsLearned != expectedStats.TotalWordsLearned {
-			t.Errorf("expected TotalWordsLearned %d, got %d", expectedStats.TotalWordsLearned, result.TotalWordsLearned)
-		}
-
-		expectedAccuracyFloat, _ := expectedStats.Accuracy.Float64Value()
-		resultAccuracyFloat, _ := result.Accuracy.Float64Value()
-		if resultAccuracyFloat.Float64 != expectedAccuracyFloat.Float64 {
-			t.Errorf("expected Accuracy %v, got %v", expectedAccuracyFloat.Float64, resultAccuracyFloat.Float64)
-		}
-	})
-
-	t.Run("returns error when repository fails", func(t *testing.T) {
-		h := newStatisticsTestHelper(t)
-		defer h.cleanup()
-
-		userID := randomStatisticsUUID(t)
-		request := dto.CreateStatisticsRequest{
-			UserID:            userID,
-			TotalWordsLearned: 50,
-		}
-
-		expectedError := errors.New("database connection failed")
-
-		h.mockRepo.EXPECT().
-			CreateUserStatistics(h.ctx, gomock.Any()).
-			Return(db.UserStatistic{}, expectedError)
-
-		_, err := h.service.Create(h.ctx, request)
-
-		if err == nil {
-			t.Error("expected error, got nil")
-		}
-	})
-}
-
 func TestUserStatisticsService_GetByID(t *testing.T) {
 	t.Run("successfully retrieves user statistics by user ID", func(t *testing.T) {
 		h := newStatisticsTestHelper(t)
@@ -225,7 +148,7 @@ func TestUserStatisticsService_List(t *testing.T) {
 		}
 
 		expectedParams := db.ListUserStatisticsParams{
-			Limit:  10,
+			Limit:  11,
 			Offset: 0,
 		}
 
@@ -249,8 +172,8 @@ func TestUserStatisticsService_List(t *testing.T) {
 			t.Errorf("expected no error, got: %v", err)
 		}
 
-		if len(result) != len(expectedStats) {
-			t.Errorf("expected %d statistics, got %d", len(expectedStats), len(result))
+		if len(result.Items) != len(expectedStats) {
+			t.Errorf("expected %d statistics, got %d", len(expectedStats), len(result.Items))
 		}
 	})
 
@@ -295,47 +218,30 @@ func TestUserStatisticsService_List(t *testing.T) {
 			t.Errorf("expected no error, got: %v", err)
 		}
 
-		if len(result) != 0 {
-			t.Errorf("expected empty list, got %d items", len(result))
+		if len(result.Items) != 0 {
+			t.Errorf("expected empty list, got %d items", len(result.Items))
 		}
 	})
 }
 
-func TestUserStatisticsService_Update(t *testing.T) {
-	t.Run("successfully updates user statistics", func(t *testing.T) {
+func TestUserStatisticsService_Recompute(t *testing.T) {
+	t.Run("successfully recomputes user statistics", func(t *testing.T) {
 		h := newStatisticsTestHelper(t)
 		defer h.cleanup()
 
 		userID := randomStatisticsUUID(t)
-		accuracyFloat := 92.5
-		accuracyNumeric := newNumeric(t, accuracyFloat)
-		
-		request := dto.UpdateStatisticsRequest{
-			UserID:            userID,
-			TotalWordsLearned: 150,
-			Accuracy:          accuracyFloat,
-			TotalTime:         5000,
-		}
-
-		expectedParams := db.UpdateUserStatisticsParams{
-			UserID:            userID,
-			TotalWordsLearned: 150,
-			Accuracy:          accuracyNumeric,
-			TotalTime:         5000,
-		}
-
 		expectedStats := db.UserStatistic{
 			UserID:            userID,
 			TotalWordsLearned: 150,
-			Accuracy:          accuracyNumeric,
+			Accuracy:          newNumeric(t, 92.5),
 			TotalTime:         5000,
 		}
 
 		h.mockRepo.EXPECT().
-			UpdateUserStatistics(h.ctx, expectedParams).
+			RecomputeUserStatistics(h.ctx, userID).
 			Return(expectedStats, nil)
 
-		result, err := h.service.Update(h.ctx, request)
+		result, err := h.service.Recompute(h.ctx, dto.RecomputeStatisticsRequest{UserID: userID})
 
 		if err != nil {
 			t.Errorf("expected no error, got: %v", err)
@@ -344,74 +250,39 @@ func TestUserStatisticsService_Update(t *testing.T) {
 		if result.TotalWordsLearned != expectedStats.TotalWordsLearned {
 			t.Errorf("expected TotalWordsLearned %d, got %d", expectedStats.TotalWordsLearned, result.TotalWordsLearned)
 		}
-
-		expectedAccuracyFloat, _ := expectedStats.Accuracy.Float64Value()
-		resultAccuracyFloat, _ := result.Accuracy.Float64Value()
-		if resultAccuracyFloat.Float64 != expectedAccuracyFloat.Float64 {
-			t.Errorf("expected Accuracy %v, got %v", expectedAccuracyFloat.Float64, resultAccuracyFloat.Float64)
-		}
-	})
-
-	t.Run("returns error when repository update fails", func(t *testing.T) {
-		h := newStatisticsTestHelper(t)
-		defer h.cleanup()
-
-		userID := randomStatisticsUUID(t)
-		request := dto.UpdateStatisticsRequest{
-			UserID:            userID,
-			TotalWordsLearned: 200,
-		}
-
-		expectedError := errors.New("update operation failed")
-
-		h.mockRepo.EXPECT().
-			UpdateUserStatistics(h.ctx, gomock.Any()).
-			Return(db.UserStatistic{}, expectedError)
-
-		_, err := h.service.Update(h.ctx, request)
-
-		if err == nil {
-			t.Error("expected error, got nil")
-		}
 	})
-}
 
-func TestUserStatisticsService_Delete(t *testing.T) {
-	t.Run("successfully deletes user statistics", func(t *testing.T) {
+	t.Run("returns user not found when user does not exist", func(t *testing.T) {
 		h := newStatisticsTestHelper(t)
 		defer h.cleanup()
 
 		userID := randomStatisticsUUID(t)
-		request := dto.DeleteStatisticsRequest{UserID: userID}
 
 		h.mockRepo.EXPECT().
-			DeleteUserStatistics(h.ctx, userID).
-			Return(nil)
+			RecomputeUserStatistics(h.ctx, userID).
+			Return(db.UserStatistic{}, pgx.ErrNoRows)
 
-		err := h.service.Delete(h.ctx, request)
+		_, err := h.service.Recompute(h.ctx, dto.RecomputeStatisticsRequest{UserID: userID})
 
-		if err != nil {
-			t.Errorf("expected no error, got: %v", err)
+		if err == nil || err.Error() != string(errorsPkg.UserNotFound) {
+			t.Errorf("expected %s, got: %v", errorsPkg.UserNotFound, err)
 		}
 	})
 
-	t.Run("returns error when repository delete fails", func(t *testing.T) {
+	t.Run("returns error when repository fails", func(t *testing.T) {
 		h := newStatisticsTestHelper(t)
 		defer h.cleanup()
 
 		userID := randomStatisticsUUID(t)
-		request := dto.DeleteStatisticsRequest{UserID: userID}
-
-		expectedError := errors.New("delete operation failed")
 
 		h.mockRepo.EXPECT().
-			DeleteUserStatistics(h.ctx, userID).
-			Return(expectedError)
+			RecomputeUserStatistics(h.ctx, gomock.Any()).
+			Return(db.UserStatistic{}, errors.New("recompute failed"))
 
-		err := h.service.Delete(h.ctx, request)
+		_, err := h.service.Recompute(h.ctx, dto.RecomputeStatisticsRequest{UserID: userID})
 
-		if err == nil {
-			t.Error("expected error, got nil")
+		if err == nil || err.Error() != string(errorsPkg.InfrastructureUnexpected) {
+			t.Errorf("expected %s, got: %v", errorsPkg.InfrastructureUnexpected, err)
 		}
 	})
 }
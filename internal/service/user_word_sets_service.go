@@ -2,47 +2,60 @@ package service
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 
 	"test-http/internal/db"
 	"test-http/internal/dto"
-	"test-http/internal/lib"
 	errorsPkg "test-http/pkg/errors_pkg"
+	"test-http/pkg/helper"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const defaultUserWordSetPageLimit = 20
+
 type UserWordSetService struct {
 	userWordRepo db.UserWordSetRepo
+	wordSetRepo  db.WordSetRepo
 	logger       *slog.Logger
 }
 
-func NewUserWordSetService(userWordRepo db.UserWordSetRepo, log *slog.Logger) *UserWordSetService {
+func NewUserWordSetService(userWordRepo db.UserWordSetRepo, wordSetRepo db.WordSetRepo, log *slog.Logger) *UserWordSetService {
 	return &UserWordSetService{
 		userWordRepo: userWordRepo,
+		wordSetRepo:  wordSetRepo,
 		logger:       log,
 	}
 }
 
 func (u *UserWordSetService) Create(ctx context.Context, request dto.CreateUserWordSetRequest) (db.UserWordSet, error) {
-	lib.LogDebug(ctx, u.logger, "UserWordSetService.Create", "creating user word set",
+	helper.LogDebug(ctx, u.logger, "UserWordSetService.Create", "creating user word set",
 		slog.String("user_id", request.UserID.String()),
 		slog.String("word_set_id", request.WordSetID.String()),
 	)
 
+	if err := u.checkCatalogSet(ctx, "UserWordSetService.Create", request.UserID, request.WordSetID); err != nil {
+		return db.UserWordSet{}, err
+	}
+
 	wordSet, err := u.userWordRepo.CreateUserWordSet(ctx, db.CreateUserWordSetParams{
 		UserID:    request.UserID,
 		WordSetID: request.WordSetID,
 	})
 	if err != nil {
-		lib.LogError(ctx, u.logger, "UserWordSetService.Create", "CreateUserWordSet", "failed to create user word set", err,
+		if f := repoFault(err, errorsPkg.UserWordSetNotFound); f != nil {
+			return db.UserWordSet{}, f
+		}
+		helper.LogError(ctx, u.logger, "UserWordSetService.Create", "CreateUserWordSet", "failed to create user word set", err,
 			slog.String("user_id", request.UserID.String()),
 			slog.String("word_set_id", request.WordSetID.String()),
 		)
 		return db.UserWordSet{}, errorsPkg.InfrastructureUnexpected.Err()
 	}
 
-	lib.LogInfo(ctx, u.logger, "UserWordSetService.Create", "user word set created successfully",
+	helper.LogInfo(ctx, u.logger, "UserWordSetService.Create", "user word set created successfully",
 		slog.String("id", wordSet.ID.String()),
 		slog.String("user_id", request.UserID.String()),
 		slog.String("word_set_id", request.WordSetID.String()),
@@ -52,41 +65,53 @@ func (u *UserWordSetService) Create(ctx context.Context, request dto.CreateUserW
 }
 
 func (u *UserWordSetService) GetByID(ctx context.Context, request dto.GetUserWordSetRequest) (db.UserWordSet, error) {
-	lib.LogDebug(ctx, u.logger, "UserWordSetService.GetByID", "getting user word set by id",
+	helper.LogDebug(ctx, u.logger, "UserWordSetService.GetByID", "getting user word set by id",
 		slog.String("id", request.ID.String()),
 	)
 
 	wordSet, err := u.userWordRepo.GetUserWordSet(ctx, request.ID)
 	if err != nil {
-		lib.LogError(ctx, u.logger, "UserWordSetService.GetByID", "GetUserWordSet", "failed to get user word set by id", err,
+		if f := repoFault(err, errorsPkg.UserWordSetNotFound); f != nil {
+			return db.UserWordSet{}, f
+		}
+		helper.LogError(ctx, u.logger, "UserWordSetService.GetByID", "GetUserWordSet", "failed to get user word set by id", err,
 			slog.String("id", request.ID.String()),
 		)
 		return db.UserWordSet{}, errorsPkg.InfrastructureUnexpected.Err()
 	}
+	// Another user's subscription is reported as missing rather than forbidden.
+	if wordSet.UserID != request.UserID {
+		return db.UserWordSet{}, errorsPkg.UserWordSetNotFound.Err()
+	}
 
 	return wordSet, nil
 }
 
 func (u *UserWordSetService) List(ctx context.Context, request dto.ListUserWordSetsRequest) ([]db.UserWordSet, error) {
-	lib.LogDebug(ctx, u.logger, "UserWordSetService.List", "listing user word sets",
+	helper.LogDebug(ctx, u.logger, "UserWordSetService.List", "listing user word sets",
 		slog.String("user_id", request.UserID.String()),
 		slog.Int("limit", int(request.Limit)),
 		slog.Int("offset", int(request.Offset)),
 	)
 
+	limit := request.Limit
+	if limit == 0 {
+		limit = defaultUserWordSetPageLimit
+	}
+
 	wordSets, err := u.userWordRepo.ListUserWordSets(ctx, db.ListUserWordSetsParams{
 		UserID: request.UserID,
-		Limit:  request.Limit,
+		Limit:  limit,
 		Offset: request.Offset,
 	})
 	if err != nil {
-		lib.LogError(ctx, u.logger, "UserWordSetService.List", "ListUserWordSets", "failed to list user word sets", err,
+		helper.LogError(ctx, u.logger, "UserWordSetService.List", "ListUserWordSets", "failed to list user word sets", err,
 			slog.String("user_id", request.UserID.String()),
 		)
 		return nil, errorsPkg.InfrastructureUnexpected.Err()
 	}
 
-	lib.LogInfo(ctx, u.logger, "UserWordSetService.List", "user word sets listed successfully",
+	helper.LogInfo(ctx, u.logger, "UserWordSetService.List", "user word sets listed successfully",
 		slog.Int("count", len(wordSets)),
 		slog.String("user_id", request.UserID.String()),
 	)
@@ -95,24 +120,32 @@ func (u *UserWordSetService) List(ctx context.Context, request dto.ListUserWordS
 }
 
 func (u *UserWordSetService) Update(ctx context.Context, request dto.UpdateUserWordSetRequest) (db.UserWordSet, error) {
-	lib.LogDebug(ctx, u.logger, "UserWordSetService.Update", "updating user word set",
+	helper.LogDebug(ctx, u.logger, "UserWordSetService.Update", "updating user word set",
 		slog.String("id", request.ID.String()),
 		slog.String("word_set_id", request.WordSetID.String()),
 	)
 
+	if err := u.checkCatalogSet(ctx, "UserWordSetService.Update", request.UserID, request.WordSetID); err != nil {
+		return db.UserWordSet{}, err
+	}
+
 	wordSet, err := u.userWordRepo.UpdateUserWordSet(ctx, db.UpdateUserWordSetParams{
 		ID:        request.ID,
 		WordSetID: request.WordSetID,
+		UserID:    request.UserID,
 	})
 	if err != nil {
-		lib.LogError(ctx, u.logger, "UserWordSetService.Update", "UpdateUserWordSet", "failed to update user word set", err,
+		if f := repoFault(err, errorsPkg.UserWordSetNotFound); f != nil {
+			return db.UserWordSet{}, f
+		}
+		helper.LogError(ctx, u.logger, "UserWordSetService.Update", "UpdateUserWordSet", "failed to update user word set", err,
 			slog.String("id", request.ID.String()),
 			slog.String("word_set_id", request.WordSetID.String()),
 		)
 		return db.UserWordSet{}, errorsPkg.InfrastructureUnexpected.Err()
 	}
 
-	lib.LogInfo(ctx, u.logger, "UserWordSetService.Update", "user word set updated successfully",
+	helper.LogInfo(ctx, u.logger, "UserWordSetService.Update", "user word set updated successfully",
 		slog.String("id", wordSet.ID.String()),
 		slog.String("word_set_id", wordSet.WordSetID.String()),
 	)
@@ -120,22 +153,51 @@ func (u *UserWordSetService) Update(ctx context.Context, request dto.UpdateUserW
 	return wordSet, nil
 }
 
-func (u *UserWordSetService) Delete(ctx context.Context, id pgtype.UUID) error {
-	lib.LogDebug(ctx, u.logger, "UserWordSetService.Delete", "deleting user word set",
-		slog.String("id", id.String()),
+func (u *UserWordSetService) Delete(ctx context.Context, request dto.DeleteUserWordSetRequest) error {
+	helper.LogDebug(ctx, u.logger, "UserWordSetService.Delete", "deleting user word set",
+		slog.String("id", request.ID.String()),
+		slog.String("user_id", request.UserID.String()),
 	)
 
-	err := u.userWordRepo.DeleteUserWordSet(ctx, id)
+	deleted, err := u.userWordRepo.DeleteUserWordSet(ctx, db.DeleteUserWordSetParams{
+		ID:     request.ID,
+		UserID: request.UserID,
+	})
 	if err != nil {
-		lib.LogError(ctx, u.logger, "UserWordSetService.Delete", "DeleteUserWordSet", "failed to delete user word set", err,
-			slog.String("id", id.String()),
+		if f := repoFault(err, errorsPkg.UserWordSetNotFound); f != nil {
+			return f
+		}
+		helper.LogError(ctx, u.logger, "UserWordSetService.Delete", "DeleteUserWordSet", "failed to delete user word set", err,
+			slog.String("id", request.ID.String()),
 		)
 		return errorsPkg.InfrastructureUnexpected.Err()
 	}
+	if deleted == 0 {
+		return errorsPkg.UserWordSetNotFound.Err()
+	}
 
-	lib.LogInfo(ctx, u.logger, "UserWordSetService.Delete", "user word set deleted successfully",
-		slog.String("id", id.String()),
+	helper.LogInfo(ctx, u.logger, "UserWordSetService.Delete", "user word set deleted successfully",
+		slog.String("id", request.ID.String()),
 	)
 
 	return nil
 }
+
+// checkCatalogSet makes sure the user may subscribe to the catalog set. A private
+// set is only visible to its owner, so it is reported as missing to everyone else.
+func (u *UserWordSetService) checkCatalogSet(ctx context.Context, operation string, userID, wordSetID pgtype.UUID) error {
+	catalogSet, err := u.wordSetRepo.GetWordSet(ctx, wordSetID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errorsPkg.WordSetNotFound.Err()
+		}
+		helper.LogError(ctx, u.logger, operation, "GetWordSet", "failed to check word set", err,
+			slog.String("word_set_id", wordSetID.String()),
+		)
+		return errorsPkg.InfrastructureUnexpected.Err()
+	}
+	if catalogSet.Visibility == WordSetVisibilityPrivate && catalogSet.OwnerID != userID {
+		return errorsPkg.WordSetNotFound.Err()
+	}
+	return nil
+}
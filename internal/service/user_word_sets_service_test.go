@@ -11,8 +11,10 @@ import (
 	db "test-http/internal/db"
 	mocks "test-http/internal/db/mocks"
 	"test-http/internal/dto"
+	errorsPkg "test-http/pkg/errors_pkg"
 
 	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -21,12 +23,15 @@ func TestUserWordSetService_Create_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockUserWordSetRepo(ctrl)
+	mockWordSetRepo := mocks.NewMockWordSetRepo(ctrl)
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-	svc := NewUserWordSetService(mockRepo, logger)
+	svc := NewUserWordSetService(mockRepo, mockWordSetRepo, logger)
 
 	var uid, wid pgtype.UUID
 	want := db.UserWordSet{UserID: uid, WordSetID: wid}
 
+	mockWordSetRepo.EXPECT().GetWordSet(gomock.Any(), wid).Return(db.WordSet{ID: wid, Visibility: "public"}, nil)
+
 	mockRepo.EXPECT().CreateUserWordSet(gomock.Any(), db.CreateUserWordSetParams{
 		UserID:    uid,
 		WordSetID: wid,
@@ -46,10 +51,12 @@ func TestUserWordSetService_Create_RepoError(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockUserWordSetRepo(ctrl)
+	mockWordSetRepo := mocks.NewMockWordSetRepo(ctrl)
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-	svc := NewUserWordSetService(mockRepo, logger)
+	svc := NewUserWordSetService(mockRepo, mockWordSetRepo, logger)
 
 	var uid, wid pgtype.UUID
+	mockWordSetRepo.EXPECT().GetWordSet(gomock.Any(), wid).Return(db.WordSet{ID: wid, Visibility: "public"}, nil)
 	mockRepo.EXPECT().CreateUserWordSet(gomock.Any(), gomock.Any()).Return(db.UserWordSet{}, errors.New("fail"))
 
 	_, err := svc.Create(context.Background(), dto.CreateUserWordSetRequest{UserID: uid, WordSetID: wid})
@@ -58,13 +65,52 @@ func TestUserWordSetService_Create_RepoError(t *testing.T) {
 	}
 }
 
+func TestUserWordSetService_Create_WordSetMissing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserWordSetRepo(ctrl)
+	mockWordSetRepo := mocks.NewMockWordSetRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewUserWordSetService(mockRepo, mockWordSetRepo, logger)
+
+	var uid, wid pgtype.UUID
+	mockWordSetRepo.EXPECT().GetWordSet(gomock.Any(), wid).Return(db.WordSet{}, pgx.ErrNoRows)
+
+	_, err := svc.Create(context.Background(), dto.CreateUserWordSetRequest{UserID: uid, WordSetID: wid})
+	if err == nil || err.Error() != string(errorsPkg.WordSetNotFound) {
+		t.Fatalf("expected %s, got %v", errorsPkg.WordSetNotFound, err)
+	}
+}
+
+func TestUserWordSetService_Create_ForeignPrivateWordSet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserWordSetRepo(ctrl)
+	mockWordSetRepo := mocks.NewMockWordSetRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewUserWordSetService(mockRepo, mockWordSetRepo, logger)
+
+	uid := pgtype.UUID{Bytes: [16]byte{1}, Valid: true}
+	owner := pgtype.UUID{Bytes: [16]byte{2}, Valid: true}
+	var wid pgtype.UUID
+	mockWordSetRepo.EXPECT().GetWordSet(gomock.Any(), wid).Return(db.WordSet{ID: wid, OwnerID: owner, Visibility: "private"}, nil)
+
+	_, err := svc.Create(context.Background(), dto.CreateUserWordSetRequest{UserID: uid, WordSetID: wid})
+	if err == nil || err.Error() != string(errorsPkg.WordSetNotFound) {
+		t.Fatalf("expected %s, got %v", errorsPkg.WordSetNotFound, err)
+	}
+}
+
 func TestUserWordSetService_GetByID_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockUserWordSetRepo(ctrl)
+	mockWordSetRepo := mocks.NewMockWordSetRepo(ctrl)
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-	svc := NewUserWordSetService(mockRepo, logger)
+	svc := NewUserWordSetService(mockRepo, mockWordSetRepo, logger)
 
 	var id pgtype.UUID
 	want := db.UserWordSet{ID: id}
@@ -85,8 +131,9 @@ func TestUserWordSetService_List_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockUserWordSetRepo(ctrl)
+	mockWordSetRepo := mocks.NewMockWordSetRepo(ctrl)
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-	svc := NewUserWordSetService(mockRepo, logger)
+	svc := NewUserWordSetService(mockRepo, mockWordSetRepo, logger)
 
 	var uid pgtype.UUID
 	want := []db.UserWordSet{{UserID: uid}}
@@ -110,14 +157,55 @@ func TestUserWordSetService_Delete_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mocks.NewMockUserWordSetRepo(ctrl)
+	mockWordSetRepo := mocks.NewMockWordSetRepo(ctrl)
 	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
-	svc := NewUserWordSetService(mockRepo, logger)
+	svc := NewUserWordSetService(mockRepo, mockWordSetRepo, logger)
 
 	var id pgtype.UUID
-	mockRepo.EXPECT().DeleteUserWordSet(gomock.Any(), id).Return(nil)
+	mockRepo.EXPECT().DeleteUserWordSet(gomock.Any(), db.DeleteUserWordSetParams{ID: id}).Return(int64(1), nil)
 
-	err := svc.Delete(context.Background(), id)
+	err := svc.Delete(context.Background(), dto.DeleteUserWordSetRequest{ID: id})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestUserWordSetService_Update_ForeignPrivateSet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserWordSetRepo(ctrl)
+	mockWordSetRepo := mocks.NewMockWordSetRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewUserWordSetService(mockRepo, mockWordSetRepo, logger)
+
+	userID := pgtype.UUID{Bytes: [16]byte{1}, Valid: true}
+	setID := pgtype.UUID{Bytes: [16]byte{2}, Valid: true}
+	mockWordSetRepo.EXPECT().GetWordSet(gomock.Any(), setID).Return(db.WordSet{
+		ID:         setID,
+		OwnerID:    pgtype.UUID{Bytes: [16]byte{9}, Valid: true},
+		Visibility: WordSetVisibilityPrivate,
+	}, nil)
+
+	_, err := svc.Update(context.Background(), dto.UpdateUserWordSetRequest{UserID: userID, WordSetID: setID})
+	if err == nil || err.Error() != string(errorsPkg.WordSetNotFound) {
+		t.Fatalf("expected %s, got %v", errorsPkg.WordSetNotFound, err)
+	}
+}
+
+func TestUserWordSetService_Delete_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockUserWordSetRepo(ctrl)
+	mockWordSetRepo := mocks.NewMockWordSetRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewUserWordSetService(mockRepo, mockWordSetRepo, logger)
+
+	mockRepo.EXPECT().DeleteUserWordSet(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+
+	err := svc.Delete(context.Background(), dto.DeleteUserWordSetRequest{})
+	if err == nil || err.Error() != string(errorsPkg.UserWordSetNotFound) {
+		t.Fatalf("expected %s, got %v", errorsPkg.UserWordSetNotFound, err)
+	}
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"test-http/internal/db"
+	"test-http/internal/dto"
+	errorsPkg "test-http/pkg/errors_pkg"
+	"test-http/pkg/helper"
+)
+
+const defaultWordSearchLimit = 20
+
+type WordService struct {
+	wordRepo db.WordRepo
+	logger   *slog.Logger
+}
+
+func NewWordService(wordRepo db.WordRepo, log *slog.Logger) *WordService {
+	return &WordService{
+		wordRepo: wordRepo,
+		logger:   log,
+	}
+}
+
+func (w *WordService) Create(ctx context.Context, request dto.CreateWordRequest) (db.Word, error) {
+	helper.LogDebug(ctx, w.logger, "WordService.Create", "creating word",
+		slog.String("lemma", request.Lemma),
+		slog.String("source_lang", request.SourceLang),
+		slog.String("target_lang", request.TargetLang),
+	)
+
+	word, err := w.wordRepo.CreateWord(ctx, db.CreateWordParams{
+		Lemma:        request.Lemma,
+		Translation:  request.Translation,
+		PartOfSpeech: request.PartOfSpeech,
+		SourceLang:   request.SourceLang,
+		TargetLang:   request.TargetLang,
+		Examples:     nonNilExamples(request.Examples),
+		Difficulty:   request.Difficulty,
+	})
+	if err != nil {
+		if f := repoFault(err, errorsPkg.WordNotFound); f != nil {
+			return db.Word{}, f
+		}
+		helper.LogError(ctx, w.logger, "WordService.Create", "CreateWord", "failed to create word", err,
+			slog.String("lemma", request.Lemma),
+		)
+		return db.Word{}, errorsPkg.InfrastructureUnexpected.Err()
+	}
+
+	helper.LogInfo(ctx, w.logger, "WordService.Create", "word created successfully",
+		slog.String("word_id", word.ID.String()),
+		slog.String("lemma", word.Lemma),
+	)
+
+	return word, nil
+}
+
+func (w *WordService) GetByID(ctx context.Context, request dto.GetWordRequest) (db.Word, error) {
+	helper.LogDebug(ctx, w.logger, "WordService.GetByID", "getting word by id",
+		slog.String("word_id", request.ID.String()),
+	)
+
+	word, err := w.wordRepo.GetWord(ctx, request.ID)
+	if err != nil {
+		if f := repoFault(err, errorsPkg.WordNotFound); f != nil {
+			return db.Word{}, f
+		}
+		helper.LogError(ctx, w.logger, "WordService.GetByID", "GetWord", "failed to get word by id", err,
+			slog.String("word_id", request.ID.String()),
+		)
+		return db.Word{}, errorsPkg.InfrastructureUnexpected.Err()
+	}
+
+	return word, nil
+}
+
+// Resolve returns the words for the given IDs, e.g. the word_id values stored in user_progress.
+// Unknown IDs are silently skipped.
+func (w *WordService) Resolve(ctx context.Context, request dto.ResolveWordsRequest) ([]db.Word, error) {
+	helper.LogDebug(ctx, w.logger, "WordService.Resolve", "resolving words by ids",
+		slog.Int("count", len(request.IDs)),
+	)
+
+	words, err := w.wordRepo.ListWordsByIDs(ctx, request.IDs)
+	if err != nil {
+		helper.LogError(ctx, w.logger, "WordService.Resolve", "ListWordsByIDs", "failed to resolve words", err,
+			slog.Int("count", len(request.IDs)),
+		)
+		return nil, errorsPkg.InfrastructureUnexpected.Err()
+	}
+
+	return words, nil
+}
+
+func (w *WordService) Search(ctx context.Context, request dto.SearchWordsRequest) ([]db.Word, error) {
+	helper.LogDebug(ctx, w.logger, "WordService.Search", "searching words",
+		slog.String("query", request.Query),
+		slog.String("source_lang", request.SourceLang),
+		slog.String("target_lang", request.TargetLang),
+		slog.Int("limit", int(request.Limit)),
+		slog.Int("offset", int(request.Offset)),
+	)
+
+	limit := request.Limit
+	if limit == 0 {
+		limit = defaultWordSearchLimit
+	}
+
+	params := db.SearchWordsParams{
+		Query:        optionalString(likeEscaper.Replace(request.Query)),
+		SourceLang:   optionalString(request.SourceLang),
+		TargetLang:   optionalString(request.TargetLang),
+		PartOfSpeech: optionalString(request.PartOfSpeech),
+		Limit:        limit,
+		Offset:       request.Offset,
+	}
+	if request.Difficulty != 0 {
+		difficulty := request.Difficulty
+		params.Difficulty = &difficulty
+	}
+
+	words, err := w.wordRepo.SearchWords(ctx, params)
+	if err != nil {
+		helper.LogError(ctx, w.logger, "WordService.Search", "SearchWords", "failed to search words", err,
+			slog.String("query", request.Query),
+		)
+		return nil, errorsPkg.InfrastructureUnexpected.Err()
+	}
+
+	helper.LogInfo(ctx, w.logger, "WordService.Search", "words searched successfully",
+		slog.Int("count", len(words)),
+	)
+
+	return words, nil
+}
+
+func (w *WordService) Update(ctx context.Context, request dto.UpdateWordRequest) (db.Word, error) {
+	helper.LogDebug(ctx, w.logger, "WordService.Update", "updating word",
+		slog.String("word_id", request.ID.String()),
+		slog.String("lemma", request.Lemma),
+	)
+
+	word, err := w.wordRepo.UpdateWord(ctx, db.UpdateWordParams{
+		ID:           request.ID,
+		Lemma:        request.Lemma,
+		Translation:  request.Translation,
+		PartOfSpeech: request.PartOfSpeech,
+		SourceLang:   request.SourceLang,
+		TargetLang:   request.TargetLang,
+		Examples:     nonNilExamples(request.Examples),
+		Difficulty:   request.Difficulty,
+	})
+	if err != nil {
+		if f := repoFault(err, errorsPkg.WordNotFound); f != nil {
+			return db.Word{}, f
+		}
+		helper.LogError(ctx, w.logger, "WordService.Update", "UpdateWord", "failed to update word", err,
+			slog.String("word_id", request.ID.String()),
+		)
+		return db.Word{}, errorsPkg.InfrastructureUnexpected.Err()
+	}
+
+	helper.LogInfo(ctx, w.logger, "WordService.Update", "word updated successfully",
+		slog.String("word_id", word.ID.String()),
+	)
+
+	return word, nil
+}
+
+func (w *WordService) Delete(ctx context.Context, request dto.DeleteWordRequest) error {
+	helper.LogDebug(ctx, w.logger, "WordService.Delete", "deleting word",
+		slog.String("word_id", request.ID.String()),
+	)
+
+	if err := w.wordRepo.DeleteWord(ctx, request.ID); err != nil {
+		if f := repoFault(err, errorsPkg.WordNotFound); f != nil {
+			return f
+		}
+		helper.LogError(ctx, w.logger, "WordService.Delete", "DeleteWord", "failed to delete word", err,
+			slog.String("word_id", request.ID.String()),
+		)
+		return errorsPkg.InfrastructureUnexpected.Err()
+	}
+
+	helper.LogInfo(ctx, w.logger, "WordService.Delete", "word deleted successfully",
+		slog.String("word_id", request.ID.String()),
+	)
+
+	return nil
+}
+
+// nonNilExamples keeps the NOT NULL examples column happy: pgx encodes a nil slice as NULL.
+func nonNilExamples(examples []string) []string {
+	if examples == nil {
+		return []string{}
+	}
+	return examples
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"test-http/internal/db"
+	"test-http/internal/db/mocks"
+	"test-http/internal/dto"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestWordService_Create_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWordRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewWordService(mockRepo, logger)
+
+	params := db.CreateWordParams{
+		Lemma:        "house",
+		Translation:  "дом",
+		PartOfSpeech: "noun",
+		SourceLang:   "en",
+		TargetLang:   "ru",
+		Examples:     []string{},
+		Difficulty:   1,
+	}
+	want := db.Word{Lemma: "house", Translation: "дом"}
+
+	// nil examples must be sent as an empty array, not NULL
+	mockRepo.EXPECT().CreateWord(gomock.Any(), params).Return(want, nil)
+
+	got, err := svc.Create(context.Background(), dto.CreateWordRequest{
+		Lemma:        params.Lemma,
+		Translation:  params.Translation,
+		PartOfSpeech: params.PartOfSpeech,
+		SourceLang:   params.SourceLang,
+		TargetLang:   params.TargetLang,
+		Difficulty:   params.Difficulty,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Lemma != want.Lemma {
+		t.Fatalf("got %+v want %+v", got, want)
+	}
+}
+
+func TestWordService_Create_RepoError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWordRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewWordService(mockRepo, logger)
+
+	mockRepo.EXPECT().CreateWord(gomock.Any(), gomock.Any()).Return(db.Word{}, errors.New("db error"))
+
+	_, err := svc.Create(context.Background(), dto.CreateWordRequest{Lemma: "house"})
+	if err == nil {
+		t.Fatalf("expected error from repo, got nil")
+	}
+}
+
+func TestWordService_Search_BuildsFilters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWordRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewWordService(mockRepo, logger)
+
+	mockRepo.EXPECT().SearchWords(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, arg db.SearchWordsParams) ([]db.Word, error) {
+			if arg.Query == nil || *arg.Query != "hou" {
+				t.Errorf("expected query filter hou, got %v", arg.Query)
+			}
+			if arg.SourceLang == nil || *arg.SourceLang != "en" {
+				t.Errorf("expected source_lang filter en, got %v", arg.SourceLang)
+			}
+			if arg.TargetLang != nil || arg.PartOfSpeech != nil || arg.Difficulty != nil {
+				t.Errorf("expected unset filters to be nil, got %+v", arg)
+			}
+			if arg.Limit != defaultWordSearchLimit {
+				t.Errorf("expected default limit %d, got %d", defaultWordSearchLimit, arg.Limit)
+			}
+			return []db.Word{{Lemma: "house"}}, nil
+		})
+
+	got, err := svc.Search(context.Background(), dto.SearchWordsRequest{Query: "hou", SourceLang: "en"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("got %d words want 1", len(got))
+	}
+}
+
+func TestWordService_Search_EscapesWildcards(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWordRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewWordService(mockRepo, logger)
+
+	mockRepo.EXPECT().SearchWords(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, arg db.SearchWordsParams) ([]db.Word, error) {
+			if arg.Query == nil || *arg.Query != `50\%\_off\\` {
+				t.Errorf("expected escaped query, got %v", arg.Query)
+			}
+			return nil, nil
+		})
+
+	if _, err := svc.Search(context.Background(), dto.SearchWordsRequest{Query: `50%_off\`}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestWordService_Resolve_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWordRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewWordService(mockRepo, logger)
+
+	ids := []pgtype.UUID{{Valid: true}}
+	mockRepo.EXPECT().ListWordsByIDs(gomock.Any(), ids).Return([]db.Word{{Lemma: "house"}}, nil)
+
+	got, err := svc.Resolve(context.Background(), dto.ResolveWordsRequest{IDs: ids})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("got %d words want 1", len(got))
+	}
+}
+
+func TestWordService_Delete_RepoError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWordRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewWordService(mockRepo, logger)
+
+	mockRepo.EXPECT().DeleteWord(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+
+	if err := svc.Delete(context.Background(), dto.DeleteWordRequest{}); err == nil {
+		t.Fatalf("expected error from repo, got nil")
+	}
+}
@@ -0,0 +1,383 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"test-http/internal/authz"
+	"test-http/internal/db"
+	"test-http/internal/dto"
+	errorsPkg "test-http/pkg/errors_pkg"
+	"test-http/pkg/helper"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const defaultWordSetPageLimit = 20
+
+const (
+	WordSetVisibilityPublic  = "public"
+	WordSetVisibilityPrivate = "private"
+)
+
+type WordSetService struct {
+	wordSetRepo db.WordSetRepo
+	txRunner    db.TxRunner
+	logger      *slog.Logger
+}
+
+func NewWordSetService(wordSetRepo db.WordSetRepo, txRunner db.TxRunner, log *slog.Logger) *WordSetService {
+	return &WordSetService{
+		wordSetRepo: wordSetRepo,
+		txRunner:    txRunner,
+		logger:      log,
+	}
+}
+
+func (w *WordSetService) Create(ctx context.Context, request dto.CreateWordSetRequest) (db.WordSet, error) {
+	helper.LogDebug(ctx, w.logger, "WordSetService.Create", "creating word set",
+		slog.String("title", request.Title),
+		slog.String("owner_id", request.OwnerID.String()),
+	)
+
+	// Regular users always own what they create; only admins may create
+	// catalog sets without an owner or on behalf of someone else.
+	principal, ok := authz.FromContext(ctx)
+	if !ok {
+		return db.WordSet{}, errorsPkg.Forbidden.Err()
+	}
+	ownerID := request.OwnerID
+	if !ownerID.Valid && !principal.IsAdmin() {
+		ownerID = principal.UserID
+	}
+	if ownerID.Valid && !principal.CanAccess(ownerID) {
+		return db.WordSet{}, errorsPkg.Forbidden.Err()
+	}
+
+	wordSet, err := w.wordSetRepo.CreateWordSet(ctx, db.CreateWordSetParams{
+		Title:       request.Title,
+		Description: request.Description,
+		Language:    request.Language,
+		OwnerID:     ownerID,
+		Visibility:  request.Visibility,
+	})
+	if err != nil {
+		if f := repoFault(err, errorsPkg.WordSetNotFound); f != nil {
+			return db.WordSet{}, f
+		}
+		helper.LogError(ctx, w.logger, "WordSetService.Create", "CreateWordSet", "failed to create word set", err,
+			slog.String("title", request.Title),
+		)
+		return db.WordSet{}, errorsPkg.InfrastructureUnexpected.Err()
+	}
+
+	helper.LogInfo(ctx, w.logger, "WordSetService.Create", "word set created successfully",
+		slog.String("word_set_id", wordSet.ID.String()),
+	)
+
+	return wordSet, nil
+}
+
+func (w *WordSetService) GetByID(ctx context.Context, request dto.GetWordSetRequest) (db.WordSet, error) {
+	helper.LogDebug(ctx, w.logger, "WordSetService.GetByID", "getting word set by id",
+		slog.String("word_set_id", request.ID.String()),
+	)
+
+	return w.authorizedSet(ctx, "WordSetService.GetByID", request.ID, false)
+}
+
+func (w *WordSetService) List(ctx context.Context, request dto.ListWordSetsRequest) ([]db.WordSet, error) {
+	helper.LogDebug(ctx, w.logger, "WordSetService.List", "listing word sets",
+		slog.String("owner_id", request.OwnerID.String()),
+		slog.Int("limit", int(request.Limit)),
+		slog.Int("offset", int(request.Offset)),
+	)
+
+	limit := request.Limit
+	if limit == 0 {
+		limit = defaultWordSetPageLimit
+	}
+
+	// Non-admins only ever see public sets plus their own private ones.
+	principal, ok := authz.FromContext(ctx)
+	if !ok {
+		return nil, errorsPkg.Forbidden.Err()
+	}
+	viewerID := request.OwnerID
+	if !principal.IsAdmin() {
+		viewerID = principal.UserID
+	}
+
+	wordSets, err := w.wordSetRepo.ListWordSets(ctx, db.ListWordSetsParams{
+		OwnerID:  viewerID,
+		Language: optionalString(request.Language),
+		Limit:    limit,
+		Offset:   request.Offset,
+	})
+	if err != nil {
+		helper.LogError(ctx, w.logger, "WordSetService.List", "ListWordSets", "failed to list word sets", err)
+		return nil, errorsPkg.InfrastructureUnexpected.Err()
+	}
+
+	return wordSets, nil
+}
+
+func (w *WordSetService) Update(ctx context.Context, request dto.UpdateWordSetRequest) (db.WordSet, error) {
+	helper.LogDebug(ctx, w.logger, "WordSetService.Update", "updating word set",
+		slog.String("word_set_id", request.ID.String()),
+	)
+
+	if _, err := w.authorizedSet(ctx, "WordSetService.Update", request.ID, true); err != nil {
+		return db.WordSet{}, err
+	}
+
+	wordSet, err := w.wordSetRepo.UpdateWordSet(ctx, db.UpdateWordSetParams{
+		ID:          request.ID,
+		Title:       request.Title,
+		Description: request.Description,
+		Language:    request.Language,
+		Visibility:  request.Visibility,
+	})
+	if err != nil {
+		if f := repoFault(err, errorsPkg.WordSetNotFound); f != nil {
+			return db.WordSet{}, f
+		}
+		helper.LogError(ctx, w.logger, "WordSetService.Update", "UpdateWordSet", "failed to update word set", err,
+			slog.String("word_set_id", request.ID.String()),
+		)
+		return db.WordSet{}, errorsPkg.InfrastructureUnexpected.Err()
+	}
+
+	helper.LogInfo(ctx, w.logger, "WordSetService.Update", "word set updated successfully",
+		slog.String("word_set_id", wordSet.ID.String()),
+	)
+
+	return wordSet, nil
+}
+
+func (w *WordSetService) Delete(ctx context.Context, request dto.DeleteWordSetRequest) error {
+	helper.LogDebug(ctx, w.logger, "WordSetService.Delete", "deleting word set",
+		slog.String("word_set_id", request.ID.String()),
+	)
+
+	if _, err := w.authorizedSet(ctx, "WordSetService.Delete", request.ID, true); err != nil {
+		return err
+	}
+
+	if err := w.wordSetRepo.DeleteWordSet(ctx, request.ID); err != nil {
+		if f := repoFault(err, errorsPkg.WordSetNotFound); f != nil {
+			return f
+		}
+		helper.LogError(ctx, w.logger, "WordSetService.Delete", "DeleteWordSet", "failed to delete word set", err,
+			slog.String("word_set_id", request.ID.String()),
+		)
+		return errorsPkg.InfrastructureUnexpected.Err()
+	}
+
+	helper.LogInfo(ctx, w.logger, "WordSetService.Delete", "word set deleted successfully",
+		slog.String("word_set_id", request.ID.String()),
+	)
+
+	return nil
+}
+
+// AddWord appends a word to the end of the set. The set row stays locked until the
+// item is in, so concurrent appends never share a position.
+func (w *WordSetService) AddWord(ctx context.Context, request dto.AddWordSetItemRequest) (db.WordSetItem, error) {
+	helper.LogDebug(ctx, w.logger, "WordSetService.AddWord", "adding word to word set",
+		slog.String("word_set_id", request.WordSetID.String()),
+		slog.String("word_id", request.WordID.String()),
+	)
+
+	if _, err := w.authorizedSet(ctx, "WordSetService.AddWord", request.WordSetID, true); err != nil {
+		return db.WordSetItem{}, err
+	}
+
+	var item db.WordSetItem
+	err := w.txRunner.InTx(ctx, func(tx db.Tx) error {
+		if _, err := tx.WordSets().LockWordSet(ctx, request.WordSetID); err != nil {
+			return err
+		}
+		var err error
+		item, err = tx.WordSets().AddWordSetItem(ctx, db.AddWordSetItemParams{
+			WordSetID: request.WordSetID,
+			WordID:    request.WordID,
+		})
+		return err
+	})
+	if err != nil {
+		if f := repoFault(err, errorsPkg.WordSetNotFound); f != nil {
+			return db.WordSetItem{}, f
+		}
+		helper.LogError(ctx, w.logger, "WordSetService.AddWord", "AddWordSetItem", "failed to add word to word set", err,
+			slog.String("word_set_id", request.WordSetID.String()),
+			slog.String("word_id", request.WordID.String()),
+		)
+		return db.WordSetItem{}, errorsPkg.InfrastructureUnexpected.Err()
+	}
+
+	return item, nil
+}
+
+func (w *WordSetService) RemoveWord(ctx context.Context, request dto.RemoveWordSetItemRequest) error {
+	helper.LogDebug(ctx, w.logger, "WordSetService.RemoveWord", "removing word from word set",
+		slog.String("word_set_id", request.WordSetID.String()),
+		slog.String("word_id", request.WordID.String()),
+	)
+
+	if _, err := w.authorizedSet(ctx, "WordSetService.RemoveWord", request.WordSetID, true); err != nil {
+		return err
+	}
+
+	removed, err := w.wordSetRepo.RemoveWordSetItem(ctx, db.RemoveWordSetItemParams{
+		WordSetID: request.WordSetID,
+		WordID:    request.WordID,
+	})
+	if err != nil {
+		if f := repoFault(err, errorsPkg.WordSetNotFound); f != nil {
+			return f
+		}
+		helper.LogError(ctx, w.logger, "WordSetService.RemoveWord", "RemoveWordSetItem", "failed to remove word from word set", err,
+			slog.String("word_set_id", request.WordSetID.String()),
+			slog.String("word_id", request.WordID.String()),
+		)
+		return errorsPkg.InfrastructureUnexpected.Err()
+	}
+	if removed == 0 {
+		return errorsPkg.WordSetItemNotFound.Err()
+	}
+
+	return nil
+}
+
+// Reorder assigns positions 1..n following the order of request.WordIDs.
+// The list must contain every word of the set exactly once; otherwise nothing is changed.
+func (w *WordSetService) Reorder(ctx context.Context, request dto.ReorderWordSetItemsRequest) error {
+	helper.LogDebug(ctx, w.logger, "WordSetService.Reorder", "reordering word set",
+		slog.String("word_set_id", request.WordSetID.String()),
+		slog.Int("count", len(request.WordIDs)),
+	)
+
+	seen := make(map[pgtype.UUID]struct{}, len(request.WordIDs))
+	for _, id := range request.WordIDs {
+		if _, dup := seen[id]; dup {
+			return errorsPkg.WordSetReorderMismatch.Err()
+		}
+		seen[id] = struct{}{}
+	}
+
+	if _, err := w.authorizedSet(ctx, "WordSetService.Reorder", request.WordSetID, true); err != nil {
+		return err
+	}
+
+	total, err := w.wordSetRepo.CountWordSetItems(ctx, request.WordSetID)
+	if err != nil {
+		helper.LogError(ctx, w.logger, "WordSetService.Reorder", "CountWordSetItems", "failed to count word set items", err,
+			slog.String("word_set_id", request.WordSetID.String()),
+		)
+		return errorsPkg.InfrastructureUnexpected.Err()
+	}
+	if total != int64(len(request.WordIDs)) {
+		return errorsPkg.WordSetReorderMismatch.Err()
+	}
+
+	updated, err := w.wordSetRepo.ReorderWordSetItems(ctx, db.ReorderWordSetItemsParams{
+		WordSetID: request.WordSetID,
+		WordIds:   request.WordIDs,
+	})
+	if err != nil {
+		if f := repoFault(err, errorsPkg.WordSetNotFound); f != nil {
+			return f
+		}
+		helper.LogError(ctx, w.logger, "WordSetService.Reorder", "ReorderWordSetItems", "failed to reorder word set", err,
+			slog.String("word_set_id", request.WordSetID.String()),
+		)
+		return errorsPkg.InfrastructureUnexpected.Err()
+	}
+	if updated != total {
+		return errorsPkg.WordSetReorderMismatch.Err()
+	}
+
+	helper.LogInfo(ctx, w.logger, "WordSetService.Reorder", "word set reordered successfully",
+		slog.String("word_set_id", request.WordSetID.String()),
+	)
+
+	return nil
+}
+
+func (w *WordSetService) ListItems(ctx context.Context, request dto.ListWordSetItemsRequest) (dto.WordSetItemsPage, error) {
+	helper.LogDebug(ctx, w.logger, "WordSetService.ListItems", "listing word set items",
+		slog.String("word_set_id", request.WordSetID.String()),
+		slog.Int("limit", int(request.Limit)),
+		slog.Int("offset", int(request.Offset)),
+	)
+
+	if _, err := w.authorizedSet(ctx, "WordSetService.ListItems", request.WordSetID, false); err != nil {
+		return dto.WordSetItemsPage{}, err
+	}
+
+	limit := request.Limit
+	if limit == 0 {
+		limit = defaultWordSetPageLimit
+	}
+
+	total, err := w.wordSetRepo.CountWordSetItems(ctx, request.WordSetID)
+	if err != nil {
+		helper.LogError(ctx, w.logger, "WordSetService.ListItems", "CountWordSetItems", "failed to count word set items", err,
+			slog.String("word_set_id", request.WordSetID.String()),
+		)
+		return dto.WordSetItemsPage{}, errorsPkg.InfrastructureUnexpected.Err()
+	}
+
+	items, err := w.wordSetRepo.ListWordSetItems(ctx, db.ListWordSetItemsParams{
+		WordSetID: request.WordSetID,
+		Limit:     limit,
+		Offset:    request.Offset,
+	})
+	if err != nil {
+		helper.LogError(ctx, w.logger, "WordSetService.ListItems", "ListWordSetItems", "failed to list word set items", err,
+			slog.String("word_set_id", request.WordSetID.String()),
+		)
+		return dto.WordSetItemsPage{}, errorsPkg.InfrastructureUnexpected.Err()
+	}
+
+	return dto.WordSetItemsPage{
+		Items:  items,
+		Total:  total,
+		Limit:  limit,
+		Offset: request.Offset,
+	}, nil
+}
+
+// authorizedSet loads a word set and checks the caller may read it or, with
+// write set, modify it. Private sets the caller cannot see are reported as
+// missing rather than forbidden so their existence does not leak.
+func (w *WordSetService) authorizedSet(ctx context.Context, operation string, id pgtype.UUID, write bool) (db.WordSet, error) {
+	wordSet, err := w.wordSetRepo.GetWordSet(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.WordSet{}, errorsPkg.WordSetNotFound.Err()
+		}
+		helper.LogError(ctx, w.logger, operation, "GetWordSet", "failed to get word set by id", err,
+			slog.String("word_set_id", id.String()),
+		)
+		return db.WordSet{}, errorsPkg.InfrastructureUnexpected.Err()
+	}
+
+	principal, ok := authz.FromContext(ctx)
+	if !ok {
+		return db.WordSet{}, errorsPkg.Forbidden.Err()
+	}
+	if principal.CanAccess(wordSet.OwnerID) {
+		return wordSet, nil
+	}
+	if wordSet.Visibility != WordSetVisibilityPublic {
+		return db.WordSet{}, errorsPkg.WordSetNotFound.Err()
+	}
+	if write {
+		return db.WordSet{}, errorsPkg.Forbidden.Err()
+	}
+	return wordSet, nil
+}
@@ -0,0 +1,315 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"test-http/internal/authz"
+	"test-http/internal/db"
+	"test-http/internal/db/mocks"
+	"test-http/internal/dto"
+	errorsPkg "test-http/pkg/errors_pkg"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var wordSetOwnerID = pgtype.UUID{Bytes: [16]byte{0xAA}, Valid: true}
+
+// wordSetOwnerCtx authenticates the caller as wordSetOwnerID.
+func wordSetOwnerCtx() context.Context {
+	return authz.WithPrincipal(context.Background(), authz.Principal{UserID: wordSetOwnerID, Role: authz.RoleUser})
+}
+
+func TestWordSetService_GetByID_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWordSetRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewWordSetService(mockRepo, mocks.NewMockTxRunner(ctrl), logger)
+
+	mockRepo.EXPECT().GetWordSet(gomock.Any(), gomock.Any()).Return(db.WordSet{}, pgx.ErrNoRows)
+
+	_, err := svc.GetByID(wordSetOwnerCtx(), dto.GetWordSetRequest{})
+	if err == nil || err.Error() != string(errorsPkg.WordSetNotFound) {
+		t.Fatalf("expected %s, got %v", errorsPkg.WordSetNotFound, err)
+	}
+}
+
+func TestWordSetService_RemoveWord_NotInSet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWordSetRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewWordSetService(mockRepo, mocks.NewMockTxRunner(ctrl), logger)
+
+	mockRepo.EXPECT().GetWordSet(gomock.Any(), gomock.Any()).Return(db.WordSet{OwnerID: wordSetOwnerID, Visibility: WordSetVisibilityPrivate}, nil)
+	mockRepo.EXPECT().RemoveWordSetItem(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+
+	err := svc.RemoveWord(wordSetOwnerCtx(), dto.RemoveWordSetItemRequest{})
+	if err == nil || err.Error() != string(errorsPkg.WordSetItemNotFound) {
+		t.Fatalf("expected %s, got %v", errorsPkg.WordSetItemNotFound, err)
+	}
+}
+
+func TestWordSetService_AddWord_LocksSetBeforeAppending(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWordSetRepo(ctrl)
+	tx := mocks.NewMockTx(ctrl)
+	tx.EXPECT().WordSets().Return(mockRepo).AnyTimes()
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewWordSetService(mockRepo, inTx(ctrl, tx), logger)
+
+	setID := pgtype.UUID{Bytes: [16]byte{1}, Valid: true}
+	wordID := pgtype.UUID{Bytes: [16]byte{2}, Valid: true}
+	mockRepo.EXPECT().GetWordSet(gomock.Any(), setID).Return(db.WordSet{ID: setID, OwnerID: wordSetOwnerID, Visibility: WordSetVisibilityPrivate}, nil)
+	gomock.InOrder(
+		mockRepo.EXPECT().LockWordSet(gomock.Any(), setID).Return(setID, nil),
+		mockRepo.EXPECT().AddWordSetItem(gomock.Any(), db.AddWordSetItemParams{WordSetID: setID, WordID: wordID}).
+			Return(db.WordSetItem{WordSetID: setID, WordID: wordID, Position: 3}, nil),
+	)
+
+	item, err := svc.AddWord(wordSetOwnerCtx(), dto.AddWordSetItemRequest{WordSetID: setID, WordID: wordID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if item.Position != 3 {
+		t.Fatalf("got position %d want 3", item.Position)
+	}
+}
+
+func TestWordSetService_AddWord_SetGoneBeforeLock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWordSetRepo(ctrl)
+	tx := mocks.NewMockTx(ctrl)
+	tx.EXPECT().WordSets().Return(mockRepo).AnyTimes()
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewWordSetService(mockRepo, inTx(ctrl, tx), logger)
+
+	mockRepo.EXPECT().GetWordSet(gomock.Any(), gomock.Any()).Return(db.WordSet{OwnerID: wordSetOwnerID, Visibility: WordSetVisibilityPrivate}, nil)
+	mockRepo.EXPECT().LockWordSet(gomock.Any(), gomock.Any()).Return(pgtype.UUID{}, pgx.ErrNoRows)
+
+	_, err := svc.AddWord(wordSetOwnerCtx(), dto.AddWordSetItemRequest{})
+	if err == nil || err.Error() != string(errorsPkg.WordSetNotFound) {
+		t.Fatalf("expected %s, got %v", errorsPkg.WordSetNotFound, err)
+	}
+}
+
+func TestWordSetService_Reorder_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWordSetRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewWordSetService(mockRepo, mocks.NewMockTxRunner(ctrl), logger)
+
+	var setID pgtype.UUID
+	ids := []pgtype.UUID{
+		{Bytes: [16]byte{2}, Valid: true},
+		{Bytes: [16]byte{1}, Valid: true},
+	}
+
+	mockRepo.EXPECT().GetWordSet(gomock.Any(), gomock.Any()).Return(db.WordSet{OwnerID: wordSetOwnerID, Visibility: WordSetVisibilityPrivate}, nil)
+	mockRepo.EXPECT().CountWordSetItems(gomock.Any(), setID).Return(int64(2), nil)
+	mockRepo.EXPECT().ReorderWordSetItems(gomock.Any(), db.ReorderWordSetItemsParams{
+		WordSetID: setID,
+		WordIds:   ids,
+	}).Return(int64(2), nil)
+
+	if err := svc.Reorder(wordSetOwnerCtx(), dto.ReorderWordSetItemsRequest{WordSetID: setID, WordIDs: ids}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestWordSetService_Reorder_Duplicates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWordSetRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewWordSetService(mockRepo, mocks.NewMockTxRunner(ctrl), logger)
+
+	id := pgtype.UUID{Bytes: [16]byte{1}, Valid: true}
+
+	err := svc.Reorder(wordSetOwnerCtx(), dto.ReorderWordSetItemsRequest{WordIDs: []pgtype.UUID{id, id}})
+	if err == nil || err.Error() != string(errorsPkg.WordSetReorderMismatch) {
+		t.Fatalf("expected %s, got %v", errorsPkg.WordSetReorderMismatch, err)
+	}
+}
+
+func TestWordSetService_Reorder_IncompleteList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWordSetRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewWordSetService(mockRepo, mocks.NewMockTxRunner(ctrl), logger)
+
+	id := pgtype.UUID{Bytes: [16]byte{1}, Valid: true}
+	mockRepo.EXPECT().GetWordSet(gomock.Any(), gomock.Any()).Return(db.WordSet{OwnerID: wordSetOwnerID, Visibility: WordSetVisibilityPrivate}, nil)
+	mockRepo.EXPECT().CountWordSetItems(gomock.Any(), gomock.Any()).Return(int64(3), nil)
+
+	err := svc.Reorder(wordSetOwnerCtx(), dto.ReorderWordSetItemsRequest{WordIDs: []pgtype.UUID{id}})
+	if err == nil || err.Error() != string(errorsPkg.WordSetReorderMismatch) {
+		t.Fatalf("expected %s, got %v", errorsPkg.WordSetReorderMismatch, err)
+	}
+}
+
+func TestWordSetService_ListItems_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWordSetRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewWordSetService(mockRepo, mocks.NewMockTxRunner(ctrl), logger)
+
+	var setID pgtype.UUID
+	rows := []db.ListWordSetItemsRow{{Position: 1}, {Position: 2}}
+
+	mockRepo.EXPECT().GetWordSet(gomock.Any(), gomock.Any()).Return(db.WordSet{OwnerID: wordSetOwnerID, Visibility: WordSetVisibilityPrivate}, nil)
+	mockRepo.EXPECT().CountWordSetItems(gomock.Any(), setID).Return(int64(5), nil)
+	mockRepo.EXPECT().ListWordSetItems(gomock.Any(), db.ListWordSetItemsParams{
+		WordSetID: setID,
+		Limit:     2,
+		Offset:    0,
+	}).Return(rows, nil)
+
+	page, err := svc.ListItems(wordSetOwnerCtx(), dto.ListWordSetItemsRequest{WordSetID: setID, Limit: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if page.Total != 5 || len(page.Items) != 2 {
+		t.Fatalf("unexpected page: %+v", page)
+	}
+}
+
+func TestWordSetService_ListItems_RepoError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWordSetRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewWordSetService(mockRepo, mocks.NewMockTxRunner(ctrl), logger)
+
+	mockRepo.EXPECT().GetWordSet(gomock.Any(), gomock.Any()).Return(db.WordSet{OwnerID: wordSetOwnerID, Visibility: WordSetVisibilityPrivate}, nil)
+	mockRepo.EXPECT().CountWordSetItems(gomock.Any(), gomock.Any()).Return(int64(0), errors.New("db error"))
+
+	if _, err := svc.ListItems(wordSetOwnerCtx(), dto.ListWordSetItemsRequest{}); err == nil {
+		t.Fatalf("expected error from repo, got nil")
+	}
+}
+
+func TestWordSetService_GetByID_ForeignPrivateSetIsHidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWordSetRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewWordSetService(mockRepo, mocks.NewMockTxRunner(ctrl), logger)
+
+	other := pgtype.UUID{Bytes: [16]byte{0xBB}, Valid: true}
+	mockRepo.EXPECT().GetWordSet(gomock.Any(), gomock.Any()).Return(db.WordSet{OwnerID: other, Visibility: WordSetVisibilityPrivate}, nil)
+
+	_, err := svc.GetByID(wordSetOwnerCtx(), dto.GetWordSetRequest{})
+	if err == nil || err.Error() != string(errorsPkg.WordSetNotFound) {
+		t.Fatalf("expected %s, got %v", errorsPkg.WordSetNotFound, err)
+	}
+}
+
+func TestWordSetService_Update_ForeignPublicSetForbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWordSetRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewWordSetService(mockRepo, mocks.NewMockTxRunner(ctrl), logger)
+
+	other := pgtype.UUID{Bytes: [16]byte{0xBB}, Valid: true}
+	mockRepo.EXPECT().GetWordSet(gomock.Any(), gomock.Any()).Return(db.WordSet{OwnerID: other, Visibility: WordSetVisibilityPublic}, nil)
+
+	_, err := svc.Update(wordSetOwnerCtx(), dto.UpdateWordSetRequest{Title: "mine now"})
+	if err == nil || err.Error() != string(errorsPkg.Forbidden) {
+		t.Fatalf("expected %s, got %v", errorsPkg.Forbidden, err)
+	}
+}
+
+func TestWordSetService_Delete_AdminBypassesOwnership(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWordSetRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewWordSetService(mockRepo, mocks.NewMockTxRunner(ctrl), logger)
+
+	setID := pgtype.UUID{Bytes: [16]byte{1}, Valid: true}
+	other := pgtype.UUID{Bytes: [16]byte{0xBB}, Valid: true}
+	mockRepo.EXPECT().GetWordSet(gomock.Any(), setID).Return(db.WordSet{ID: setID, OwnerID: other, Visibility: WordSetVisibilityPrivate}, nil)
+	mockRepo.EXPECT().DeleteWordSet(gomock.Any(), setID).Return(nil)
+
+	ctx := authz.WithPrincipal(context.Background(), authz.Principal{UserID: wordSetOwnerID, Role: authz.RoleAdmin})
+	if err := svc.Delete(ctx, dto.DeleteWordSetRequest{ID: setID}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestWordSetService_Create_DefaultsOwnerToCaller(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWordSetRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewWordSetService(mockRepo, mocks.NewMockTxRunner(ctrl), logger)
+
+	mockRepo.EXPECT().CreateWordSet(gomock.Any(), db.CreateWordSetParams{
+		Title:      "verbs",
+		Language:   "en",
+		OwnerID:    wordSetOwnerID,
+		Visibility: WordSetVisibilityPrivate,
+	}).Return(db.WordSet{OwnerID: wordSetOwnerID}, nil)
+
+	if _, err := svc.Create(wordSetOwnerCtx(), dto.CreateWordSetRequest{Title: "verbs", Language: "en", Visibility: WordSetVisibilityPrivate}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestWordSetService_Create_ForOtherUserForbidden(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWordSetRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewWordSetService(mockRepo, mocks.NewMockTxRunner(ctrl), logger)
+
+	other := pgtype.UUID{Bytes: [16]byte{0xBB}, Valid: true}
+	_, err := svc.Create(wordSetOwnerCtx(), dto.CreateWordSetRequest{Title: "verbs", Language: "en", OwnerID: other, Visibility: WordSetVisibilityPublic})
+	if err == nil || err.Error() != string(errorsPkg.Forbidden) {
+		t.Fatalf("expected %s, got %v", errorsPkg.Forbidden, err)
+	}
+}
+
+func TestWordSetService_List_ScopesToCaller(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWordSetRepo(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{}))
+	svc := NewWordSetService(mockRepo, mocks.NewMockTxRunner(ctrl), logger)
+
+	other := pgtype.UUID{Bytes: [16]byte{0xBB}, Valid: true}
+	mockRepo.EXPECT().ListWordSets(gomock.Any(), db.ListWordSetsParams{OwnerID: wordSetOwnerID, Limit: defaultWordSetPageLimit}).
+		Return([]db.WordSet{}, nil)
+
+	if _, err := svc.List(wordSetOwnerCtx(), dto.ListWordSetsRequest{OwnerID: other}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -17,8 +17,12 @@ import (
 
 var pgContainer testcontainers.Container
 
+// SetupTestDB starts a Postgres container and connects to it. Without a reachable
+// container runtime the test is skipped locally, but fails when CI is set, so a CI
+// run cannot pass with the database tests silently skipped.
 func SetupTestDB(t *testing.T) *pgxpool.Pool {
 	t.Helper()
+	requireContainerRuntime(t)
 	ctx := context.Background()
 
 	// Create and start PostgreSQL container
@@ -57,6 +61,32 @@ func SetupTestDB(t *testing.T) *pgxpool.Pool {
 	return pool
 }
 
+// requireContainerRuntime checks the Docker provider the way testcontainers does
+// before starting a container; looking the provider up panics when there is no runtime.
+func requireContainerRuntime(t *testing.T) {
+	t.Helper()
+	unavailable := func(reason any) {
+		if os.Getenv("CI") != "" {
+			t.Fatalf("container runtime unavailable: %v", reason)
+		}
+		t.Skipf("container runtime unavailable: %v", reason)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			unavailable(r)
+		}
+	}()
+
+	provider, err := testcontainers.ProviderDocker.GetProvider()
+	if err != nil {
+		unavailable(err)
+	}
+	defer provider.Close()
+	if err := provider.Health(context.Background()); err != nil {
+		unavailable(err)
+	}
+}
+
 func TeardownTestDB(t *testing.T, pool *pgxpool.Pool) {
 	t.Helper()
 	if pool != nil {
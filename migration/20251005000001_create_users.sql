@@ -12,9 +12,6 @@ CREATE TABLE users (
 CREATE INDEX IF NOT EXISTS idx_users_email ON users  (email);
 
 -- +goose Down
+DROP INDEX IF EXISTS idx_users_email;
 DROP TABLE IF EXISTS users;
-DROP INDEX IF EXISTS idx_users_email
-
-
-
-
+DROP EXTENSION IF EXISTS citext;
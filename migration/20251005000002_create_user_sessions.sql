@@ -12,8 +12,6 @@ CREATE INDEX IF NOT EXISTS idx_user_sessions_user_id ON user_sessions (user_id);
 CREATE INDEX IF NOT EXISTS idx_user_sessions_status ON user_sessions (status) WHERE status = 'active';
 
 -- +goose Down
+DROP INDEX IF EXISTS idx_user_sessions_status;
+DROP INDEX IF EXISTS idx_user_sessions_user_id;
 DROP TABLE IF EXISTS user_sessions;
-DROP INDEX IF EXISTS idx_user_sessions_user_id
-DROP INDEX IF EXISTS idx_user_sessions_status 
-
-
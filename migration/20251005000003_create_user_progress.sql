@@ -10,4 +10,4 @@ CREATE TABLE user_progress (
 );
 
 -- +goose Down
-DROP TABLE IF NOT EXISTS user_progress;
\ No newline at end of file
+DROP TABLE IF EXISTS user_progress;
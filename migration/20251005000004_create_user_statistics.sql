@@ -8,4 +8,4 @@ CREATE TABLE user_statistics (
 );
 
 -- +goose Down
-DROP TABLE IF NOT EXISTS user_statistics;
\ No newline at end of file
+DROP TABLE IF EXISTS user_statistics;
@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TABLE words (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    lemma CITEXT NOT NULL,
+    translation TEXT NOT NULL,
+    part_of_speech TEXT NOT NULL CHECK (part_of_speech IN ('noun', 'verb', 'adjective', 'adverb', 'pronoun', 'preposition', 'conjunction', 'interjection', 'phrase', 'other')),
+    source_lang TEXT NOT NULL CHECK (char_length(source_lang) BETWEEN 2 AND 8),
+    target_lang TEXT NOT NULL CHECK (char_length(target_lang) BETWEEN 2 AND 8),
+    examples TEXT[] NOT NULL DEFAULT '{}',
+    difficulty SMALLINT NOT NULL DEFAULT 1 CHECK (difficulty BETWEEN 1 AND 5),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT unique_word_lemma_lang_pair UNIQUE (lemma, source_lang, target_lang)
+);
+
+CREATE INDEX IF NOT EXISTS idx_words_lang_pair ON words (source_lang, target_lang);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_words_lang_pair;
+DROP TABLE IF EXISTS words;
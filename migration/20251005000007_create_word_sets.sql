@@ -0,0 +1,34 @@
+-- +goose Up
+CREATE TABLE word_sets (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    title TEXT NOT NULL CHECK (char_length(title) BETWEEN 1 AND 200),
+    description TEXT NOT NULL DEFAULT '',
+    language TEXT NOT NULL CHECK (char_length(language) BETWEEN 2 AND 8),
+    owner_id UUID NULL REFERENCES users(id) ON DELETE SET NULL,
+    visibility TEXT NOT NULL DEFAULT 'private' CHECK (visibility IN ('public', 'private')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_word_sets_owner_id ON word_sets (owner_id);
+
+CREATE TABLE word_set_items (
+    word_set_id UUID NOT NULL REFERENCES word_sets(id) ON DELETE CASCADE,
+    word_id UUID NOT NULL REFERENCES words(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL CHECK (position > 0),
+    added_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (word_set_id, word_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_word_set_items_position ON word_set_items (word_set_id, position);
+
+-- Existing rows may point at sets that never existed, so only new rows are checked.
+ALTER TABLE user_word_sets
+    ADD CONSTRAINT fk_user_word_sets_word_set_id FOREIGN KEY (word_set_id) REFERENCES word_sets(id) ON DELETE CASCADE NOT VALID;
+
+-- +goose Down
+ALTER TABLE user_word_sets DROP CONSTRAINT IF EXISTS fk_user_word_sets_word_set_id;
+DROP INDEX IF EXISTS idx_word_set_items_position;
+DROP TABLE IF EXISTS word_set_items;
+DROP INDEX IF EXISTS idx_word_sets_owner_id;
+DROP TABLE IF EXISTS word_sets;
@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE game_questions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    session_id UUID NOT NULL REFERENCES user_sessions(id) ON DELETE CASCADE,
+    word_id UUID NOT NULL REFERENCES words(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL CHECK (position > 0),
+    kind TEXT NOT NULL CHECK (kind IN ('multiple_choice', 'typing', 'flashcard')),
+    prompt TEXT NOT NULL,
+    choices TEXT[] NOT NULL DEFAULT '{}',
+    answer TEXT NOT NULL,
+    given_answer TEXT NULL,
+    is_correct BOOLEAN NULL,
+    answered_at TIMESTAMPTZ NULL,
+    CONSTRAINT unique_game_question_position UNIQUE (session_id, position)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS game_questions;
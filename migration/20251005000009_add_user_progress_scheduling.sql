@@ -0,0 +1,16 @@
+-- +goose Up
+ALTER TABLE user_progress
+    ADD COLUMN ease_factor DOUBLE PRECISION NOT NULL DEFAULT 2.5 CHECK (ease_factor >= 1.3),
+    ADD COLUMN interval_days INTEGER NOT NULL DEFAULT 0 CHECK (interval_days >= 0),
+    ADD COLUMN repetitions INTEGER NOT NULL DEFAULT 0 CHECK (repetitions >= 0),
+    ADD COLUMN due_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+CREATE INDEX IF NOT EXISTS idx_user_progress_due ON user_progress (user_id, due_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_user_progress_due;
+ALTER TABLE user_progress
+    DROP COLUMN IF EXISTS due_at,
+    DROP COLUMN IF EXISTS repetitions,
+    DROP COLUMN IF EXISTS interval_days,
+    DROP COLUMN IF EXISTS ease_factor;
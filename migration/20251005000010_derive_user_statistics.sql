@@ -0,0 +1,72 @@
+-- +goose Up
+-- user_statistics is derived data: a word counts as learned once it has two
+-- consecutive successful reviews, accuracy is the share of correct attempts and
+-- total_time sums the duration of finished sessions in seconds.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION refresh_user_statistics(p_user_id UUID)
+RETURNS SETOF user_statistics
+LANGUAGE sql
+AS $$
+    INSERT INTO user_statistics (user_id, total_words_learned, accuracy, total_time, updated_at)
+    SELECT
+        u.id,
+        COALESCE(p.learned, 0),
+        COALESCE(ROUND(100.0 * p.correct / NULLIF(p.correct + p.incorrect, 0), 2), 0),
+        COALESCE(s.seconds, 0),
+        now()
+    FROM users u
+    LEFT JOIN (
+        SELECT
+            COUNT(*) FILTER (WHERE repetitions >= 2) AS learned,
+            SUM(correct_count) AS correct,
+            SUM(incorrect_count) AS incorrect
+        FROM user_progress
+        WHERE user_id = p_user_id
+    ) p ON TRUE
+    LEFT JOIN (
+        SELECT SUM(EXTRACT(EPOCH FROM (ended_at - started_at)))::INTEGER AS seconds
+        FROM user_sessions
+        WHERE user_id = p_user_id AND ended_at IS NOT NULL AND ended_at > started_at
+    ) s ON TRUE
+    WHERE u.id = p_user_id
+    ON CONFLICT (user_id) DO UPDATE
+    SET total_words_learned = EXCLUDED.total_words_learned,
+        accuracy = EXCLUDED.accuracy,
+        total_time = EXCLUDED.total_time,
+        updated_at = EXCLUDED.updated_at
+    RETURNING *;
+$$;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION refresh_user_statistics_trigger()
+RETURNS TRIGGER
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        PERFORM refresh_user_statistics(OLD.user_id);
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') AND (TG_OP = 'INSERT' OR NEW.user_id IS DISTINCT FROM OLD.user_id) THEN
+        PERFORM refresh_user_statistics(NEW.user_id);
+    END IF;
+    RETURN NULL;
+END;
+$$;
+-- +goose StatementEnd
+
+CREATE TRIGGER trg_user_progress_refresh_statistics
+    AFTER INSERT OR UPDATE OR DELETE ON user_progress
+    FOR EACH ROW EXECUTE FUNCTION refresh_user_statistics_trigger();
+
+CREATE TRIGGER trg_user_sessions_refresh_statistics
+    AFTER INSERT OR UPDATE OF started_at, ended_at, user_id OR DELETE ON user_sessions
+    FOR EACH ROW EXECUTE FUNCTION refresh_user_statistics_trigger();
+
+SELECT refresh_user_statistics(id) FROM users;
+
+-- +goose Down
+DROP TRIGGER IF EXISTS trg_user_sessions_refresh_statistics ON user_sessions;
+DROP TRIGGER IF EXISTS trg_user_progress_refresh_statistics ON user_progress;
+DROP FUNCTION IF EXISTS refresh_user_statistics_trigger();
+DROP FUNCTION IF EXISTS refresh_user_statistics(UUID);
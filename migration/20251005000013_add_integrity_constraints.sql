@@ -0,0 +1,32 @@
+-- +goose Up
+-- Which of two accounts sharing an email to keep is not the migration's call, so it
+-- stops and leaves the duplicates to be merged by hand.
+-- +goose StatementBegin
+DO $$
+DECLARE
+    duplicates BIGINT;
+BEGIN
+    SELECT COUNT(*) INTO duplicates
+    FROM (SELECT email FROM users GROUP BY email HAVING COUNT(*) > 1) d;
+    IF duplicates > 0 THEN
+        RAISE EXCEPTION '% emails belong to more than one user; merge those accounts before adding users_email_key', duplicates
+            USING HINT = 'SELECT email, array_agg(id) FROM users GROUP BY email HAVING COUNT(*) > 1';
+    END IF;
+END;
+$$;
+-- +goose StatementEnd
+
+ALTER TABLE users
+    ADD CONSTRAINT users_email_key UNIQUE (email);
+
+DROP INDEX IF EXISTS idx_users_email;
+
+ALTER TABLE user_progress
+    ADD CONSTRAINT fk_user_progress_word_id FOREIGN KEY (word_id) REFERENCES words(id) ON DELETE CASCADE NOT VALID;
+
+-- +goose Down
+ALTER TABLE user_progress DROP CONSTRAINT IF EXISTS fk_user_progress_word_id;
+
+CREATE INDEX IF NOT EXISTS idx_users_email ON users (email);
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
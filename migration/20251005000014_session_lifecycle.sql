@@ -0,0 +1,34 @@
+-- +goose Up
+ALTER TABLE user_sessions
+    ADD COLUMN last_active_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+UPDATE user_sessions SET last_active_at = COALESCE(ended_at, started_at);
+
+-- Keep only the newest active session of every user before enforcing uniqueness.
+UPDATE user_sessions s
+SET status = 'abandoned', ended_at = now()
+WHERE s.status = 'active'
+  AND EXISTS (
+    SELECT 1 FROM user_sessions n
+    WHERE n.user_id = s.user_id
+      AND n.status = 'active'
+      AND (n.started_at, n.id) > (s.started_at, s.id)
+  );
+
+UPDATE user_sessions SET ended_at = NULL WHERE status = 'active';
+UPDATE user_sessions SET ended_at = last_active_at WHERE status <> 'active' AND ended_at IS NULL;
+
+ALTER TABLE user_sessions
+    ADD CONSTRAINT user_sessions_ended_at_check CHECK ((status = 'active') = (ended_at IS NULL));
+
+DROP INDEX IF EXISTS idx_user_sessions_status;
+CREATE UNIQUE INDEX user_sessions_one_active_per_user ON user_sessions (user_id) WHERE status = 'active';
+CREATE INDEX idx_user_sessions_idle ON user_sessions (last_active_at) WHERE status = 'active';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_user_sessions_idle;
+DROP INDEX IF EXISTS user_sessions_one_active_per_user;
+CREATE INDEX IF NOT EXISTS idx_user_sessions_status ON user_sessions (status) WHERE status = 'active';
+
+ALTER TABLE user_sessions DROP CONSTRAINT IF EXISTS user_sessions_ended_at_check;
+ALTER TABLE user_sessions DROP COLUMN IF EXISTS last_active_at;
@@ -0,0 +1,112 @@
+-- +goose Up
+ALTER TABLE user_sessions
+    ADD COLUMN active_seconds INTEGER NOT NULL DEFAULT 0 CHECK (active_seconds >= 0),
+    ADD COLUMN paused BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Sessions recorded before activity tracking have no events; their wall time is the best estimate.
+UPDATE user_sessions
+SET active_seconds = GREATEST(0, EXTRACT(EPOCH FROM (ended_at - started_at)))::INTEGER
+WHERE ended_at IS NOT NULL;
+
+CREATE TABLE user_session_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    session_id UUID NOT NULL REFERENCES user_sessions(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL CHECK (kind IN ('answer', 'pause', 'resume', 'heartbeat')),
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_session_events_session ON user_session_events (session_id, occurred_at);
+
+-- total_time now sums the active time of every session instead of its wall time.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION refresh_user_statistics(p_user_id UUID)
+RETURNS SETOF user_statistics
+LANGUAGE sql
+AS $$
+    INSERT INTO user_statistics (user_id, total_words_learned, accuracy, total_time, updated_at)
+    SELECT
+        u.id,
+        COALESCE(p.learned, 0),
+        COALESCE(ROUND(100.0 * p.correct / NULLIF(p.correct + p.incorrect, 0), 2), 0),
+        COALESCE(s.seconds, 0),
+        now()
+    FROM users u
+    LEFT JOIN (
+        SELECT
+            COUNT(*) FILTER (WHERE repetitions >= 2) AS learned,
+            SUM(correct_count) AS correct,
+            SUM(incorrect_count) AS incorrect
+        FROM user_progress
+        WHERE user_id = p_user_id
+    ) p ON TRUE
+    LEFT JOIN (
+        SELECT SUM(active_seconds)::INTEGER AS seconds
+        FROM user_sessions
+        WHERE user_id = p_user_id
+    ) s ON TRUE
+    WHERE u.id = p_user_id
+    ON CONFLICT (user_id) DO UPDATE
+    SET total_words_learned = EXCLUDED.total_words_learned,
+        accuracy = EXCLUDED.accuracy,
+        total_time = EXCLUDED.total_time,
+        updated_at = EXCLUDED.updated_at
+    RETURNING *;
+$$;
+-- +goose StatementEnd
+
+DROP TRIGGER IF EXISTS trg_user_sessions_refresh_statistics ON user_sessions;
+CREATE TRIGGER trg_user_sessions_refresh_statistics
+    AFTER INSERT OR UPDATE OF started_at, ended_at, active_seconds, user_id OR DELETE ON user_sessions
+    FOR EACH ROW EXECUTE FUNCTION refresh_user_statistics_trigger();
+
+SELECT refresh_user_statistics(id) FROM users;
+
+-- +goose Down
+DROP TRIGGER IF EXISTS trg_user_sessions_refresh_statistics ON user_sessions;
+CREATE TRIGGER trg_user_sessions_refresh_statistics
+    AFTER INSERT OR UPDATE OF started_at, ended_at, user_id OR DELETE ON user_sessions
+    FOR EACH ROW EXECUTE FUNCTION refresh_user_statistics_trigger();
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION refresh_user_statistics(p_user_id UUID)
+RETURNS SETOF user_statistics
+LANGUAGE sql
+AS $$
+    INSERT INTO user_statistics (user_id, total_words_learned, accuracy, total_time, updated_at)
+    SELECT
+        u.id,
+        COALESCE(p.learned, 0),
+        COALESCE(ROUND(100.0 * p.correct / NULLIF(p.correct + p.incorrect, 0), 2), 0),
+        COALESCE(s.seconds, 0),
+        now()
+    FROM users u
+    LEFT JOIN (
+        SELECT
+            COUNT(*) FILTER (WHERE repetitions >= 2) AS learned,
+            SUM(correct_count) AS correct,
+            SUM(incorrect_count) AS incorrect
+        FROM user_progress
+        WHERE user_id = p_user_id
+    ) p ON TRUE
+    LEFT JOIN (
+        SELECT SUM(EXTRACT(EPOCH FROM (ended_at - started_at)))::INTEGER AS seconds
+        FROM user_sessions
+        WHERE user_id = p_user_id AND ended_at IS NOT NULL AND ended_at > started_at
+    ) s ON TRUE
+    WHERE u.id = p_user_id
+    ON CONFLICT (user_id) DO UPDATE
+    SET total_words_learned = EXCLUDED.total_words_learned,
+        accuracy = EXCLUDED.accuracy,
+        total_time = EXCLUDED.total_time,
+        updated_at = EXCLUDED.updated_at
+    RETURNING *;
+$$;
+-- +goose StatementEnd
+
+DROP TABLE IF EXISTS user_session_events;
+
+ALTER TABLE user_sessions
+    DROP COLUMN IF EXISTS paused,
+    DROP COLUMN IF EXISTS active_seconds;
+
+SELECT refresh_user_statistics(id) FROM users;
@@ -0,0 +1,17 @@
+-- +goose Up
+-- Keyset pagination walks (sort key, id) in both directions; each index matches one listing.
+CREATE INDEX IF NOT EXISTS idx_users_created_at_id ON users (created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_user_sessions_user_started ON user_sessions (user_id, started_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_user_progress_user_last_attempt ON user_progress (user_id, last_attempt DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_user_statistics_updated_at ON user_statistics (updated_at DESC, user_id DESC);
+
+-- The (user_id, started_at, id) index covers every lookup by user_id alone.
+DROP INDEX IF EXISTS idx_user_sessions_user_id;
+
+-- +goose Down
+CREATE INDEX IF NOT EXISTS idx_user_sessions_user_id ON user_sessions (user_id);
+
+DROP INDEX IF EXISTS idx_user_statistics_updated_at;
+DROP INDEX IF EXISTS idx_user_progress_user_last_attempt;
+DROP INDEX IF EXISTS idx_user_sessions_user_started;
+DROP INDEX IF EXISTS idx_users_created_at_id;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- Admin search: username prefix (LIKE 'abc%') and exact email domain.
+CREATE INDEX IF NOT EXISTS idx_users_username_lower_pattern ON users (lower(username) text_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_users_email_domain ON users (lower(split_part(email, '@', 2)));
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_users_email_domain;
+DROP INDEX IF EXISTS idx_users_username_lower_pattern;
@@ -0,0 +1,45 @@
+-- +goose Up
+CREATE TABLE import_jobs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_by UUID NULL REFERENCES users(id) ON DELETE SET NULL,
+    filename TEXT NOT NULL,
+    format TEXT NOT NULL CHECK (format IN ('csv', 'tsv', 'anki_text', 'apkg')),
+    source_lang TEXT NOT NULL CHECK (char_length(source_lang) BETWEEN 2 AND 8),
+    target_lang TEXT NOT NULL CHECK (char_length(target_lang) BETWEEN 2 AND 8),
+    word_set_id UUID NULL REFERENCES word_sets(id) ON DELETE SET NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'completed', 'failed')),
+    total_rows INTEGER NOT NULL CHECK (total_rows >= 0),
+    processed_rows INTEGER NOT NULL DEFAULT 0 CHECK (processed_rows BETWEEN 0 AND total_rows),
+    created_words INTEGER NOT NULL DEFAULT 0,
+    existing_words INTEGER NOT NULL DEFAULT 0,
+    failed_rows INTEGER NOT NULL DEFAULT 0,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    error_code TEXT NULL,
+    lease_expires_at TIMESTAMPTZ NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    finished_at TIMESTAMPTZ NULL
+);
+
+-- Runners look for pending jobs and for running ones whose lease ran out.
+CREATE INDEX IF NOT EXISTS idx_import_jobs_claimable ON import_jobs (created_at) WHERE status IN ('pending', 'running');
+
+-- The uploaded file is kept apart so polling a job doesn't drag it along.
+CREATE TABLE import_job_payloads (
+    job_id UUID PRIMARY KEY REFERENCES import_jobs(id) ON DELETE CASCADE,
+    data BYTEA NOT NULL
+);
+
+CREATE TABLE import_job_errors (
+    job_id UUID NOT NULL REFERENCES import_jobs(id) ON DELETE CASCADE,
+    line INTEGER NOT NULL,
+    error JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (job_id, line)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS import_job_errors;
+DROP TABLE IF EXISTS import_job_payloads;
+DROP INDEX IF EXISTS idx_import_jobs_claimable;
+DROP TABLE IF EXISTS import_jobs;
@@ -0,0 +1,215 @@
+-- +goose Up
+-- Statistics used to be recomputed from all of a user's progress and sessions on every
+-- written row, which made batch attempts and review log imports quadratic. Triggers now
+-- apply each row's change as a delta; accuracy is kept exact through the attempt totals.
+ALTER TABLE user_statistics
+    ADD COLUMN correct_attempts BIGINT NOT NULL DEFAULT 0 CHECK (correct_attempts >= 0),
+    ADD COLUMN incorrect_attempts BIGINT NOT NULL DEFAULT 0 CHECK (incorrect_attempts >= 0);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION refresh_user_statistics(p_user_id UUID)
+RETURNS SETOF user_statistics
+LANGUAGE sql
+AS $$
+    INSERT INTO user_statistics (user_id, total_words_learned, accuracy, total_time, updated_at, correct_attempts, incorrect_attempts)
+    SELECT
+        u.id,
+        COALESCE(p.learned, 0),
+        COALESCE(ROUND(100.0 * p.correct / NULLIF(p.correct + p.incorrect, 0), 2), 0),
+        COALESCE(s.seconds, 0),
+        now(),
+        COALESCE(p.correct, 0),
+        COALESCE(p.incorrect, 0)
+    FROM users u
+    LEFT JOIN (
+        SELECT
+            COUNT(*) FILTER (WHERE repetitions >= 2) AS learned,
+            SUM(correct_count) AS correct,
+            SUM(incorrect_count) AS incorrect
+        FROM user_progress
+        WHERE user_id = p_user_id
+    ) p ON TRUE
+    LEFT JOIN (
+        SELECT SUM(active_seconds)::INTEGER AS seconds
+        FROM user_sessions
+        WHERE user_id = p_user_id
+    ) s ON TRUE
+    WHERE u.id = p_user_id
+    ON CONFLICT (user_id) DO UPDATE
+    SET total_words_learned = EXCLUDED.total_words_learned,
+        accuracy = EXCLUDED.accuracy,
+        total_time = EXCLUDED.total_time,
+        updated_at = EXCLUDED.updated_at,
+        correct_attempts = EXCLUDED.correct_attempts,
+        incorrect_attempts = EXCLUDED.incorrect_attempts
+    RETURNING *;
+$$;
+-- +goose StatementEnd
+
+-- Nothing is written for a user that no longer exists, such as while a user delete
+-- cascades to their progress and sessions.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION apply_user_statistics_delta(
+    p_user_id UUID, p_learned INTEGER, p_correct BIGINT, p_incorrect BIGINT, p_seconds INTEGER
+)
+RETURNS VOID
+LANGUAGE sql
+AS $$
+    INSERT INTO user_statistics AS st (user_id, total_words_learned, accuracy, total_time, updated_at, correct_attempts, incorrect_attempts)
+    SELECT
+        u.id,
+        p_learned,
+        COALESCE(ROUND(100.0 * p_correct / NULLIF(p_correct + p_incorrect, 0), 2), 0),
+        p_seconds,
+        now(),
+        p_correct,
+        p_incorrect
+    FROM users u
+    WHERE u.id = p_user_id
+    ON CONFLICT (user_id) DO UPDATE
+    SET total_words_learned = st.total_words_learned + EXCLUDED.total_words_learned,
+        accuracy = COALESCE(ROUND(100.0 * (st.correct_attempts + EXCLUDED.correct_attempts)
+            / NULLIF(st.correct_attempts + EXCLUDED.correct_attempts + st.incorrect_attempts + EXCLUDED.incorrect_attempts, 0), 2), 0),
+        total_time = st.total_time + EXCLUDED.total_time,
+        updated_at = EXCLUDED.updated_at,
+        correct_attempts = st.correct_attempts + EXCLUDED.correct_attempts,
+        incorrect_attempts = st.incorrect_attempts + EXCLUDED.incorrect_attempts;
+$$;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION user_progress_statistics_trigger()
+RETURNS TRIGGER
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' AND NEW.user_id = OLD.user_id THEN
+        PERFORM apply_user_statistics_delta(NEW.user_id,
+            (NEW.repetitions >= 2)::INTEGER - (OLD.repetitions >= 2)::INTEGER,
+            NEW.correct_count - OLD.correct_count,
+            NEW.incorrect_count - OLD.incorrect_count,
+            0);
+        RETURN NULL;
+    END IF;
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        PERFORM apply_user_statistics_delta(OLD.user_id,
+            -(OLD.repetitions >= 2)::INTEGER, -OLD.correct_count, -OLD.incorrect_count, 0);
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        PERFORM apply_user_statistics_delta(NEW.user_id,
+            (NEW.repetitions >= 2)::INTEGER, NEW.correct_count, NEW.incorrect_count, 0);
+    END IF;
+    RETURN NULL;
+END;
+$$;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION user_sessions_statistics_trigger()
+RETURNS TRIGGER
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' AND NEW.user_id = OLD.user_id THEN
+        IF NEW.active_seconds <> OLD.active_seconds THEN
+            PERFORM apply_user_statistics_delta(NEW.user_id, 0, 0, 0, NEW.active_seconds - OLD.active_seconds);
+        END IF;
+        RETURN NULL;
+    END IF;
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        PERFORM apply_user_statistics_delta(OLD.user_id, 0, 0, 0, -OLD.active_seconds);
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        PERFORM apply_user_statistics_delta(NEW.user_id, 0, 0, 0, NEW.active_seconds);
+    END IF;
+    RETURN NULL;
+END;
+$$;
+-- +goose StatementEnd
+
+DROP TRIGGER IF EXISTS trg_user_progress_refresh_statistics ON user_progress;
+CREATE TRIGGER trg_user_progress_refresh_statistics
+    AFTER INSERT OR UPDATE OF user_id, correct_count, incorrect_count, repetitions OR DELETE ON user_progress
+    FOR EACH ROW EXECUTE FUNCTION user_progress_statistics_trigger();
+
+DROP TRIGGER IF EXISTS trg_user_sessions_refresh_statistics ON user_sessions;
+CREATE TRIGGER trg_user_sessions_refresh_statistics
+    AFTER INSERT OR UPDATE OF active_seconds, user_id OR DELETE ON user_sessions
+    FOR EACH ROW EXECUTE FUNCTION user_sessions_statistics_trigger();
+
+DROP FUNCTION IF EXISTS refresh_user_statistics_trigger();
+
+SELECT refresh_user_statistics(id) FROM users;
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION refresh_user_statistics_trigger()
+RETURNS TRIGGER
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        PERFORM refresh_user_statistics(OLD.user_id);
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') AND (TG_OP = 'INSERT' OR NEW.user_id IS DISTINCT FROM OLD.user_id) THEN
+        PERFORM refresh_user_statistics(NEW.user_id);
+    END IF;
+    RETURN NULL;
+END;
+$$;
+-- +goose StatementEnd
+
+DROP TRIGGER IF EXISTS trg_user_sessions_refresh_statistics ON user_sessions;
+CREATE TRIGGER trg_user_sessions_refresh_statistics
+    AFTER INSERT OR UPDATE OF started_at, ended_at, active_seconds, user_id OR DELETE ON user_sessions
+    FOR EACH ROW EXECUTE FUNCTION refresh_user_statistics_trigger();
+
+DROP TRIGGER IF EXISTS trg_user_progress_refresh_statistics ON user_progress;
+CREATE TRIGGER trg_user_progress_refresh_statistics
+    AFTER INSERT OR UPDATE OR DELETE ON user_progress
+    FOR EACH ROW EXECUTE FUNCTION refresh_user_statistics_trigger();
+
+DROP FUNCTION IF EXISTS user_sessions_statistics_trigger();
+DROP FUNCTION IF EXISTS user_progress_statistics_trigger();
+DROP FUNCTION IF EXISTS apply_user_statistics_delta(UUID, INTEGER, BIGINT, BIGINT, INTEGER);
+
+ALTER TABLE user_statistics
+    DROP COLUMN correct_attempts,
+    DROP COLUMN incorrect_attempts;
+
+-- refresh_user_statistics goes back to the body migration 15 gave it.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION refresh_user_statistics(p_user_id UUID)
+RETURNS SETOF user_statistics
+LANGUAGE sql
+AS $$
+    INSERT INTO user_statistics (user_id, total_words_learned, accuracy, total_time, updated_at)
+    SELECT
+        u.id,
+        COALESCE(p.learned, 0),
+        COALESCE(ROUND(100.0 * p.correct / NULLIF(p.correct + p.incorrect, 0), 2), 0),
+        COALESCE(s.seconds, 0),
+        now()
+    FROM users u
+    LEFT JOIN (
+        SELECT
+            COUNT(*) FILTER (WHERE repetitions >= 2) AS learned,
+            SUM(correct_count) AS correct,
+            SUM(incorrect_count) AS incorrect
+        FROM user_progress
+        WHERE user_id = p_user_id
+    ) p ON TRUE
+    LEFT JOIN (
+        SELECT SUM(active_seconds)::INTEGER AS seconds
+        FROM user_sessions
+        WHERE user_id = p_user_id
+    ) s ON TRUE
+    WHERE u.id = p_user_id
+    ON CONFLICT (user_id) DO UPDATE
+    SET total_words_learned = EXCLUDED.total_words_learned,
+        accuracy = EXCLUDED.accuracy,
+        total_time = EXCLUDED.total_time,
+        updated_at = EXCLUDED.updated_at
+    RETURNING *;
+$$;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- Only live accounts need distinct emails: a soft-deleted user keeps theirs until the
+-- purge without blocking a new registration. Restoring a user whose email has been
+-- registered again in the meantime trips this index.
+ALTER TABLE users DROP CONSTRAINT users_email_key;
+
+CREATE UNIQUE INDEX users_email_key ON users (email) WHERE deleted_at IS NULL;
+
+-- +goose Down
+-- Fails while a deleted user and a live one share an email.
+DROP INDEX IF EXISTS users_email_key;
+
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
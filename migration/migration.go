@@ -0,0 +1,10 @@
+// Package migration embeds the goose SQL migrations so the binary can apply them
+// itself; see the migrate subcommand and db.Migrator.
+package migration
+
+import "embed"
+
+// FS holds every migration file at its root.
+//
+//go:embed *.sql
+var FS embed.FS
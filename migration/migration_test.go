@@ -0,0 +1,158 @@
+package migration
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"test-http/internal/testutil"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/pressly/goose/v3"
+)
+
+var update = flag.Bool("update", false, "rewrite testdata/schema.golden from the migrated database")
+
+const goldenSchema = "testdata/schema.golden"
+
+// schemaQueries describe the public schema one object per row. Objects owned by an
+// extension, such as the functions citext installs, are left to the extension line,
+// and goose's own version table is not part of the schema under test.
+var schemaQueries = []string{
+	`SELECT 'extension ' || extname FROM pg_extension WHERE extname <> 'plpgsql'`,
+
+	`SELECT format('column %s.%s #%s %s%s%s', c.relname, a.attname,
+	        row_number() OVER (PARTITION BY a.attrelid ORDER BY a.attnum),
+	        format_type(a.atttypid, a.atttypmod),
+	        CASE WHEN a.attnotnull THEN ' not null' ELSE '' END,
+	        COALESCE(' default ' || pg_get_expr(d.adbin, d.adrelid), ''))
+	 FROM pg_attribute a
+	 JOIN pg_class c ON c.oid = a.attrelid
+	 LEFT JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+	 WHERE c.relnamespace = 'public'::regnamespace AND c.relkind IN ('r', 'p')
+	   AND c.relname <> 'goose_db_version' AND a.attnum > 0 AND NOT a.attisdropped`,
+
+	`SELECT format('constraint %s.%s %s', c.relname, con.conname, pg_get_constraintdef(con.oid))
+	 FROM pg_constraint con
+	 JOIN pg_class c ON c.oid = con.conrelid
+	 WHERE con.connamespace = 'public'::regnamespace AND c.relname <> 'goose_db_version'`,
+
+	`SELECT 'index ' || indexdef FROM pg_indexes
+	 WHERE schemaname = 'public' AND tablename <> 'goose_db_version'`,
+
+	`SELECT 'function ' || pg_get_functiondef(p.oid)
+	 FROM pg_proc p
+	 WHERE p.pronamespace = 'public'::regnamespace
+	   AND NOT EXISTS (SELECT 1 FROM pg_depend d WHERE d.objid = p.oid AND d.deptype = 'e')`,
+
+	`SELECT 'trigger ' || pg_get_triggerdef(t.oid)
+	 FROM pg_trigger t
+	 JOIN pg_class c ON c.oid = t.tgrelid
+	 WHERE c.relnamespace = 'public'::regnamespace AND NOT t.tgisinternal`,
+}
+
+// TestMigrations_UpDownUp applies every migration, rolls it back and applies it again.
+// Rolling back has to restore the schema the migration started from, and applying it
+// again has to reproduce the schema it first built. The end result is compared with
+// testdata/schema.golden; run with -update after adding a migration to refresh it.
+func TestMigrations_UpDownUp(t *testing.T) {
+	if testing.Short() {
+		t.Skip("starts a Postgres container")
+	}
+	pool := testutil.SetupTestDB(t)
+	defer testutil.TeardownTestDB(t, pool)
+	ctx := context.Background()
+
+	db := stdlib.OpenDBFromPool(pool)
+	defer db.Close()
+	provider, err := goose.NewProvider(goose.DialectPostgres, db, FS)
+	if err != nil {
+		t.Fatalf("goose.NewProvider: %v", err)
+	}
+
+	before := schemaSnapshot(t, ctx, pool)
+	for _, source := range provider.ListSources() {
+		name := filepath.Base(source.Path)
+
+		if _, err := provider.UpByOne(ctx); err != nil {
+			t.Fatalf("%s: up: %v", name, err)
+		}
+		after := schemaSnapshot(t, ctx, pool)
+
+		if _, err := provider.Down(ctx); err != nil {
+			t.Fatalf("%s: down: %v", name, err)
+		}
+		if got := schemaSnapshot(t, ctx, pool); !slices.Equal(got, before) {
+			t.Fatalf("%s: down does not restore the previous schema:\n%s", name, schemaDiff(before, got))
+		}
+
+		if _, err := provider.UpByOne(ctx); err != nil {
+			t.Fatalf("%s: up after down: %v", name, err)
+		}
+		if got := schemaSnapshot(t, ctx, pool); !slices.Equal(got, after) {
+			t.Fatalf("%s: up after down builds a different schema:\n%s", name, schemaDiff(after, got))
+		}
+		before = after
+	}
+
+	got := strings.Join(before, "\n") + "\n"
+	if *update {
+		if err := os.WriteFile(goldenSchema, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(goldenSchema)
+	if err != nil {
+		t.Fatalf("reading golden schema (run with -update to create it): %v", err)
+	}
+	if got != string(want) {
+		t.Fatalf("schema differs from %s (run with -update if the change is intended):\n%s",
+			goldenSchema, schemaDiff(strings.Split(strings.TrimSuffix(string(want), "\n"), "\n"), before))
+	}
+}
+
+// schemaSnapshot lists the objects of the public schema in a stable order.
+func schemaSnapshot(t *testing.T, ctx context.Context, pool *pgxpool.Pool) []string {
+	t.Helper()
+	var objects []string
+	for _, q := range schemaQueries {
+		rows, err := pool.Query(ctx, q)
+		if err != nil {
+			t.Fatalf("reading schema: %v", err)
+		}
+		for rows.Next() {
+			var object string
+			if err := rows.Scan(&object); err != nil {
+				t.Fatalf("reading schema: %v", err)
+			}
+			objects = append(objects, strings.TrimSpace(object))
+		}
+		if err := rows.Err(); err != nil {
+			t.Fatalf("reading schema: %v", err)
+		}
+	}
+	slices.Sort(objects)
+	return objects
+}
+
+// schemaDiff lists the objects only in want with "-" and those only in got with "+".
+func schemaDiff(want, got []string) string {
+	var b strings.Builder
+	for _, o := range want {
+		if !slices.Contains(got, o) {
+			b.WriteString("- " + o + "\n")
+		}
+	}
+	for _, o := range got {
+		if !slices.Contains(want, o) {
+			b.WriteString("+ " + o + "\n")
+		}
+	}
+	return b.String()
+}
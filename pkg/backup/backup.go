@@ -0,0 +1,293 @@
+// Package backup reads and writes the database backup archive format.
+//
+// An archive is a gzip stream holding a magic line, a JSON header, one block per
+// table and an end marker. Each part is a frame: a big-endian uint32 length followed
+// by that many bytes. A table block is a JSON block header, the table data as frames
+// ended by an empty frame, and a JSON trailer with the row count and the SHA-256 of
+// the data. An empty frame in place of a block header ends the archive. Every part
+// can be written and read in a single pass, so archives can be piped.
+package backup
+
+import (
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"time"
+)
+
+// FormatVersion is the archive layout written by this package. Readers reject other versions.
+const FormatVersion = 1
+
+const (
+	magic = "test-http-backup\n"
+
+	// maxChunk bounds a data frame on write; maxMetaFrame bounds a JSON frame on read.
+	maxChunk     = 64 << 10
+	maxMetaFrame = 1 << 20
+)
+
+var (
+	ErrNotArchive = errors.New("backup: not a backup archive")
+	ErrVersion    = errors.New("backup: unsupported archive format version")
+	ErrChecksum   = errors.New("backup: table checksum mismatch")
+	ErrRowCount   = errors.New("backup: table row count mismatch")
+	ErrTruncated  = errors.New("backup: archive is truncated")
+)
+
+// Header describes an archive. SchemaVersion is the migration level of the dumped
+// database; Tables lists the table blocks in the order they appear.
+type Header struct {
+	FormatVersion int       `json:"format_version"`
+	SchemaVersion int64     `json:"schema_version"`
+	CreatedAt     time.Time `json:"created_at"`
+	Tables        []string  `json:"tables"`
+}
+
+type blockHeader struct {
+	Table string `json:"table"`
+}
+
+type blockTrailer struct {
+	Rows   int64  `json:"rows"`
+	SHA256 string `json:"sha256"`
+}
+
+// Writer writes an archive. Tables must be written in the order of Header.Tables.
+type Writer struct {
+	gz     *gzip.Writer
+	header Header
+	next   int
+}
+
+// NewWriter writes the magic line and h to w. FormatVersion is filled in.
+func NewWriter(w io.Writer, h Header) (*Writer, error) {
+	h.FormatVersion = FormatVersion
+	gz := gzip.NewWriter(w)
+	if _, err := io.WriteString(gz, magic); err != nil {
+		return nil, err
+	}
+	if err := writeJSONFrame(gz, h); err != nil {
+		return nil, err
+	}
+	return &Writer{gz: gz, header: h}, nil
+}
+
+// WriteTable writes one table block. dump writes the table data and returns the number
+// of rows it wrote; the row count and a checksum of the data go into the block trailer.
+func (w *Writer) WriteTable(name string, dump func(io.Writer) (int64, error)) error {
+	if w.next >= len(w.header.Tables) || w.header.Tables[w.next] != name {
+		return fmt.Errorf("backup: table %q written out of header order", name)
+	}
+	w.next++
+
+	if err := writeJSONFrame(w.gz, blockHeader{Table: name}); err != nil {
+		return err
+	}
+	cw := &chunkWriter{w: w.gz, hash: sha256.New()}
+	rows, err := dump(cw)
+	if err != nil {
+		return fmt.Errorf("backup: dump %s: %w", name, err)
+	}
+	if err := writeFrame(w.gz, nil); err != nil {
+		return err
+	}
+	return writeJSONFrame(w.gz, blockTrailer{Rows: rows, SHA256: hex.EncodeToString(cw.hash.Sum(nil))})
+}
+
+// Close writes the end marker and flushes the compressed stream. It does not close
+// the underlying writer.
+func (w *Writer) Close() error {
+	if w.next != len(w.header.Tables) {
+		return fmt.Errorf("backup: %d of %d tables written", w.next, len(w.header.Tables))
+	}
+	if err := writeFrame(w.gz, nil); err != nil {
+		return err
+	}
+	return w.gz.Close()
+}
+
+// Reader reads an archive written by Writer.
+type Reader struct {
+	Header Header
+
+	r    *bufio.Reader
+	next int
+}
+
+// NewReader checks the magic line and format version and reads the header.
+func NewReader(r io.Reader) (*Reader, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, ErrNotArchive
+	}
+	br := bufio.NewReader(gz)
+
+	got := make([]byte, len(magic))
+	if _, err := io.ReadFull(br, got); err != nil || string(got) != magic {
+		return nil, ErrNotArchive
+	}
+	var h Header
+	if err := readJSONFrame(br, &h); err != nil {
+		return nil, err
+	}
+	if h.FormatVersion != FormatVersion {
+		return nil, fmt.Errorf("%w: %d", ErrVersion, h.FormatVersion)
+	}
+	return &Reader{Header: h, r: br}, nil
+}
+
+// NextTable passes the data of the next table block to load, which returns the number
+// of rows it loaded. Any data load leaves unread is skipped. The checksum and row count
+// are verified against the block trailer once load returns. NextTable returns io.EOF
+// after the last table.
+func (r *Reader) NextTable(load func(name string, data io.Reader) (int64, error)) error {
+	raw, err := readFrame(r.r, maxMetaFrame)
+	if err != nil {
+		return err
+	}
+	if len(raw) == 0 {
+		if r.next != len(r.Header.Tables) {
+			return ErrTruncated
+		}
+		// Reading to the end makes gzip verify its own CRC of the whole stream.
+		if _, err := io.Copy(io.Discard, r.r); err != nil {
+			return fmt.Errorf("%w: %v", ErrChecksum, err)
+		}
+		return io.EOF
+	}
+	var bh blockHeader
+	if err := json.Unmarshal(raw, &bh); err != nil {
+		return ErrNotArchive
+	}
+	if r.next >= len(r.Header.Tables) || r.Header.Tables[r.next] != bh.Table {
+		return fmt.Errorf("%w: unexpected table %q", ErrNotArchive, bh.Table)
+	}
+	r.next++
+
+	cr := &chunkReader{r: r.r, hash: sha256.New()}
+	rows, err := load(bh.Table, cr)
+	if err != nil {
+		return fmt.Errorf("backup: load %s: %w", bh.Table, err)
+	}
+	if _, err := io.Copy(io.Discard, cr); err != nil {
+		return err
+	}
+
+	var trailer blockTrailer
+	if err := readJSONFrame(r.r, &trailer); err != nil {
+		return err
+	}
+	if hex.EncodeToString(cr.hash.Sum(nil)) != trailer.SHA256 {
+		return fmt.Errorf("%w: %s", ErrChecksum, bh.Table)
+	}
+	if rows != trailer.Rows {
+		return fmt.Errorf("%w: %s has %d rows, archive says %d", ErrRowCount, bh.Table, rows, trailer.Rows)
+	}
+	return nil
+}
+
+// chunkWriter frames table data and hashes it on the way out.
+type chunkWriter struct {
+	w    io.Writer
+	hash hash.Hash
+}
+
+func (c *chunkWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		n := min(len(p), maxChunk)
+		if err := writeFrame(c.w, p[:n]); err != nil {
+			return written, err
+		}
+		c.hash.Write(p[:n])
+		written += n
+		p = p[n:]
+	}
+	return written, nil
+}
+
+// chunkReader returns table data frame by frame until the empty frame, hashing it.
+type chunkReader struct {
+	r    *bufio.Reader
+	left uint32
+	done bool
+	hash hash.Hash
+}
+
+func (c *chunkReader) Read(p []byte) (int, error) {
+	for c.left == 0 {
+		if c.done {
+			return 0, io.EOF
+		}
+		var size [4]byte
+		if _, err := io.ReadFull(c.r, size[:]); err != nil {
+			return 0, ErrTruncated
+		}
+		c.left = binary.BigEndian.Uint32(size[:])
+		if c.left == 0 {
+			c.done = true
+		}
+	}
+	if uint32(len(p)) > c.left {
+		p = p[:c.left]
+	}
+	n, err := c.r.Read(p)
+	c.left -= uint32(n)
+	c.hash.Write(p[:n])
+	if errors.Is(err, io.EOF) {
+		return n, ErrTruncated
+	}
+	return n, err
+}
+
+func writeFrame(w io.Writer, p []byte) error {
+	var size [4]byte
+	binary.BigEndian.PutUint32(size[:], uint32(len(p)))
+	if _, err := w.Write(size[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(p)
+	return err
+}
+
+func writeJSONFrame(w io.Writer, v any) error {
+	p, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return writeFrame(w, p)
+}
+
+func readFrame(r io.Reader, limit uint32) ([]byte, error) {
+	var size [4]byte
+	if _, err := io.ReadFull(r, size[:]); err != nil {
+		return nil, ErrTruncated
+	}
+	n := binary.BigEndian.Uint32(size[:])
+	if n > limit {
+		return nil, ErrNotArchive
+	}
+	p := make([]byte, n)
+	if _, err := io.ReadFull(r, p); err != nil {
+		return nil, ErrTruncated
+	}
+	return p, nil
+}
+
+func readJSONFrame(r io.Reader, v any) error {
+	p, err := readFrame(r, maxMetaFrame)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(p, v); err != nil {
+		return ErrNotArchive
+	}
+	return nil
+}
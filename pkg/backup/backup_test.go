@@ -0,0 +1,132 @@
+package backup
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeTestArchive(t *testing.T, tables map[string]string, order []string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, Header{SchemaVersion: 20251005000018, CreatedAt: time.Unix(1_700_000_000, 0).UTC(), Tables: order})
+	if err != nil {
+		t.Fatalf("NewWriter: %v", err)
+	}
+	for _, name := range order {
+		data := tables[name]
+		if err := w.WriteTable(name, func(dst io.Writer) (int64, error) {
+			_, err := io.WriteString(dst, data)
+			return int64(strings.Count(data, "\n")), err
+		}); err != nil {
+			t.Fatalf("WriteTable %s: %v", name, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func countRows(_ string, data io.Reader) (int64, error) {
+	b, err := io.ReadAll(data)
+	return int64(bytes.Count(b, []byte("\n"))), err
+}
+
+func TestArchive_RoundTrip(t *testing.T) {
+	big := strings.Repeat("row\n", maxChunk/2) // spans several data frames
+	tables := map[string]string{"users": "a\nb\n", "user_sessions": "", "user_progress": big}
+	order := []string{"users", "user_sessions", "user_progress"}
+
+	r, err := NewReader(bytes.NewReader(writeTestArchive(t, tables, order)))
+	if err != nil {
+		t.Fatalf("NewReader: %v", err)
+	}
+	if r.Header.FormatVersion != FormatVersion || r.Header.SchemaVersion != 20251005000018 {
+		t.Fatalf("unexpected header: %+v", r.Header)
+	}
+
+	var got []string
+	for {
+		err := r.NextTable(func(name string, data io.Reader) (int64, error) {
+			b, err := io.ReadAll(data)
+			if string(b) != tables[name] {
+				t.Errorf("%s: got %d bytes, want %d", name, len(b), len(tables[name]))
+			}
+			got = append(got, name)
+			return int64(bytes.Count(b, []byte("\n"))), err
+		})
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("NextTable: %v", err)
+		}
+	}
+	if strings.Join(got, ",") != strings.Join(order, ",") {
+		t.Fatalf("tables = %v, want %v", got, order)
+	}
+}
+
+func TestArchive_Rejects(t *testing.T) {
+	archive := writeTestArchive(t, map[string]string{"users": "a\nb\n"}, []string{"users"})
+
+	// Flip one byte of table data inside the compressed stream.
+	plain := gunzip(t, archive)
+	tampered := bytes.Replace(plain, []byte("a\nb\n"), []byte("a\nc\n"), 1)
+
+	tests := []struct {
+		name    string
+		archive []byte
+		load    func(string, io.Reader) (int64, error)
+		want    error
+	}{
+		{"not gzip", []byte("hello"), countRows, ErrNotArchive},
+		{"wrong magic", regzip(t, bytes.Replace(plain, []byte(magic), []byte("something-else!!\n"), 1)), countRows, ErrNotArchive},
+		{"future version", regzip(t, bytes.Replace(plain, []byte(`"format_version":1`), []byte(`"format_version":9`), 1)), countRows, ErrVersion},
+		{"tampered data", regzip(t, tampered), countRows, ErrChecksum},
+		{"truncated", regzip(t, plain[:len(plain)-12]), countRows, ErrTruncated},
+		{"row count", archive, func(string, io.Reader) (int64, error) { return 1, nil }, ErrRowCount},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewReader(bytes.NewReader(tt.archive))
+			for err == nil {
+				err = r.NextTable(tt.load)
+			}
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func gunzip(t *testing.T, p []byte) []byte {
+	t.Helper()
+	gz, err := gzip.NewReader(bytes.NewReader(p))
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := io.ReadAll(gz)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func regzip(t *testing.T, p []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(p); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
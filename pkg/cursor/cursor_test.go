@@ -0,0 +1,67 @@
+package cursor
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestCodec() *Codec {
+	return NewCodec([]byte("0123456789abcdef0123456789abcdef"))
+}
+
+func TestCodec_EncodeDecode(t *testing.T) {
+	c := newTestCodec()
+	want := Cursor{
+		Scope: "users",
+		At:    time.Date(2025, 10, 5, 12, 30, 0, 123456000, time.UTC),
+		ID:    "8f2c1e0a-7b5d-4e5f-9a1b-2c3d4e5f6a7b",
+		Prev:  true,
+	}
+
+	got, err := c.Decode("users", c.Encode(want))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if !got.At.Equal(want.At) || got.ID != want.ID || got.Prev != want.Prev || got.Scope != want.Scope {
+		t.Fatalf("cursor = %+v, want %+v", got, want)
+	}
+}
+
+func TestCodec_DecodeRejects(t *testing.T) {
+	c := newTestCodec()
+	token := c.Encode(Cursor{Scope: "users", At: time.Unix(1_700_000_000, 0), ID: "id-1"})
+	body, sig, _ := strings.Cut(token, ".")
+
+	foreign := NewCodec([]byte("another-secret-another-secret-xx")).
+		Encode(Cursor{Scope: "users", At: time.Unix(1_700_000_000, 0), ID: "id-1"})
+
+	// A body signed with the shared secret itself, the way the JWT issuer signs.
+	mac := hmac.New(sha256.New, []byte("0123456789abcdef0123456789abcdef"))
+	mac.Write([]byte(body))
+	rawSigned := body + "." + encoding.EncodeToString(mac.Sum(nil))
+
+	tests := []struct {
+		name  string
+		scope string
+		token string
+		want  error
+	}{
+		{"empty", "users", "", ErrMalformed},
+		{"no signature", "users", body, ErrMalformed},
+		{"tampered body", "users", "x" + body + "." + sig, ErrSignature},
+		{"other secret", "users", foreign, ErrSignature},
+		{"underived secret", "users", rawSigned, ErrSignature},
+		{"other scope", "user_sessions", token, ErrScope},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := c.Decode(tt.scope, tt.token); !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -9,8 +9,66 @@ const (
 	ContextCreatingUserStatisticsMissing fault.Code = "CONTEXT_CREATING_USER_STATISTICS_MISSING"
 	ContextGettingUserStatisticsMissing  fault.Code = "CONTEXT_GETTING_USER_STATISTICS_MISSING"
 	ContextUpdatingUserStatisticsMissing fault.Code = "CONTEXT_UPDATING_USER_STATISTICS_MISSING"
-	ContextDeletingUserStatisticsMissing fault.Code = "CONTEXT_DELETING_USER_STATISTICS_MISSING"
 	UUIDParsingFailed                    fault.Code = "UUID_PARSING_FAILED"
 	ContextGettingUserMissing            fault.Code = "CONTEXT_GETTING_USER_MISSING"
+	UserNotFound                         fault.Code = "USER_NOT_FOUND"
 	InfrastructureUnexpected             fault.Code = "INFRASTRUCTURE_UNEXPECTED"
+	ContextCreatingWordMissing           fault.Code = "CONTEXT_CREATING_WORD_MISSING"
+	ContextGettingWordMissing            fault.Code = "CONTEXT_GETTING_WORD_MISSING"
+	ContextUpdatingWordMissing           fault.Code = "CONTEXT_UPDATING_WORD_MISSING"
+	ContextDeletingWordMissing           fault.Code = "CONTEXT_DELETING_WORD_MISSING"
+	ContextSearchingWordsMissing         fault.Code = "CONTEXT_SEARCHING_WORDS_MISSING"
+	QueryParamInvalid                    fault.Code = "QUERY_PARAM_INVALID"
+	WordSetNotFound                      fault.Code = "WORD_SET_NOT_FOUND"
+	WordSetItemNotFound                  fault.Code = "WORD_SET_ITEM_NOT_FOUND"
+	WordSetReorderMismatch               fault.Code = "WORD_SET_REORDER_MISMATCH"
+	ContextCreatingWordSetMissing        fault.Code = "CONTEXT_CREATING_WORD_SET_MISSING"
+	ContextGettingWordSetMissing         fault.Code = "CONTEXT_GETTING_WORD_SET_MISSING"
+	ContextUpdatingWordSetMissing        fault.Code = "CONTEXT_UPDATING_WORD_SET_MISSING"
+	ContextDeletingWordSetMissing        fault.Code = "CONTEXT_DELETING_WORD_SET_MISSING"
+	ContextUpdatingWordSetItemsMissing   fault.Code = "CONTEXT_UPDATING_WORD_SET_ITEMS_MISSING"
+	UserWordSetNotFound                  fault.Code = "USER_WORD_SET_NOT_FOUND"
+	GameWordSetEmpty                     fault.Code = "GAME_WORD_SET_EMPTY"
+	GameRoundNotFound                    fault.Code = "GAME_ROUND_NOT_FOUND"
+	GameRoundClosed                      fault.Code = "GAME_ROUND_CLOSED"
+	GameRoundFinished                    fault.Code = "GAME_ROUND_FINISHED"
+	GameQuestionNotFound                 fault.Code = "GAME_QUESTION_NOT_FOUND"
+	GameQuestionAlreadyAnswered          fault.Code = "GAME_QUESTION_ALREADY_ANSWERED"
+	GameFlashcardVerdictMissing          fault.Code = "GAME_FLASHCARD_VERDICT_MISSING"
+	ContextPlayingGameMissing            fault.Code = "CONTEXT_PLAYING_GAME_MISSING"
+	ContextListingDueReviewsMissing      fault.Code = "CONTEXT_LISTING_DUE_REVIEWS_MISSING"
+	Unauthorized                         fault.Code = "UNAUTHORIZED"
+	Forbidden                            fault.Code = "FORBIDDEN"
+	EmailAlreadyRegistered               fault.Code = "EMAIL_ALREADY_REGISTERED"
+	InvalidCredentials                   fault.Code = "INVALID_CREDENTIALS"
+	RefreshTokenInvalid                  fault.Code = "REFRESH_TOKEN_INVALID"
+	ContextAuthenticatingMissing         fault.Code = "CONTEXT_AUTHENTICATING_MISSING"
+	Conflict                             fault.Code = "CONFLICT"
+	ReferenceMissing                     fault.Code = "REFERENCE_MISSING"
+	WordNotFound                         fault.Code = "WORD_NOT_FOUND"
+	UserProgressNotFound                 fault.Code = "USER_PROGRESS_NOT_FOUND"
+	UserSessionNotFound                  fault.Code = "USER_SESSION_NOT_FOUND"
+	ContextCreatingUserSessionMissing    fault.Code = "CONTEXT_CREATING_USER_SESSION_MISSING"
+	ContextGettingUserSessionMissing     fault.Code = "CONTEXT_GETTING_USER_SESSION_MISSING"
+	ContextUpdatingUserSessionMissing    fault.Code = "CONTEXT_UPDATING_USER_SESSION_MISSING"
+	ContextDeletingUserSessionMissing    fault.Code = "CONTEXT_DELETING_USER_SESSION_MISSING"
+	ContextCreatingUserProgressMissing   fault.Code = "CONTEXT_CREATING_USER_PROGRESS_MISSING"
+	ContextGettingUserProgressMissing    fault.Code = "CONTEXT_GETTING_USER_PROGRESS_MISSING"
+	ContextUpdatingUserProgressMissing   fault.Code = "CONTEXT_UPDATING_USER_PROGRESS_MISSING"
+	ContextDeletingUserProgressMissing   fault.Code = "CONTEXT_DELETING_USER_PROGRESS_MISSING"
+	ContextCreatingUserWordSetMissing    fault.Code = "CONTEXT_CREATING_USER_WORD_SET_MISSING"
+	ContextGettingUserWordSetMissing     fault.Code = "CONTEXT_GETTING_USER_WORD_SET_MISSING"
+	ContextUpdatingUserWordSetMissing    fault.Code = "CONTEXT_UPDATING_USER_WORD_SET_MISSING"
+	ContextDeletingUserWordSetMissing    fault.Code = "CONTEXT_DELETING_USER_WORD_SET_MISSING"
+	UserSessionAlreadyActive             fault.Code = "USER_SESSION_ALREADY_ACTIVE"
+	UserSessionTransitionInvalid         fault.Code = "USER_SESSION_TRANSITION_INVALID"
+	UserSessionNotActive                 fault.Code = "USER_SESSION_NOT_ACTIVE"
+	CursorInvalid                        fault.Code = "CURSOR_INVALID"
+	ImportJobNotFound                    fault.Code = "IMPORT_JOB_NOT_FOUND"
+	ImportJobNotRetryable                fault.Code = "IMPORT_JOB_NOT_RETRYABLE"
+	ImportFileInvalid                    fault.Code = "IMPORT_FILE_INVALID"
+	ImportFileTooLarge                   fault.Code = "IMPORT_FILE_TOO_LARGE"
+	ImportRowInvalid                     fault.Code = "IMPORT_ROW_INVALID"
+	ContextImportingWordsMissing         fault.Code = "CONTEXT_IMPORTING_WORDS_MISSING"
+	ContextImportingProgressMissing      fault.Code = "CONTEXT_IMPORTING_PROGRESS_MISSING"
 )
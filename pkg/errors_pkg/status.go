@@ -0,0 +1,84 @@
+package errors_pkg
+
+import (
+	"net/http"
+
+	"test-http/pkg/fault"
+)
+
+// statuses maps every code declared in this package to the HTTP status
+// fault.HTTPError responds with. A new code must be added here as well.
+var statuses = map[fault.Code]int{
+	ValidationError:                      http.StatusBadRequest,
+	DecodeFailed:                         http.StatusBadRequest,
+	ContextCreatingUserMissing:           http.StatusInternalServerError,
+	ContextCreatingUserStatisticsMissing: http.StatusInternalServerError,
+	ContextGettingUserStatisticsMissing:  http.StatusInternalServerError,
+	ContextUpdatingUserStatisticsMissing: http.StatusInternalServerError,
+	UUIDParsingFailed:                    http.StatusBadRequest,
+	ContextGettingUserMissing:            http.StatusInternalServerError,
+	UserNotFound:                         http.StatusNotFound,
+	InfrastructureUnexpected:             http.StatusInternalServerError,
+	ContextCreatingWordMissing:           http.StatusInternalServerError,
+	ContextGettingWordMissing:            http.StatusInternalServerError,
+	ContextUpdatingWordMissing:           http.StatusInternalServerError,
+	ContextDeletingWordMissing:           http.StatusInternalServerError,
+	ContextSearchingWordsMissing:         http.StatusInternalServerError,
+	QueryParamInvalid:                    http.StatusBadRequest,
+	WordSetNotFound:                      http.StatusNotFound,
+	WordSetItemNotFound:                  http.StatusNotFound,
+	WordSetReorderMismatch:               http.StatusUnprocessableEntity,
+	ContextCreatingWordSetMissing:        http.StatusInternalServerError,
+	ContextGettingWordSetMissing:         http.StatusInternalServerError,
+	ContextUpdatingWordSetMissing:        http.StatusInternalServerError,
+	ContextDeletingWordSetMissing:        http.StatusInternalServerError,
+	ContextUpdatingWordSetItemsMissing:   http.StatusInternalServerError,
+	UserWordSetNotFound:                  http.StatusNotFound,
+	GameWordSetEmpty:                     http.StatusUnprocessableEntity,
+	GameRoundNotFound:                    http.StatusNotFound,
+	GameRoundClosed:                      http.StatusConflict,
+	GameRoundFinished:                    http.StatusConflict,
+	GameQuestionNotFound:                 http.StatusNotFound,
+	GameQuestionAlreadyAnswered:          http.StatusConflict,
+	GameFlashcardVerdictMissing:          http.StatusBadRequest,
+	ContextPlayingGameMissing:            http.StatusInternalServerError,
+	ContextListingDueReviewsMissing:      http.StatusInternalServerError,
+	Unauthorized:                         http.StatusUnauthorized,
+	Forbidden:                            http.StatusForbidden,
+	EmailAlreadyRegistered:               http.StatusConflict,
+	InvalidCredentials:                   http.StatusUnauthorized,
+	RefreshTokenInvalid:                  http.StatusUnauthorized,
+	ContextAuthenticatingMissing:         http.StatusInternalServerError,
+	Conflict:                             http.StatusConflict,
+	ReferenceMissing:                     http.StatusUnprocessableEntity,
+	WordNotFound:                         http.StatusNotFound,
+	UserProgressNotFound:                 http.StatusNotFound,
+	UserSessionNotFound:                  http.StatusNotFound,
+	ContextCreatingUserSessionMissing:    http.StatusInternalServerError,
+	ContextGettingUserSessionMissing:     http.StatusInternalServerError,
+	ContextUpdatingUserSessionMissing:    http.StatusInternalServerError,
+	ContextDeletingUserSessionMissing:    http.StatusInternalServerError,
+	ContextCreatingUserProgressMissing:   http.StatusInternalServerError,
+	ContextGettingUserProgressMissing:    http.StatusInternalServerError,
+	ContextUpdatingUserProgressMissing:   http.StatusInternalServerError,
+	ContextDeletingUserProgressMissing:   http.StatusInternalServerError,
+	ContextCreatingUserWordSetMissing:    http.StatusInternalServerError,
+	ContextGettingUserWordSetMissing:     http.StatusInternalServerError,
+	ContextUpdatingUserWordSetMissing:    http.StatusInternalServerError,
+	ContextDeletingUserWordSetMissing:    http.StatusInternalServerError,
+	UserSessionAlreadyActive:             http.StatusConflict,
+	UserSessionTransitionInvalid:         http.StatusConflict,
+	UserSessionNotActive:                 http.StatusConflict,
+	CursorInvalid:                        http.StatusBadRequest,
+	ImportJobNotFound:                    http.StatusNotFound,
+	ImportJobNotRetryable:                http.StatusConflict,
+	ImportFileInvalid:                    http.StatusBadRequest,
+	ImportFileTooLarge:                   http.StatusRequestEntityTooLarge,
+	ImportRowInvalid:                     http.StatusUnprocessableEntity,
+	ContextImportingWordsMissing:         http.StatusInternalServerError,
+	ContextImportingProgressMissing:      http.StatusInternalServerError,
+}
+
+func init() {
+	fault.RegisterStatuses(statuses)
+}
@@ -0,0 +1,48 @@
+package errors_pkg
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"testing"
+
+	"test-http/pkg/fault"
+)
+
+// TestStatuses_CoverEveryCode guards against adding a code to errors_pkg.go
+// without giving it a status, which would silently turn it into a 500.
+func TestStatuses_CoverEveryCode(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "errors_pkg.go", nil, 0)
+	if err != nil {
+		t.Fatalf("parse errors_pkg.go: %v", err)
+	}
+
+	var seen int
+	ast.Inspect(file, func(n ast.Node) bool {
+		spec, ok := n.(*ast.ValueSpec)
+		if !ok || len(spec.Values) != 1 {
+			return true
+		}
+		lit, ok := spec.Values[0].(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return true
+		}
+		code, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			t.Fatalf("unquote %s: %v", lit.Value, err)
+		}
+		seen++
+		if _, ok := fault.LookupStatus(fault.Code(code)); !ok {
+			t.Errorf("%s (%s) has no registered HTTP status", spec.Names[0].Name, code)
+		}
+		return true
+	})
+
+	if seen == 0 {
+		t.Fatal("no codes found in errors_pkg.go")
+	}
+	if seen != len(statuses) {
+		t.Errorf("errors_pkg.go declares %d codes, statuses maps %d", seen, len(statuses))
+	}
+}
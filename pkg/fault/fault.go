@@ -17,8 +17,22 @@ type Arg struct {
 
 // Fault — структура бизнес-ошибки.
 type Fault struct {
-	Code string            `json:"code"`           // уникальный код ошибки
-	Args map[string]string `json:"args,omitempty"` // дополнительные аргументы
+	Code   string            `json:"code"`             // уникальный код ошибки
+	Args   map[string]string `json:"args,omitempty"`   // дополнительные аргументы
+	Fields []FieldViolation  `json:"fields,omitempty"` // ошибки валидации отдельных полей
+}
+
+// FieldViolation — нарушение правила валидации в конкретном поле запроса.
+type FieldViolation struct {
+	Field string `json:"field"`           // имя поля в теле запроса
+	Tag   string `json:"tag"`             // сработавшее правило (required, email, max, ...)
+	Param string `json:"param,omitempty"` // параметр правила, например 72 для max=72
+}
+
+// WithFields добавляет к ошибке нарушения валидации полей.
+func (f *Fault) WithFields(fields ...FieldViolation) *Fault {
+	f.Fields = append(f.Fields, fields...)
+	return f
 }
 
 // Error возвращает строковое представление ошибки (код).
@@ -73,30 +87,40 @@ const (
 	UnhandledError Code = "UNHANDLED_ERROR"
 )
 
-func HTTPError(w http.ResponseWriter, err error) error {
+// HTTPError пишет ошибку в ответ со статусом, зарегистрированным для её кода.
+// Ошибки, не являющиеся Fault, отдаются как UnhandledError. Если клиент
+// предпочитает application/problem+json, ответ формируется по RFC 7807.
+func HTTPError(w http.ResponseWriter, r *http.Request, err error) error {
 	var f *Fault
 
-	if errors.As(err, &f) {
-		writeFaultResponse(w, f)
-		return nil
+	if !errors.As(err, &f) {
+		// Use a generic unhandled fault as a fallback.
+		f = UnhandledError.Err()
 	}
 
-	// Use a generic unhandled fault as a fallback.
-	fallbackFault := UnhandledError.Err()
-	writeFaultResponse(w, fallbackFault)
+	if r != nil && prefersProblem(r.Header.Get("Accept")) {
+		writeProblemResponse(w, f)
+		return nil
+	}
+	writeFaultResponse(w, f)
 	return nil
 }
 
 func writeFaultResponse(w http.ResponseWriter, f *Fault) {
+	body := map[string]interface{}{
+		"code": f.Code,
+		"args": f.Args,
+	}
+	if len(f.Fields) > 0 {
+		body["fields"] = f.Fields
+	}
 
 	response := map[string]interface{}{
-		"error": map[string]interface{}{
-			"code": f.Code,
-			"args": f.Args,
-		},
+		"error": body,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(Status(Code(f.Code)))
 
 	_ = json.NewEncoder(w).Encode(response)
 }
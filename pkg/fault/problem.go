@@ -0,0 +1,99 @@
+package fault
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	// ProblemContentType — медиатип ответа по RFC 7807.
+	ProblemContentType = "application/problem+json"
+
+	// TraceHeader — заголовок с идентификатором трассировки запроса,
+	// его значение попадает в поле instance.
+	TraceHeader = "X-Trace-Id"
+
+	problemTypePrefix = "urn:problem-type:"
+)
+
+// Problem — тело ответа application/problem+json. Помимо стандартных полей
+// содержит код и аргументы Fault, чтобы клиенты не теряли машиночитаемую ошибку.
+type Problem struct {
+	Type     string            `json:"type"`
+	Title    string            `json:"title"`
+	Status   int               `json:"status"`
+	Detail   string            `json:"detail,omitempty"`
+	Instance string            `json:"instance,omitempty"`
+	Code     string            `json:"code"`
+	Args     map[string]string `json:"args,omitempty"`
+	Errors   []FieldViolation  `json:"errors,omitempty"`
+}
+
+// NewProblem строит Problem для ошибки; instance — идентификатор трассировки.
+func NewProblem(f *Fault, instance string) Problem {
+	status := Status(Code(f.Code))
+	return Problem{
+		Type:     problemTypePrefix + strings.ToLower(strings.ReplaceAll(f.Code, "_", "-")),
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   problemDetail(f),
+		Instance: instance,
+		Code:     f.Code,
+		Args:     f.Args,
+		Errors:   f.Fields,
+	}
+}
+
+func writeProblemResponse(w http.ResponseWriter, f *Fault) {
+	problem := NewProblem(f, w.Header().Get(TraceHeader))
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(problem.Status)
+
+	_ = json.NewEncoder(w).Encode(problem)
+}
+
+// problemDetail делает из кода читаемое описание: VALIDATION_ERROR -> "Validation error".
+func problemDetail(f *Fault) string {
+	detail := strings.ToLower(strings.ReplaceAll(f.Code, "_", " "))
+	if detail == "" {
+		return ""
+	}
+	detail = strings.ToUpper(detail[:1]) + detail[1:]
+	if n := len(f.Fields); n > 0 {
+		detail += ": " + strconv.Itoa(n) + " invalid field"
+		if n > 1 {
+			detail += "s"
+		}
+	}
+	return detail
+}
+
+// prefersProblem сообщает, выбирает ли заголовок Accept problem+json: его
+// q должен быть не ниже, чем у application/json. Маски (*/*) не учитываются,
+// чтобы клиенты без явного выбора получали привычный формат.
+func prefersProblem(accept string) bool {
+	var problemQ, jsonQ float64
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if raw, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(raw, 64); err == nil {
+				q = parsed
+			}
+		}
+		switch mediaType {
+		case ProblemContentType:
+			problemQ = max(problemQ, q)
+		case "application/json":
+			jsonQ = max(jsonQ, q)
+		}
+	}
+	return problemQ > 0 && problemQ >= jsonQ
+}
@@ -0,0 +1,84 @@
+package fault
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPrefersProblem(t *testing.T) {
+	tests := map[string]bool{
+		"":                         false,
+		"*/*":                      false,
+		"application/json":         false,
+		"application/problem+json": true,
+		"application/json, application/problem+json":           true,
+		"application/json;q=0.9, application/problem+json":     true,
+		"application/json, application/problem+json;q=0.5":     false,
+		"application/problem+json;q=0, application/json;q=0.1": false,
+		"text/html, application/problem+json;q=0.8, */*;q=0.1": true,
+	}
+	for accept, want := range tests {
+		if got := prefersProblem(accept); got != want {
+			t.Errorf("prefersProblem(%q) = %v, want %v", accept, got, want)
+		}
+	}
+}
+
+func TestHTTPError_ProblemJSON(t *testing.T) {
+	const code Code = "TEST_PROBLEM_INVALID"
+	RegisterStatus(code, http.StatusBadRequest)
+
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	r.Header.Set("Accept", ProblemContentType)
+	rec := httptest.NewRecorder()
+	rec.Header().Set(TraceHeader, "trace-123")
+
+	f := code.Err(&Arg{K: "param", V: "limit"}).WithFields(FieldViolation{Field: "email", Tag: "email"})
+	_ = HTTPError(rec, r, f)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != ProblemContentType {
+		t.Fatalf("unexpected content type %q", ct)
+	}
+
+	var got Problem
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.Type != "urn:problem-type:test-problem-invalid" || got.Title != "Bad Request" || got.Status != http.StatusBadRequest {
+		t.Fatalf("unexpected problem: %+v", got)
+	}
+	if got.Instance != "trace-123" || got.Code != string(code) || got.Args["param"] != "limit" {
+		t.Fatalf("unexpected problem: %+v", got)
+	}
+	if got.Detail != "Test problem invalid: 1 invalid field" {
+		t.Fatalf("unexpected detail %q", got.Detail)
+	}
+	if len(got.Errors) != 1 || got.Errors[0] != (FieldViolation{Field: "email", Tag: "email"}) {
+		t.Fatalf("unexpected errors: %+v", got.Errors)
+	}
+}
+
+func TestHTTPError_LegacyShapeByDefault(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	rec := httptest.NewRecorder()
+
+	_ = HTTPError(rec, r, Code("TEST_LEGACY").Err().WithFields(FieldViolation{Field: "title", Tag: "required"}))
+
+	var got struct {
+		Error struct {
+			Code   string           `json:"code"`
+			Fields []FieldViolation `json:"fields"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.Error.Code != "TEST_LEGACY" || len(got.Error.Fields) != 1 {
+		t.Fatalf("unexpected body: %+v", got)
+	}
+}
@@ -0,0 +1,48 @@
+package fault
+
+import (
+	"net/http"
+	"sync"
+)
+
+// statuses — реестр соответствия кодов ошибок HTTP-статусам.
+var (
+	statusesMu sync.RWMutex
+	statuses   = map[Code]int{
+		UnhandledError: http.StatusInternalServerError,
+	}
+)
+
+// RegisterStatus связывает код ошибки с HTTP-статусом.
+// Повторная регистрация перезаписывает предыдущее значение.
+func RegisterStatus(code Code, status int) {
+	if status < 400 || status > 599 {
+		panic("fault: status for " + string(code) + " must be a 4xx or 5xx code")
+	}
+	statusesMu.Lock()
+	defer statusesMu.Unlock()
+	statuses[code] = status
+}
+
+// RegisterStatuses регистрирует несколько кодов за раз.
+func RegisterStatuses(m map[Code]int) {
+	for code, status := range m {
+		RegisterStatus(code, status)
+	}
+}
+
+// LookupStatus возвращает HTTP-статус кода и признак того, что код зарегистрирован.
+func LookupStatus(code Code) (int, bool) {
+	statusesMu.RLock()
+	defer statusesMu.RUnlock()
+	status, ok := statuses[code]
+	return status, ok
+}
+
+// Status возвращает HTTP-статус кода; для незарегистрированных кодов — 500.
+func Status(code Code) int {
+	if status, ok := LookupStatus(code); ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,49 @@
+package fault
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPError_WritesRegisteredStatus(t *testing.T) {
+	const code Code = "TEST_STATUS_NOT_FOUND"
+	RegisterStatus(code, http.StatusNotFound)
+
+	rec := httptest.NewRecorder()
+	if err := HTTPError(rec, httptest.NewRequest(http.MethodGet, "/", nil), code.Err()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("unexpected content type %q", ct)
+	}
+}
+
+func TestHTTPError_UnknownCodeIs500(t *testing.T) {
+	rec := httptest.NewRecorder()
+	_ = HTTPError(rec, httptest.NewRequest(http.MethodGet, "/", nil), Code("TEST_STATUS_UNREGISTERED").Err())
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", rec.Code)
+	}
+}
+
+func TestHTTPError_PlainErrorIsUnhandled(t *testing.T) {
+	rec := httptest.NewRecorder()
+	_ = HTTPError(rec, httptest.NewRequest(http.MethodGet, "/", nil), errors.New("boom"))
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", rec.Code)
+	}
+}
+
+func TestRegisterStatus_RejectsSuccessStatus(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic for a 2xx status")
+		}
+	}()
+	RegisterStatus("TEST_STATUS_OK", http.StatusOK)
+}
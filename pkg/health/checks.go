@@ -0,0 +1,67 @@
+package health
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// Ping fails when the database does not answer a ping.
+func Ping(p Pinger) Checker {
+	return CheckerFunc(func(ctx context.Context) (map[string]any, error) {
+		return nil, p.Ping(ctx)
+	})
+}
+
+type PoolStater interface {
+	Stat() *pgxpool.Stat
+}
+
+// PoolSaturation fails when the share of connections in use reaches threshold, so a
+// replica whose requests already queue for a connection stops taking new ones.
+func PoolSaturation(p PoolStater, threshold float64) Checker {
+	return CheckerFunc(func(ctx context.Context) (map[string]any, error) {
+		stat := p.Stat()
+		details := map[string]any{
+			"acquired":               stat.AcquiredConns(),
+			"idle":                   stat.IdleConns(),
+			"total":                  stat.TotalConns(),
+			"max":                    stat.MaxConns(),
+			"empty_acquire_count":    stat.EmptyAcquireCount(),
+			"canceled_acquire_count": stat.CanceledAcquireCount(),
+		}
+		return details, saturation(stat.AcquiredConns(), stat.MaxConns(), threshold)
+	})
+}
+
+func saturation(acquired, max int32, threshold float64) error {
+	if max > 0 && float64(acquired) >= threshold*float64(max) {
+		return fmt.Errorf("%d of %d connections in use", acquired, max)
+	}
+	return nil
+}
+
+type Versioner interface {
+	Versions(ctx context.Context) (current, latest int64, err error)
+}
+
+// Schema fails while the database lacks migrations this binary was built with. A newer
+// schema passes, so the previous version keeps serving while a rollout migrates ahead of it.
+func Schema(v Versioner) Checker {
+	return CheckerFunc(func(ctx context.Context) (map[string]any, error) {
+		current, latest, err := v.Versions(ctx)
+		if err != nil {
+			return nil, err
+		}
+		details := map[string]any{"version": current, "latest": latest}
+		if current < latest {
+			return details, fmt.Errorf("schema version %d is behind %d", current, latest)
+		}
+		return details, nil
+	})
+}
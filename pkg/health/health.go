@@ -0,0 +1,173 @@
+// Package health runs the dependency checks behind the liveness, readiness and
+// startup probes. Checks are registered once with the probes they belong to and a
+// timeout of their own, and every probe request runs its checks concurrently.
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Probe names the question a check helps answer, after the endpoint that asks it.
+type Probe string
+
+const (
+	Liveness  Probe = "livez"
+	Readiness Probe = "readyz"
+	Startup   Probe = "startupz"
+)
+
+type Status string
+
+const (
+	StatusOK   Status = "ok"
+	StatusFail Status = "fail"
+)
+
+// Checker reports whether one dependency is usable. The details it returns are shown
+// only in a verbose report, whether the check passed or not.
+type Checker interface {
+	Check(ctx context.Context) (details map[string]any, err error)
+}
+
+type CheckerFunc func(ctx context.Context) (map[string]any, error)
+
+func (f CheckerFunc) Check(ctx context.Context) (map[string]any, error) {
+	return f(ctx)
+}
+
+// ErrTimeout is reported for a check that did not return within its timeout.
+var ErrTimeout = errors.New("check timed out")
+
+type check struct {
+	name    string
+	timeout time.Duration
+	checker Checker
+	probes  []Probe
+}
+
+// Registry holds the checks of every probe. It is safe to register checks while
+// probes are being served.
+type Registry struct {
+	mu     sync.RWMutex
+	checks []check
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds a check to the given probes. Checks appear in reports in the order
+// they were registered; registering a name twice panics.
+func (r *Registry) Register(name string, timeout time.Duration, c Checker, probes ...Probe) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.checks {
+		if existing.name == name {
+			panic(fmt.Sprintf("health: check %q registered twice", name))
+		}
+	}
+	r.checks = append(r.checks, check{name: name, timeout: timeout, checker: c, probes: probes})
+}
+
+// CheckResult is the outcome of one check. Error and Details are left out of a brief report.
+type CheckResult struct {
+	Name      string         `json:"name"`
+	Status    Status         `json:"status"`
+	LatencyMS float64        `json:"latency_ms"`
+	Error     string         `json:"error,omitempty"`
+	Details   map[string]any `json:"details,omitempty"`
+}
+
+// Report is the outcome of a probe: it fails when any of its checks fails.
+type Report struct {
+	Status Status        `json:"status"`
+	Checks []CheckResult `json:"checks"`
+}
+
+// Brief drops the error messages and details, which may describe the infrastructure
+// to whoever can reach the probe.
+func (r Report) Brief() Report {
+	checks := make([]CheckResult, len(r.Checks))
+	for i, c := range r.Checks {
+		checks[i] = CheckResult{Name: c.Name, Status: c.Status, LatencyMS: c.LatencyMS}
+	}
+	return Report{Status: r.Status, Checks: checks}
+}
+
+// Run runs the checks of a probe concurrently and waits for all of them.
+func (r *Registry) Run(ctx context.Context, probe Probe) Report {
+	r.mu.RLock()
+	var checks []check
+	for _, c := range r.checks {
+		for _, p := range c.probes {
+			if p == probe {
+				checks = append(checks, c)
+				break
+			}
+		}
+	}
+	r.mu.RUnlock()
+
+	report := Report{Status: StatusOK, Checks: make([]CheckResult, len(checks))}
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Checks[i] = c.run(ctx)
+		}()
+	}
+	wg.Wait()
+
+	for _, c := range report.Checks {
+		if c.Status != StatusOK {
+			report.Status = StatusFail
+		}
+	}
+	return report
+}
+
+type outcome struct {
+	details map[string]any
+	err     error
+}
+
+// run gives up on a check at its timeout even if the checker ignores the context;
+// the checker's goroutine then finishes on its own.
+func (c check) run(ctx context.Context) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan outcome, 1)
+	go func() {
+		details, err := c.checker.Check(ctx)
+		done <- outcome{details: details, err: err}
+	}()
+
+	var o outcome
+	select {
+	case o = <-done:
+	case <-ctx.Done():
+		o.err = ErrTimeout
+	}
+	if errors.Is(o.err, context.DeadlineExceeded) {
+		o.err = ErrTimeout
+	}
+
+	result := CheckResult{
+		Name:      c.name,
+		Status:    StatusOK,
+		LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+		Details:   o.details,
+	}
+	if o.err != nil {
+		result.Status = StatusFail
+		result.Error = o.err.Error()
+	}
+	return result
+}
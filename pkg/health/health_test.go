@@ -0,0 +1,107 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func checkerReturning(details map[string]any, err error) Checker {
+	return CheckerFunc(func(context.Context) (map[string]any, error) { return details, err })
+}
+
+func TestRegistry_RunsOnlyTheProbesChecks(t *testing.T) {
+	r := NewRegistry()
+	r.Register("database", time.Second, checkerReturning(nil, nil), Readiness, Startup)
+	r.Register("pool", time.Second, checkerReturning(nil, nil), Readiness)
+
+	if report := r.Run(context.Background(), Liveness); report.Status != StatusOK || len(report.Checks) != 0 {
+		t.Fatalf("livez = %+v", report)
+	}
+	report := r.Run(context.Background(), Readiness)
+	if report.Status != StatusOK || len(report.Checks) != 2 || report.Checks[0].Name != "database" || report.Checks[1].Name != "pool" {
+		t.Fatalf("readyz = %+v", report)
+	}
+	if report := r.Run(context.Background(), Startup); len(report.Checks) != 1 || report.Checks[0].Name != "database" {
+		t.Fatalf("startupz = %+v", report)
+	}
+}
+
+func TestRegistry_OneFailingCheckFailsTheProbe(t *testing.T) {
+	r := NewRegistry()
+	r.Register("database", time.Second, checkerReturning(nil, nil), Readiness)
+	r.Register("schema", time.Second, checkerReturning(map[string]any{"version": 3}, errors.New("behind")), Readiness)
+
+	report := r.Run(context.Background(), Readiness)
+	if report.Status != StatusFail || report.Checks[0].Status != StatusOK || report.Checks[1].Status != StatusFail {
+		t.Fatalf("report = %+v", report)
+	}
+	if report.Checks[1].Error != "behind" || report.Checks[1].Details["version"] != 3 {
+		t.Fatalf("schema = %+v", report.Checks[1])
+	}
+
+	brief := report.Brief()
+	if brief.Status != StatusFail || brief.Checks[1].Error != "" || brief.Checks[1].Details != nil {
+		t.Fatalf("brief = %+v", brief)
+	}
+}
+
+func TestRegistry_CheckTimesOutEvenIfItIgnoresTheContext(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	r := NewRegistry()
+	r.Register("stuck", 10*time.Millisecond, CheckerFunc(func(context.Context) (map[string]any, error) {
+		<-release
+		return nil, nil
+	}), Readiness)
+
+	report := r.Run(context.Background(), Readiness)
+	if report.Status != StatusFail || report.Checks[0].Error != ErrTimeout.Error() {
+		t.Fatalf("report = %+v", report)
+	}
+}
+
+func TestRegistry_RegisterTwicePanics(t *testing.T) {
+	r := NewRegistry()
+	r.Register("database", time.Second, checkerReturning(nil, nil), Readiness)
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic")
+		}
+	}()
+	r.Register("database", time.Second, checkerReturning(nil, nil), Startup)
+}
+
+type versions struct{ current, latest int64 }
+
+func (v versions) Versions(context.Context) (int64, int64, error) { return v.current, v.latest, nil }
+
+func TestSchema(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		v       versions
+		wantErr bool
+	}{
+		{"current", versions{19, 19}, false},
+		{"behind", versions{18, 19}, true},
+		{"ahead", versions{20, 19}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			details, err := Schema(tc.v).Check(context.Background())
+			if (err != nil) != tc.wantErr || details["version"] != tc.v.current || details["latest"] != tc.v.latest {
+				t.Fatalf("Check = %v, %v", details, err)
+			}
+		})
+	}
+}
+
+func TestSaturation(t *testing.T) {
+	if err := saturation(14, 16, 0.9); err != nil {
+		t.Fatalf("14 of 16: %v", err)
+	}
+	if err := saturation(15, 16, 0.9); err == nil {
+		t.Fatal("15 of 16 should be saturated")
+	}
+}
@@ -0,0 +1,75 @@
+package jwt
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestSigner(now time.Time) *Signer {
+	s := NewSigner([]byte("0123456789abcdef0123456789abcdef"), "test-http", 15*time.Minute)
+	s.now = func() time.Time { return now }
+	return s
+}
+
+func TestSigner_IssueVerify(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	s := newTestSigner(now)
+
+	token, issued, err := s.Issue("user-1", "user")
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	if issued.ExpiresAt != now.Add(15*time.Minute).Unix() {
+		t.Fatalf("exp = %d, want %d", issued.ExpiresAt, now.Add(15*time.Minute).Unix())
+	}
+
+	claims, err := s.Verify(token)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if claims != issued {
+		t.Fatalf("claims = %+v, want %+v", claims, issued)
+	}
+}
+
+func TestSigner_VerifyRejects(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	s := newTestSigner(now)
+	token, _, err := s.Issue("user-1", "user")
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	parts := strings.Split(token, ".")
+
+	other := NewSigner([]byte("another-secret-another-secret-xx"), "test-http", time.Minute)
+	other.now = s.now
+	foreign, _, _ := other.Issue("user-1", "user")
+
+	expired := newTestSigner(now.Add(16 * time.Minute))
+
+	wrongIssuer := NewSigner(s.secret, "someone-else", time.Minute)
+	wrongIssuer.now = s.now
+
+	tests := []struct {
+		name   string
+		signer *Signer
+		token  string
+		want   error
+	}{
+		{"garbage", s, "not-a-token", ErrMalformed},
+		{"alg none", s, encoding.EncodeToString([]byte(`{"alg":"none"}`)) + "." + parts[1] + ".", ErrMalformed},
+		{"tampered payload", s, parts[0] + "." + encoding.EncodeToString([]byte(`{"sub":"admin","exp":9999999999}`)) + "." + parts[2], ErrSignature},
+		{"foreign secret", s, foreign, ErrSignature},
+		{"expired", expired, token, ErrExpired},
+		{"wrong issuer", wrongIssuer, token, ErrIssuer},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.signer.Verify(tt.token); !errors.Is(err, tt.want) {
+				t.Fatalf("Verify error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package wordimport
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrReviewHeader = errors.New("wordimport: header must name the word, timestamp and result columns")
+	ErrTimestamp    = errors.New("wordimport: unrecognised timestamp")
+	ErrResult       = errors.New("wordimport: unrecognised result")
+)
+
+// ReviewRow is one attempt from a review log as written in the source.
+type ReviewRow struct {
+	Line      int
+	Word      string
+	Timestamp string
+	Result    string
+}
+
+// SM-2 grades given to the results a review log may record.
+const (
+	gradeAgain = 1
+	gradeHard  = 3
+	gradeGood  = 4
+	gradeEasy  = 5
+)
+
+// timestampLayouts are tried in order; layouts without a zone are read as UTC.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+}
+
+// ParseReviews reads a review log exported by another vocabulary app: a CSV or
+// TSV file whose header names a word (or lemma) column, a timestamp column and
+// a result column. The delimiter is taken from the header line.
+func ParseReviews(data []byte) ([]ReviewRow, error) {
+	data = bytes.TrimPrefix(data, utf8BOM)
+	comma := ','
+	if first, _, _ := bytes.Cut(data, []byte("\n")); bytes.Count(first, []byte("\t")) > bytes.Count(first, []byte(",")) {
+		comma = '\t'
+	}
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comma = comma
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+
+	header, err := r.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, ErrEmpty
+		}
+		return nil, err
+	}
+	cols := map[string]int{}
+	for i, name := range header {
+		cols[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := cols["word"]; !ok {
+		if i, ok := cols["lemma"]; ok {
+			cols["word"] = i
+		}
+	}
+	for _, name := range []string{"word", "timestamp", "result"} {
+		if _, ok := cols[name]; !ok {
+			return nil, ErrReviewHeader
+		}
+	}
+	field := func(record []string, name string) string {
+		if i := cols[name]; i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var rows []ReviewRow
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := r.FieldPos(0)
+		if blank(record) {
+			continue
+		}
+		rows = append(rows, ReviewRow{
+			Line:      line,
+			Word:      field(record, "word"),
+			Timestamp: field(record, "timestamp"),
+			Result:    field(record, "result"),
+		})
+	}
+	if len(rows) == 0 {
+		return nil, ErrEmpty
+	}
+	return rows, nil
+}
+
+// Time parses the timestamp as RFC 3339, a zoneless "YYYY-MM-DD hh:mm[:ss]" in
+// UTC, or Unix seconds; values too large to be seconds are read as milliseconds.
+func (r ReviewRow) Time() (time.Time, error) {
+	if n, err := strconv.ParseInt(r.Timestamp, 10, 64); err == nil {
+		if n > 1e11 || n < -1e11 {
+			return time.UnixMilli(n).UTC(), nil
+		}
+		return time.Unix(n, 0).UTC(), nil
+	}
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, r.Timestamp); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, ErrTimestamp
+}
+
+// Grade maps the result to whether the answer was correct and its SM-2 grade.
+// Anki-style again/hard/good/easy keep their nuance; pass/fail results grade
+// like an answer given in this app.
+func (r ReviewRow) Grade() (bool, int16, error) {
+	switch strings.ToLower(r.Result) {
+	case "again", "wrong", "incorrect", "false", "fail", "no", "0":
+		return false, gradeAgain, nil
+	case "hard":
+		return true, gradeHard, nil
+	case "good", "correct", "right", "true", "pass", "yes", "1":
+		return true, gradeGood, nil
+	case "easy":
+		return true, gradeEasy, nil
+	}
+	return false, 0, ErrResult
+}
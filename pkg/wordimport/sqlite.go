@@ -0,0 +1,288 @@
+package wordimport
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// sqliteFile is a read-only view of an SQLite 3 database image, just enough to walk
+// the rows of a table. Anki packages ship their collection in this format. Uploads are
+// untrusted, so every size and page number read from the file is checked against the
+// image before use and anything out of range is reported as errSQLiteCorrupt.
+type sqliteFile struct {
+	data     []byte
+	pageSize int
+	pages    int
+	usable   int
+}
+
+var errSQLiteCorrupt = errors.New("corrupt sqlite database")
+
+const sqliteMagic = "SQLite format 3\x00"
+
+func openSQLite(data []byte) (*sqliteFile, error) {
+	if len(data) < 100 || string(data[:16]) != sqliteMagic {
+		return nil, errors.New("not an sqlite database")
+	}
+	pageSize := int(binary.BigEndian.Uint16(data[16:18]))
+	if pageSize == 1 {
+		pageSize = 65536
+	}
+	if pageSize < 512 || pageSize&(pageSize-1) != 0 {
+		return nil, errSQLiteCorrupt
+	}
+	// SQLite itself refuses files with less than 480 usable bytes per page.
+	usable := pageSize - int(data[20])
+	if usable < 480 {
+		return nil, errSQLiteCorrupt
+	}
+	return &sqliteFile{data: data, pageSize: pageSize, pages: len(data) / pageSize, usable: usable}, nil
+}
+
+// table calls fn with the column values of every row of the named table, in rowid order.
+// Values are nil, int64, float64, string or []byte.
+func (f *sqliteFile) table(name string, fn func(values []any) error) error {
+	root := 0
+	err := f.walk(1, func(values []any) error {
+		// sqlite_master: type, name, tbl_name, rootpage, sql
+		if len(values) >= 4 && values[0] == "table" && strings.EqualFold(fmt.Sprint(values[1]), name) {
+			if page, ok := values[3].(int64); ok {
+				root = int(page)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if root == 0 {
+		return fmt.Errorf("table %s not found", name)
+	}
+	return f.walk(root, fn)
+}
+
+func (f *sqliteFile) page(n int) ([]byte, int, error) {
+	if n < 1 || n > f.pages {
+		return nil, 0, errSQLiteCorrupt
+	}
+	start := (n - 1) * f.pageSize
+	header := 0
+	if n == 1 {
+		header = 100 // the database header precedes the b-tree page header
+	}
+	return f.data[start : start+f.pageSize], header, nil
+}
+
+// walk visits the leaf cells of the table b-tree rooted at page root, left to right.
+// A page reached twice means the tree loops back on itself.
+func (f *sqliteFile) walk(root int, fn func(values []any) error) error {
+	return f.walkDepth(root, fn, 0, make(map[int]bool))
+}
+
+func (f *sqliteFile) walkDepth(n int, fn func(values []any) error, depth int, seen map[int]bool) error {
+	if depth > 64 || seen[n] {
+		return errSQLiteCorrupt
+	}
+	seen[n] = true
+	p, h, err := f.page(n)
+	if err != nil {
+		return err
+	}
+	kind := p[h]
+	cells := int(binary.BigEndian.Uint16(p[h+3 : h+5]))
+
+	switch kind {
+	case 0x05: // interior table page: cells point left, the header points right
+		ptrs := h + 12
+		for i := 0; i < cells; i++ {
+			off, err := cellOffset(p, ptrs, i)
+			if err != nil {
+				return err
+			}
+			child := int(binary.BigEndian.Uint32(p[off : off+4]))
+			if err := f.walkDepth(child, fn, depth+1, seen); err != nil {
+				return err
+			}
+		}
+		return f.walkDepth(int(binary.BigEndian.Uint32(p[h+8:h+12])), fn, depth+1, seen)
+	case 0x0d: // leaf table page
+		ptrs := h + 8
+		for i := 0; i < cells; i++ {
+			off, err := cellOffset(p, ptrs, i)
+			if err != nil {
+				return err
+			}
+			payload, err := f.leafPayload(p, off)
+			if err != nil {
+				return err
+			}
+			values, err := decodeRecord(payload)
+			if err != nil {
+				return err
+			}
+			if err := fn(values); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return errSQLiteCorrupt
+	}
+}
+
+func cellOffset(p []byte, ptrs, i int) (int, error) {
+	at := ptrs + 2*i
+	if at+2 > len(p) {
+		return 0, errSQLiteCorrupt
+	}
+	off := int(binary.BigEndian.Uint16(p[at : at+2]))
+	if off+4 > len(p) {
+		return 0, errSQLiteCorrupt
+	}
+	return off, nil
+}
+
+// leafPayload returns the record stored in a table leaf cell, following overflow pages.
+func (f *sqliteFile) leafPayload(p []byte, off int) ([]byte, error) {
+	size, n := uvarint(p[off:])
+	if n == 0 {
+		return nil, errSQLiteCorrupt
+	}
+	off += n
+	if _, n = uvarint(p[off:]); n == 0 { // rowid
+		return nil, errSQLiteCorrupt
+	}
+	off += n
+
+	// A record can never be larger than the whole image.
+	if size > uint64(len(f.data)) {
+		return nil, errSQLiteCorrupt
+	}
+	total := int(size)
+	u := f.usable
+	maxLocal := u - 35
+	local := total
+	if total > maxLocal {
+		minLocal := (u-12)*32/255 - 23
+		local = minLocal + (total-minLocal)%(u-4)
+		if local > maxLocal {
+			local = minLocal
+		}
+	}
+	if off+local > len(p) {
+		return nil, errSQLiteCorrupt
+	}
+	payload := make([]byte, 0, total)
+	payload = append(payload, p[off:off+local]...)
+	if local == total {
+		return payload, nil
+	}
+
+	if off+local+4 > len(p) {
+		return nil, errSQLiteCorrupt
+	}
+	next := int(binary.BigEndian.Uint32(p[off+local : off+local+4]))
+	// A chain never revisits a page, so it is at most as long as the file.
+	seen := make(map[int]bool)
+	for len(payload) < total {
+		if seen[next] {
+			return nil, errSQLiteCorrupt
+		}
+		seen[next] = true
+		op, _, err := f.page(next)
+		if err != nil {
+			return nil, err
+		}
+		chunk := min(total-len(payload), u-4)
+		payload = append(payload, op[4:4+chunk]...)
+		next = int(binary.BigEndian.Uint32(op[:4]))
+	}
+	return payload, nil
+}
+
+func decodeRecord(rec []byte) ([]any, error) {
+	headerSize, n := uvarint(rec)
+	if n == 0 || headerSize > uint64(len(rec)) {
+		return nil, errSQLiteCorrupt
+	}
+	var types []uint64
+	for pos := n; pos < int(headerSize); {
+		t, m := uvarint(rec[pos:headerSize])
+		if m == 0 {
+			return nil, errSQLiteCorrupt
+		}
+		types = append(types, t)
+		pos += m
+	}
+
+	body := rec[headerSize:]
+	values := make([]any, len(types))
+	for i, t := range types {
+		var size uint64
+		switch {
+		case t == 0, t == 8, t == 9:
+			size = 0
+		case t >= 1 && t <= 4:
+			size = t
+		case t == 5:
+			size = 6
+		case t == 6, t == 7:
+			size = 8
+		case t >= 12:
+			size = (t - 12) / 2
+		default:
+			return nil, errSQLiteCorrupt
+		}
+		if size > uint64(len(body)) {
+			return nil, errSQLiteCorrupt
+		}
+		raw := body[:size]
+		body = body[size:]
+
+		switch {
+		case t == 0:
+			values[i] = nil
+		case t == 8:
+			values[i] = int64(0)
+		case t == 9:
+			values[i] = int64(1)
+		case t == 7:
+			values[i] = math.Float64frombits(binary.BigEndian.Uint64(raw))
+		case t <= 6:
+			var v int64
+			for _, b := range raw {
+				v = v<<8 | int64(b)
+			}
+			// sign-extend from the stored width
+			shift := uint(64 - 8*size)
+			values[i] = v << shift >> shift
+		case t%2 == 0:
+			values[i] = append([]byte(nil), raw...)
+		default:
+			values[i] = string(raw)
+		}
+	}
+	return values, nil
+}
+
+// uvarint decodes an SQLite varint: big-endian, 7 bits per byte, the ninth byte whole.
+// It returns 0 bytes read when p is too short.
+func uvarint(p []byte) (uint64, int) {
+	var v uint64
+	for i := 0; i < 9; i++ {
+		if i >= len(p) {
+			return 0, 0
+		}
+		if i == 8 {
+			return v<<8 | uint64(p[i]), 9
+		}
+		v = v<<7 | uint64(p[i]&0x7f)
+		if p[i]&0x80 == 0 {
+			return v, i + 1
+		}
+	}
+	return v, 9
+}
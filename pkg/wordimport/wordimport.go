@@ -0,0 +1,348 @@
+// Package wordimport parses word lists exported from spreadsheets and Anki.
+//
+// CSV and TSV files need a header row naming the columns: lemma, translation and,
+// optionally, part_of_speech, examples (separated by "|") and difficulty. Anki plain
+// text exports ("Notes in Plain Text") and .apkg packages are read as lemma from the
+// first note field and translation from the second; HTML in the fields is stripped.
+// Parsing is separate from validation: rows come back as written, numbered by their
+// position in the source, and the caller decides which of them are acceptable.
+//
+// ParseReviews reads review logs from other apps the same way; see ReviewRow.
+package wordimport
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Format names a supported source format.
+type Format string
+
+const (
+	FormatCSV      Format = "csv"
+	FormatTSV      Format = "tsv"
+	FormatAnkiText Format = "anki_text"
+	FormatAPKG     Format = "apkg"
+)
+
+// maxCollectionSize caps the unpacked size of an Anki collection, so a small
+// package cannot expand into an arbitrarily large database image.
+const maxCollectionSize = 256 << 20
+
+var (
+	ErrFormat = errors.New("wordimport: unsupported format")
+	ErrHeader = errors.New("wordimport: header must name the lemma and translation columns")
+	ErrEmpty  = errors.New("wordimport: no rows")
+)
+
+// Row is one word as read from the source. Line is the 1-based line of a text file
+// or the 1-based note number of an Anki package.
+type Row struct {
+	Line         int
+	Lemma        string
+	Translation  string
+	PartOfSpeech string
+	Examples     []string
+	Difficulty   string
+}
+
+// DetectFormat guesses the format from a file name, returning "" when it can't.
+func DetectFormat(filename string) Format {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return FormatCSV
+	case ".tsv", ".tab":
+		return FormatTSV
+	case ".txt":
+		return FormatAnkiText
+	case ".apkg", ".colpkg":
+		return FormatAPKG
+	}
+	return ""
+}
+
+// Valid reports whether f is a supported format.
+func (f Format) Valid() bool {
+	switch f {
+	case FormatCSV, FormatTSV, FormatAnkiText, FormatAPKG:
+		return true
+	}
+	return false
+}
+
+// Parse reads every row of data in the given format.
+func Parse(format Format, data []byte) ([]Row, error) {
+	var (
+		rows []Row
+		err  error
+	)
+	switch format {
+	case FormatCSV:
+		rows, err = parseDelimited(data, ',')
+	case FormatTSV:
+		rows, err = parseDelimited(data, '\t')
+	case FormatAnkiText:
+		rows, err = parseAnkiText(data)
+	case FormatAPKG:
+		rows, err = parseAPKG(data)
+	default:
+		return nil, ErrFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, ErrEmpty
+	}
+	return rows, nil
+}
+
+const examplesSeparator = "|"
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+func parseDelimited(data []byte, comma rune) ([]Row, error) {
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, utf8BOM)))
+	r.Comma = comma
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+
+	header, err := r.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, ErrEmpty
+		}
+		return nil, err
+	}
+	cols := map[string]int{}
+	for i, name := range header {
+		cols[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := cols["lemma"]; !ok {
+		return nil, ErrHeader
+	}
+	if _, ok := cols["translation"]; !ok {
+		return nil, ErrHeader
+	}
+	field := func(record []string, name string) string {
+		if i, ok := cols[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var rows []Row
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := r.FieldPos(0)
+		if blank(record) {
+			continue
+		}
+		row := Row{
+			Line:         line,
+			Lemma:        field(record, "lemma"),
+			Translation:  field(record, "translation"),
+			PartOfSpeech: field(record, "part_of_speech"),
+			Difficulty:   field(record, "difficulty"),
+		}
+		if examples := field(record, "examples"); examples != "" {
+			for _, e := range strings.Split(examples, examplesSeparator) {
+				if e = strings.TrimSpace(e); e != "" {
+					row.Examples = append(row.Examples, e)
+				}
+			}
+		}
+		rows = append(rows, row)
+	}
+}
+
+// parseAnkiText reads a "Notes in Plain Text" export. The file may start with
+// "#key:value" lines; #separator and #html are honoured, the rest are skipped.
+func parseAnkiText(data []byte) ([]Row, error) {
+	data = bytes.TrimPrefix(data, utf8BOM)
+	comma := '\t'
+	stripHTML := true
+
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	skipped := 0
+	for _, line := range lines {
+		text := strings.TrimRight(string(line), "\r\n")
+		if !strings.HasPrefix(text, "#") {
+			break
+		}
+		key, value, _ := strings.Cut(text[1:], ":")
+		switch strings.ToLower(key) {
+		case "separator":
+			switch strings.ToLower(value) {
+			case "tab":
+				comma = '\t'
+			case "comma":
+				comma = ','
+			case "semicolon":
+				comma = ';'
+			case "space":
+				comma = ' '
+			case "pipe":
+				comma = '|'
+			case "colon":
+				comma = ':'
+			default:
+				if len(value) == 1 {
+					comma = rune(value[0])
+				}
+			}
+		case "html":
+			stripHTML = value != "false"
+		}
+		skipped++
+	}
+
+	r := csv.NewReader(bytes.NewReader(bytes.Join(lines[skipped:], nil)))
+	r.Comma = comma
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+
+	var rows []Row
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := r.FieldPos(0)
+		if blank(record) {
+			continue
+		}
+		rows = append(rows, noteRow(skipped+line, record, stripHTML))
+	}
+}
+
+// parseAPKG reads the notes of an Anki package. Newer Anki versions store a
+// zstd-compressed collection.anki21b next to a stub collection.anki2, so the
+// newest collection present wins.
+func parseAPKG(data []byte) ([]Row, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("wordimport: apkg: %w", err)
+	}
+	files := map[string]*zip.File{}
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	var collection []byte
+	for _, name := range []string{"collection.anki21b", "collection.anki21", "collection.anki2"} {
+		f, ok := files[name]
+		if !ok {
+			continue
+		}
+		if collection, err = readZipFile(f); err != nil {
+			return nil, fmt.Errorf("wordimport: apkg: %w", err)
+		}
+		if name == "collection.anki21b" {
+			dec, err := zstd.NewReader(nil, zstd.WithDecoderMaxMemory(maxCollectionSize))
+			if err != nil {
+				return nil, err
+			}
+			collection, err = dec.DecodeAll(collection, nil)
+			dec.Close()
+			if err != nil {
+				return nil, fmt.Errorf("wordimport: apkg: %w", err)
+			}
+		}
+		break
+	}
+	if collection == nil {
+		return nil, errors.New("wordimport: apkg: no collection in package")
+	}
+
+	db, err := openSQLite(collection)
+	if err != nil {
+		return nil, fmt.Errorf("wordimport: apkg: %w", err)
+	}
+	var rows []Row
+	err = db.table("notes", func(values []any) error {
+		// notes: id, guid, mid, mod, usn, tags, flds, sfld, csum, flags, data
+		if len(values) < 7 {
+			return errSQLiteCorrupt
+		}
+		flds, _ := values[6].(string)
+		rows = append(rows, noteRow(len(rows)+1, strings.Split(flds, "\x1f"), true))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("wordimport: apkg: %w", err)
+	}
+	return rows, nil
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(io.LimitReader(rc, maxCollectionSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxCollectionSize {
+		return nil, fmt.Errorf("%s is larger than %d bytes", f.Name, maxCollectionSize)
+	}
+	return data, nil
+}
+
+func noteRow(line int, fields []string, stripHTML bool) Row {
+	clean := func(i int) string {
+		if i >= len(fields) {
+			return ""
+		}
+		s := fields[i]
+		if stripHTML {
+			s = plainText(s)
+		}
+		return strings.TrimSpace(s)
+	}
+	return Row{Line: line, Lemma: clean(0), Translation: clean(1)}
+}
+
+var (
+	htmlBreak = regexp.MustCompile(`(?i)<br\s*/?>|</?div[^>]*>`)
+	htmlTag   = regexp.MustCompile(`<[^>]*>`)
+	spaces    = regexp.MustCompile(`\s+`)
+)
+
+// plainText drops markup from an Anki field, keeping line breaks as spaces.
+func plainText(s string) string {
+	s = htmlBreak.ReplaceAllString(s, " ")
+	s = htmlTag.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+	return spaces.ReplaceAllString(s, " ")
+}
+
+func blank(record []string) bool {
+	for _, f := range record {
+		if strings.TrimSpace(f) != "" {
+			return false
+		}
+	}
+	return true
+}
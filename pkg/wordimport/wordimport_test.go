@@ -0,0 +1,319 @@
+package wordimport
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+func TestParse_CSV(t *testing.T) {
+	data := "\xEF\xBB\xBFLemma,translation,part_of_speech,examples,difficulty\n" +
+		"house,дом,noun,\"a big house|my house\",2\n" +
+		"\n" +
+		"run,бежать\n" +
+		",,,,\n" +
+		"\"say \"\"hi\"\"\",сказать,verb,,\n"
+
+	rows, err := Parse(FormatCSV, []byte(data))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("got %d rows, want 3: %+v", len(rows), rows)
+	}
+	first := rows[0]
+	if first.Line != 2 || first.Lemma != "house" || first.Translation != "дом" || first.PartOfSpeech != "noun" ||
+		first.Difficulty != "2" || len(first.Examples) != 2 || first.Examples[1] != "my house" {
+		t.Fatalf("first row = %+v", first)
+	}
+	if rows[1].Line != 4 || rows[1].Lemma != "run" || rows[1].PartOfSpeech != "" {
+		t.Fatalf("short row = %+v", rows[1])
+	}
+	if rows[2].Line != 6 || rows[2].Lemma != `say "hi"` {
+		t.Fatalf("quoted row = %+v", rows[2])
+	}
+}
+
+func TestParse_TSVHeaderRequired(t *testing.T) {
+	if _, err := Parse(FormatTSV, []byte("word\tmeaning\nhouse\tдом\n")); !errors.Is(err, ErrHeader) {
+		t.Fatalf("err = %v, want ErrHeader", err)
+	}
+	if _, err := Parse(FormatTSV, []byte("lemma\ttranslation\n")); !errors.Is(err, ErrEmpty) {
+		t.Fatalf("err = %v, want ErrEmpty", err)
+	}
+	rows, err := Parse(FormatTSV, []byte("translation\tlemma\nдом\thouse\n"))
+	if err != nil || len(rows) != 1 || rows[0].Lemma != "house" {
+		t.Fatalf("rows = %+v, err = %v", rows, err)
+	}
+}
+
+func TestParse_AnkiText(t *testing.T) {
+	data := "#separator:Semicolon\n#html:true\n#notetype column:3\n" +
+		"<b>house</b>;дом<br/>здание;Basic\n" +
+		"\"fish &amp; chips\";рыба\n"
+
+	rows, err := Parse(FormatAnkiText, []byte(data))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+	if rows[0].Line != 4 || rows[0].Lemma != "house" || rows[0].Translation != "дом здание" {
+		t.Fatalf("first row = %+v", rows[0])
+	}
+	if rows[1].Lemma != "fish & chips" {
+		t.Fatalf("second row = %+v", rows[1])
+	}
+}
+
+func TestParse_APKG(t *testing.T) {
+	collection, err := os.ReadFile("testdata/collection.anki2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc, err := zstd.NewWriter(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	compressed := enc.EncodeAll(collection, nil)
+	enc.Close()
+
+	tests := []struct {
+		name  string
+		files map[string][]byte
+	}{
+		{"legacy", map[string][]byte{"collection.anki2": collection, "media": []byte("{}")}},
+		// A stub anki2 that must be ignored in favour of the real collection.
+		{"anki21b", map[string][]byte{"collection.anki2": []byte("stub"), "collection.anki21b": compressed}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := Parse(FormatAPKG, zipFiles(t, tt.files))
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			// The fixture spans interior b-tree pages and an overflowing note.
+			if len(rows) != 300 {
+				t.Fatalf("got %d rows, want 300", len(rows))
+			}
+			if rows[0].Lemma != "house" || rows[0].Translation != "дом здание" {
+				t.Fatalf("first row = %+v", rows[0])
+			}
+			if rows[1].Lemma != "fish & chips" || !strings.HasSuffix(rows[1].Translation, strings.Repeat("x", 1500)) {
+				t.Fatalf("overflow row lemma %q, translation length %d", rows[1].Lemma, len(rows[1].Translation))
+			}
+			if last := rows[299]; last.Line != 300 || last.Lemma != "word300" || last.Translation != "слово300" {
+				t.Fatalf("last row = %+v", last)
+			}
+		})
+	}
+}
+
+func TestParse_APKGRejectsGarbage(t *testing.T) {
+	if _, err := Parse(FormatAPKG, []byte("not a zip")); err == nil {
+		t.Fatal("expected an error for a non-zip package")
+	}
+	if _, err := Parse(FormatAPKG, zipFiles(t, map[string][]byte{"collection.anki2": []byte("SQLite format 3\x00 short")})); err == nil {
+		t.Fatal("expected an error for a corrupt collection")
+	}
+}
+
+func TestOpenSQLite_RejectsCorruptImages(t *testing.T) {
+	huge := bytes.Repeat([]byte{0xff}, 9) // the largest 9-byte varint
+	tests := []struct {
+		name  string
+		image []byte
+	}{
+		{"huge serial type", sqliteImage(leafPage(1, append([]byte{10, 1, 10}, huge...)))},
+		{"huge record header", sqliteImage(leafPage(1, append([]byte{9, 1}, huge...)))},
+		{"huge payload", sqliteImage(leafPage(1, append(append([]byte{}, huge...), 1, 0)))},
+		{"huge root page", sqliteImage(leafPage(1, masterCell([]byte{0x7f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})))},
+		// 1000 bytes keep 39 locally and need two overflow pages, but page 2 links to itself.
+		{"overflow loop", sqliteImage(leafPage(1, overflowCell(1000, 39, 2)), overflowPage(2), make([]byte, 512))},
+		{"interior loop", sqliteImage(interiorPage(1, 1))},
+		{"shared child", sqliteImage(interiorPage(1, 2, 2), leafPage(2))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, err := openSQLite(tt.image)
+			if err != nil {
+				t.Fatalf("openSQLite: %v", err)
+			}
+			if err := db.table("notes", func([]any) error { return nil }); !errors.Is(err, errSQLiteCorrupt) {
+				t.Fatalf("err = %v, want %v", err, errSQLiteCorrupt)
+			}
+		})
+	}
+}
+
+func FuzzOpenSQLite(f *testing.F) {
+	collection, err := os.ReadFile("testdata/collection.anki2")
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(collection)
+	f.Add(sqliteImage(leafPage(1, overflowCell(1000, 39, 2)), overflowPage(2), make([]byte, 512)))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		db, err := openSQLite(data)
+		if err != nil {
+			return
+		}
+		_ = db.table("notes", func([]any) error { return nil })
+	})
+}
+
+// sqliteImage joins 512-byte pages into a database image, writing the file header
+// into the first one.
+func sqliteImage(pages ...[]byte) []byte {
+	data := bytes.Join(pages, nil)
+	copy(data, sqliteMagic)
+	data[16], data[17] = 0x02, 0x00
+	return data
+}
+
+// leafPage lays out a table leaf page n holding cells, packed from the end of the page.
+func leafPage(n int, cells ...[]byte) []byte {
+	p, h := make([]byte, 512), pageHeader(n)
+	p[h] = 0x0d
+	p[h+4] = byte(len(cells))
+	end := len(p)
+	for i, c := range cells {
+		end -= len(c)
+		copy(p[end:], c)
+		p[h+8+2*i], p[h+9+2*i] = byte(end>>8), byte(end)
+	}
+	return p
+}
+
+// interiorPage lays out a table interior page n whose cells point at children and
+// whose right-most pointer is right.
+func interiorPage(n int, right int, children ...int) []byte {
+	p, h := make([]byte, 512), pageHeader(n)
+	p[h] = 0x05
+	p[h+4] = byte(len(children))
+	p[h+11] = byte(right)
+	for i, child := range children {
+		off := 500 - 8*i
+		p[off+3], p[off+4] = byte(child), 1 // child page, then rowid 1
+		p[h+12+2*i], p[h+13+2*i] = byte(off>>8), byte(off)
+	}
+	return p
+}
+
+func overflowPage(next int) []byte {
+	p := make([]byte, 512)
+	p[3] = byte(next)
+	return p
+}
+
+func pageHeader(n int) int {
+	if n == 1 {
+		return 100
+	}
+	return 0
+}
+
+// overflowCell is a leaf cell announcing a record of total bytes, local of them on the
+// page and the rest starting at overflow page next.
+func overflowCell(total, local, next int) []byte {
+	cell := []byte{byte(0x80 | total>>7), byte(total & 0x7f), 1}
+	cell = append(cell, make([]byte, local)...)
+	return append(cell, 0, 0, 0, byte(next))
+}
+
+// masterCell is an sqlite_master row for the notes table rooted at the 8-byte
+// big-endian page number root.
+func masterCell(root []byte) []byte {
+	rec := []byte{5, 23, 23, 23, 6}
+	rec = append(rec, "tablenotesnotes"...)
+	rec = append(rec, root...)
+	return append([]byte{byte(len(rec)), 1}, rec...)
+}
+
+func TestDetectFormat(t *testing.T) {
+	for name, want := range map[string]Format{
+		"words.CSV":    FormatCSV,
+		"words.tsv":    FormatTSV,
+		"deck.txt":     FormatAnkiText,
+		"deck.apkg":    FormatAPKG,
+		"words.xlsx":   "",
+		"no-extension": "",
+	} {
+		if got := DetectFormat(name); got != want {
+			t.Errorf("DetectFormat(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func zipFiles(t *testing.T, files map[string][]byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, data := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestParseReviews(t *testing.T) {
+	data := "Lemma\ttimestamp\tresult\textra\n" +
+		"house\t2024-03-01T10:00:00+02:00\tgood\tx\n" +
+		"house\t2024-03-01 08:05:00\tAgain\n" +
+		"run\t1709287200000\tfail\n" +
+		"\n" +
+		"tree\tyesterday\tmaybe\n"
+
+	rows, err := ParseReviews([]byte(data))
+	if err != nil {
+		t.Fatalf("ParseReviews: %v", err)
+	}
+	if len(rows) != 4 || rows[0].Word != "house" || rows[3].Line != 6 {
+		t.Fatalf("rows = %+v", rows)
+	}
+
+	want := []struct {
+		at      time.Time
+		correct bool
+		quality int16
+	}{
+		{time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC), true, 4},
+		{time.Date(2024, 3, 1, 8, 5, 0, 0, time.UTC), false, 1},
+		{time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC), false, 1},
+	}
+	for i, w := range want {
+		at, err := rows[i].Time()
+		if err != nil || !at.Equal(w.at) {
+			t.Errorf("row %d time = %v, %v; want %v", i, at, err, w.at)
+		}
+		correct, quality, err := rows[i].Grade()
+		if err != nil || correct != w.correct || quality != w.quality {
+			t.Errorf("row %d grade = %v, %d, %v; want %v, %d", i, correct, quality, err, w.correct, w.quality)
+		}
+	}
+	if _, err := rows[3].Time(); !errors.Is(err, ErrTimestamp) {
+		t.Errorf("bad timestamp err = %v", err)
+	}
+	if _, _, err := rows[3].Grade(); !errors.Is(err, ErrResult) {
+		t.Errorf("bad result err = %v", err)
+	}
+
+	if _, err := ParseReviews([]byte("word,when,result\nhouse,1,good\n")); !errors.Is(err, ErrReviewHeader) {
+		t.Fatalf("err = %v, want ErrReviewHeader", err)
+	}
+}